@@ -8,23 +8,55 @@ import (
 )
 
 type Handler struct {
-	UserHandler         UserHandler
-	AuthHandler         AuthHandler
-	ProfileHandler      ProfileHandler
-	ReservationHandler  ReservationHandler
-	InventoryLogHandler InventoryLogHandler
-	CategoryHandler     CategoryHandler
-	ProductHandler      ProductHandler
+	UserHandler          UserHandler
+	AuthHandler          AuthHandler
+	ProfileHandler       ProfileHandler
+	ReservationHandler   ReservationHandler
+	InventoryLogHandler  InventoryLogHandler
+	CategoryHandler      CategoryHandler
+	ProductHandler       ProductHandler
+	ModifierHandler      ModifierHandler
+	OrderHandler         OrderHandler
+	IngredientHandler    IngredientHandler
+	SupplierHandler      SupplierHandler
+	PurchaseOrderHandler PurchaseOrderHandler
+	StockTakeHandler     StockTakeHandler
+	ShiftHandler         ShiftHandler
+	AttendanceHandler    AttendanceHandler
+	CashDrawerHandler    CashDrawerHandler
+	ReportHandler        ReportHandler
+	TransactionHandler   TransactionHandler
+	ReceiptHandler       ReceiptHandler
+	KitchenHandler       KitchenHandler
+	RoleHandler          RoleHandler
+	AuditHandler         AuditHandler
+	UploadHandler        UploadHandler
 }
 
 func NewHandler(u *usecase.Usecase, log *zap.Logger, config utils.Configuration) Handler {
 	return Handler{
-		UserHandler:         NewUserHandler(u.UserService, log, config),
-		AuthHandler:         NewAuthHandler(u.AuthService, log, config),
-		ProfileHandler:      NewProfileHandler(u.ProfileService, log, config),
-		ReservationHandler:  NewReservationHandler(u.ReservationService, log, config),
-		InventoryLogHandler: NewInventoryLogHandler(u.InventoryLogService, log, config),
-		CategoryHandler:     *NewCategoryHandler(u.CategoryService, log),
-		ProductHandler:      *NewProductHandler(u.ProductService, log),
+		UserHandler:          NewUserHandler(u.UserService, log, config),
+		AuthHandler:          NewAuthHandler(u.AuthService, log, config),
+		ProfileHandler:       NewProfileHandler(u.ProfileService, log, config),
+		ReservationHandler:   NewReservationHandler(u.ReservationService, log, config),
+		InventoryLogHandler:  NewInventoryLogHandler(u.InventoryLogService, log, config),
+		CategoryHandler:      *NewCategoryHandler(u.CategoryService, log),
+		ProductHandler:       *NewProductHandler(u.ProductService, log),
+		ModifierHandler:      NewModifierHandler(u.ModifierService, log, config),
+		OrderHandler:         NewOrderHandler(u.OrderService, log, config),
+		IngredientHandler:    NewIngredientHandler(u.IngredientService, log, config),
+		SupplierHandler:      NewSupplierHandler(u.SupplierService, log, config),
+		PurchaseOrderHandler: NewPurchaseOrderHandler(u.PurchaseOrderService, log, config),
+		StockTakeHandler:     NewStockTakeHandler(u.StockTakeService, log, config),
+		ShiftHandler:         NewShiftHandler(u.ShiftService, log, config),
+		AttendanceHandler:    NewAttendanceHandler(u.AttendanceService, log, config),
+		CashDrawerHandler:    NewCashDrawerHandler(u.CashDrawerService, log, config),
+		ReportHandler:        NewReportHandler(u.ReportService, log, config),
+		TransactionHandler:   NewTransactionHandler(u.TransactionService, log, config),
+		ReceiptHandler:       NewReceiptHandler(u.ReceiptService, log, config),
+		KitchenHandler:       NewKitchenHandler(u.KitchenService, log, config),
+		RoleHandler:          NewRoleHandler(u.PermissionService, log, config),
+		AuditHandler:         NewAuditHandler(u.AuditService, log, config),
+		UploadHandler:        NewUploadHandler(u.UploadService, log, config),
 	}
 }
@@ -0,0 +1,236 @@
+package adaptor
+
+import (
+	"fmt"
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AttendanceHandler struct {
+	service usecase.AttendanceService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewAttendanceHandler(service usecase.AttendanceService, log *zap.Logger, config utils.Configuration) AttendanceHandler {
+	return AttendanceHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "attendance")),
+		config:  config,
+	}
+}
+
+// ClockIn opens a punch for the logged in user
+func (h *AttendanceHandler) ClockIn(c *gin.Context) {
+	var req request.ClockRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	attendance, err := h.service.ClockIn(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to clock in")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Clocked in successfully", attendance)
+}
+
+// ClockOut closes the logged in user's open punch
+func (h *AttendanceHandler) ClockOut(c *gin.Context) {
+	var req request.ClockRequest
+	if !h.bindOptionalJSON(c, &req) {
+		return
+	}
+
+	attendance, err := h.service.ClockOut(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to clock out")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Clocked out successfully", attendance)
+}
+
+// GetMyAttendance lists the logged in user's punches
+func (h *AttendanceHandler) GetMyAttendance(c *gin.Context) {
+	var req request.GetAttendanceRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	attendances, pagination, err := h.service.GetMyAttendance(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get attendance")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Attendance retrieved successfully", attendances, pagination)
+}
+
+// GetAttendance lists punches for all staff
+func (h *AttendanceHandler) GetAttendance(c *gin.Context) {
+	var req request.GetAttendanceRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	attendances, pagination, err := h.service.GetAttendance(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get attendance")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Attendance retrieved successfully", attendances, pagination)
+}
+
+// GetAttendanceByID gets a punch with its correction history
+func (h *AttendanceHandler) GetAttendanceByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.service.GetAttendanceByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get attendance")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Attendance retrieved successfully", attendance)
+}
+
+// CorrectAttendance lets a manager fix a punch, recording the reason
+func (h *AttendanceHandler) CorrectAttendance(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.CorrectAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	attendance, err := h.service.CorrectAttendance(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to correct attendance")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Attendance corrected successfully", attendance)
+}
+
+// GetTimesheet returns hours and estimated labor cost per staff member for a
+// pay period, as JSON or as a download with format=csv or format=xlsx
+func (h *AttendanceHandler) GetTimesheet(c *gin.Context) {
+	var req request.TimesheetRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	timesheet, err := h.service.GetTimesheet(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get timesheet")
+		return
+	}
+
+	if !utils.IsExportFormat(req.Format) {
+		utils.ResponseSuccess(c, http.StatusOK, "Timesheet retrieved successfully", timesheet)
+		return
+	}
+
+	name := fmt.Sprintf("timesheet-%s-%s", timesheet.StartDate, timesheet.EndDate)
+	err = streamExport(c, h.logger, req.Format, name, func(w utils.ExportWriter) error {
+		return writeTimesheet(w, timesheet)
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to export timesheet")
+	}
+}
+
+func writeTimesheet(w utils.ExportWriter, timesheet *response.TimesheetResponse) error {
+	err := w.WriteRow("profile_id", "full_name", "days_worked", "worked_hours", "overtime_hours", "late_count", "late_minutes",
+		"early_leave_count", "open_punches", "monthly_salary", "hourly_rate", "labor_cost")
+	if err != nil {
+		return err
+	}
+
+	for _, e := range timesheet.Entries {
+		err := w.WriteRow(e.ProfileID, e.FullName, e.DaysWorked, e.WorkedHours, e.OvertimeHours, e.LateCount, e.LateMinutes,
+			e.EarlyLeaveCount, e.OpenPunches, e.MonthlySalary, e.HourlyRate, e.LaborCost)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.WriteRow(nil, "TOTAL", nil, timesheet.TotalHours, nil, nil, nil, nil, nil, nil, nil, timesheet.TotalLaborCost)
+}
+
+// bindOptionalJSON accepts an empty body so clock punches can be sent without notes
+func (h *AttendanceHandler) bindOptionalJSON(c *gin.Context, req *request.ClockRequest) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *AttendanceHandler) bindQuery(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *AttendanceHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid attendance ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *AttendanceHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
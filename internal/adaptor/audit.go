@@ -0,0 +1,51 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuditHandler struct {
+	service usecase.AuditService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewAuditHandler(service usecase.AuditService, log *zap.Logger, config utils.Configuration) AuditHandler {
+	return AuditHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "audit")),
+		config:  config,
+	}
+}
+
+// GetAuditLogs lists audit entries, newest first, filtered by actor, entity,
+// action and date
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	var req request.GetAuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	logs, pagination, err := h.service.GetAuditLogs(c, req)
+	if err != nil {
+		h.logger.Error("Failed to get audit logs", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Audit logs retrieved successfully", logs, pagination)
+}
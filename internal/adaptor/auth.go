@@ -5,6 +5,7 @@ import (
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/usecase"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -28,66 +29,115 @@ func NewAuthHandler(service usecase.AuthService, log *zap.Logger, config utils.C
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
 	res, err := h.service.Login(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusUnauthorized, "login failed", err)
+		h.handleError(c, err, "login failed")
 		return
 	}
 
+	if res.TwoFactor != nil {
+		utils.ResponseSuccess(c, http.StatusOK, "two-factor verification required", res)
+		return
+	}
 	utils.ResponseSuccess(c, http.StatusOK, "login success", res)
 }
 
+// VerifyLogin finishes a login that needs a second factor
+func (h *AuthHandler) VerifyLogin(c *gin.Context) {
+	var req request.VerifyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.VerifyLogin(c, req)
+	if err != nil {
+		h.handleError(c, err, "login failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "login success", res)
+}
+
+// SendLoginCode emails a code for a login that needs a second factor
+func (h *AuthHandler) SendLoginCode(c *gin.Context) {
+	var req request.LoginCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	if err := h.service.SendLoginCode(c, req); err != nil {
+		h.handleError(c, err, "send login code failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "login code sent", nil)
+}
+
 func (h *AuthHandler) RequestResetPassword(c *gin.Context) {
 	var req request.ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	result, err := h.service.RequestResetPassword(c, req.Email)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "request reset password failed", err)
+	// Known and unknown emails get the same answer
+	req.IPAddress = c.ClientIP()
+	if err := h.service.RequestResetPassword(c, req); err != nil {
+		h.handleError(c, err, "request reset password failed")
 		return
 	}
 
-	utils.ResponseSuccess(c, http.StatusOK, "request reset password success", result)
+	utils.ResponseSuccess(c, http.StatusOK, "if the email is registered, a reset code and link have been sent", nil)
 }
 
 func (h *AuthHandler) ValidateOTP(c *gin.Context) {
 	var req request.ValidateOTP
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
 	result, err := h.service.ValidateOTP(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "validate otp failed", err)
+		h.handleError(c, err, "validate otp failed")
 		return
 	}
 
@@ -97,20 +147,18 @@ func (h *AuthHandler) ValidateOTP(c *gin.Context) {
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req request.ResetPassword
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	err = h.service.ResetPassword(c, req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "reset password failed", err)
+	if err := h.service.ResetPassword(c, req); err != nil {
+		h.handleError(c, err, "reset password failed")
 		return
 	}
 
@@ -120,16 +168,228 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	auth := c.GetHeader("Authorization")
 	if !strings.HasPrefix(auth, "Bearer ") {
-		utils.ResponseFailed(c, http.StatusUnauthorized, "missing token", nil)
+		utils.ResponseError(c, utils.ErrMissingToken)
 		return
 	}
 
 	token := strings.TrimPrefix(auth, "Bearer ")
 	err := h.service.Logout(c, token)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "logout failed", err)
+		h.handleError(c, err, "logout failed")
 		return
 	}
 
 	utils.ResponseSuccess(c, http.StatusOK, "logout success", nil)
-}
\ No newline at end of file
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req request.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	// Validation
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	res, err := h.service.Refresh(c, req)
+	if err != nil {
+		h.handleError(c, err, "refresh token failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "refresh token success", res)
+}
+
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	res, err := h.service.ListSessions(c)
+	if err != nil {
+		h.handleError(c, err, "get sessions failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get sessions success", res)
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	err = h.service.RevokeSession(c, uint(id))
+	if err != nil {
+		h.handleError(c, err, "revoke session failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "revoke session success", nil)
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	res, err := h.service.RevokeOtherSessions(c)
+	if err != nil {
+		h.handleError(c, err, "revoke sessions failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "revoke sessions success", res)
+}
+
+// ForceLogout lets an admin end every session of another user
+func (h *AuthHandler) ForceLogout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	res, err := h.service.ForceLogout(c, uint(id))
+	if err != nil {
+		h.handleError(c, err, "force logout failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "force logout success", res)
+}
+
+// UnlockAccount lets an admin lift a lockout caused by failed logins
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	if err := h.service.UnlockAccount(c, uint(id)); err != nil {
+		h.handleError(c, err, "unlock account failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "unlock account success", nil)
+}
+
+func (h *AuthHandler) SetManagerPIN(c *gin.Context) {
+	var req request.SetManagerPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if err := h.service.SetManagerPIN(c, req); err != nil {
+		h.handleError(c, err, "set manager pin failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "set manager pin success", nil)
+}
+
+func (h *AuthHandler) GetTwoFactorStatus(c *gin.Context) {
+	res, err := h.service.GetTwoFactorStatus(c)
+	if err != nil {
+		h.handleError(c, err, "get two-factor status failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get two-factor status success", res)
+}
+
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	var req request.SetupTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.SetupTwoFactor(c, req)
+	if err != nil {
+		h.handleError(c, err, "setup two-factor failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "scan the code with your authenticator app, then enable two-factor", res)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req request.EnableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.EnableTwoFactor(c, req)
+	if err != nil {
+		h.handleError(c, err, "enable two-factor failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "two-factor enabled, store the recovery codes safely", res)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req request.TwoFactorConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if err := h.service.DisableTwoFactor(c, req); err != nil {
+		h.handleError(c, err, "disable two-factor failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "two-factor disabled", nil)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req request.TwoFactorConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.RegenerateRecoveryCodes(c, req)
+	if err != nil {
+		h.handleError(c, err, "regenerate recovery codes failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "recovery codes regenerated, store them safely", res)
+}
+
+func (h *AuthHandler) handleError(c *gin.Context, err error, message string) {
+	h.Logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
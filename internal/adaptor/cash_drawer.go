@@ -0,0 +1,193 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CashDrawerHandler struct {
+	service usecase.CashDrawerService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewCashDrawerHandler(service usecase.CashDrawerService, log *zap.Logger, config utils.Configuration) CashDrawerHandler {
+	return CashDrawerHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "cash_drawer")),
+		config:  config,
+	}
+}
+
+// OpenDrawer opens a till for the logged in cashier with a starting float
+func (h *CashDrawerHandler) OpenDrawer(c *gin.Context) {
+	var req request.OpenCashDrawerRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	drawer, err := h.service.OpenDrawer(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to open cash drawer")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Cash drawer opened successfully", drawer)
+}
+
+// GetCurrentDrawer gets the logged in cashier's open till with live totals
+func (h *CashDrawerHandler) GetCurrentDrawer(c *gin.Context) {
+	drawer, err := h.service.GetCurrentDrawer(c)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cash drawer")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Cash drawer retrieved successfully", drawer)
+}
+
+// GetDrawers lists cash drawer sessions
+func (h *CashDrawerHandler) GetDrawers(c *gin.Context) {
+	var req request.GetCashDrawersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	drawers, pagination, err := h.service.GetDrawers(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cash drawers")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Cash drawers retrieved successfully", drawers, pagination)
+}
+
+// GetDrawerByID gets a cash drawer session by ID
+func (h *CashDrawerHandler) GetDrawerByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	drawer, err := h.service.GetDrawerByID(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get cash drawer")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Cash drawer retrieved successfully", drawer)
+}
+
+// PayIn records cash added to the drawer
+func (h *CashDrawerHandler) PayIn(c *gin.Context) {
+	h.recordMovement(c, entity.CashMovementPayIn)
+}
+
+// PayOut records cash taken out of the drawer
+func (h *CashDrawerHandler) PayOut(c *gin.Context) {
+	h.recordMovement(c, entity.CashMovementPayOut)
+}
+
+func (h *CashDrawerHandler) recordMovement(c *gin.Context, movementType entity.CashMovementType) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.CashMovementRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	drawer, err := h.service.RecordMovement(c, id, movementType, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record cash movement")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Cash movement recorded successfully", drawer)
+}
+
+// CloseDrawer records the counted cash and returns the Z-report
+func (h *CashDrawerHandler) CloseDrawer(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.CloseCashDrawerRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.service.CloseDrawer(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to close cash drawer")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Cash drawer closed successfully", report)
+}
+
+// GetZReport summarizes a session's sales by payment method
+func (h *CashDrawerHandler) GetZReport(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetZReport(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get Z-report")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Z-report retrieved successfully", report)
+}
+
+func (h *CashDrawerHandler) bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *CashDrawerHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid cash drawer ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *CashDrawerHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
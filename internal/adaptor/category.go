@@ -45,7 +45,7 @@ func (ch *CategoryHandler) GetAllCategories(c *gin.Context) {
 	// Bind query parameters
 	if err := c.ShouldBindQuery(&req); err != nil {
 		ch.log.Warn("Failed to bind query parameters", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid query parameters", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
@@ -60,13 +60,13 @@ func (ch *CategoryHandler) GetAllCategories(c *gin.Context) {
 	// Validate pagination (Bad Request example)
 	if req.Page <= 0 {
 		ch.log.Warn("Invalid page number", zap.Int("page", req.Page))
-		utils.ResponseFailed(c, 400, "Page must be greater than 0", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "page", Code: "min", Param: "1", Message: "page must be greater than 0"}))
 		return
 	}
 
 	if req.PerPage <= 0 || req.PerPage > 100 {
 		ch.log.Warn("Invalid per_page value", zap.Int("per_page", req.PerPage))
-		utils.ResponseFailed(c, 400, "Per page must be between 1 and 100", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "per_page", Code: "range", Param: "1-100", Message: "per_page must be between 1 and 100"}))
 		return
 	}
 
@@ -74,7 +74,7 @@ func (ch *CategoryHandler) GetAllCategories(c *gin.Context) {
 	result, err := ch.srv.GetAllCategories(req)
 	if err != nil {
 		ch.log.Error("Failed to get categories", zap.Error(err))
-		utils.ResponseFailed(c, 500, "Failed to retrieve categories", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -111,7 +111,7 @@ func (ch *CategoryHandler) GetCategoryByID(c *gin.Context) {
 			zap.String("id", idStr),
 			zap.Error(err),
 			zap.String("error_type", "invalid_id_format"))
-		utils.ResponseFailed(c, 400, "Invalid category ID. Must be a positive integer", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -120,11 +120,7 @@ func (ch *CategoryHandler) GetCategoryByID(c *gin.Context) {
 	if err != nil {
 		ch.log.Error("Failed to get category", zap.Uint("id", uint(id)), zap.Error(err))
 
-		if err == utils.ErrCategoryNotFound {
-			utils.ResponseFailed(c, 404, "Category not found", nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to get category", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -149,30 +145,25 @@ func (ch *CategoryHandler) CreateCategory(c *gin.Context) {
 	// Bind JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
 		ch.log.Warn("Failed to bind request body", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validate
-	if validationErrors, err := utils.ValidateErrors(req); err != nil {
+	if err := utils.Validate(req); err != nil {
 		ch.log.Warn("Request validation failed",
-			zap.Any("errors", validationErrors),
 			zap.Error(err))
 
-		utils.ResponseFailed(c, 400, "Validation failed", validationErrors)
+		utils.ResponseError(c, err)
 		return
 	}
 
 	// Call service
-	category, err := ch.srv.CreateCategory(req)
+	category, err := ch.srv.CreateCategory(c, req)
 	if err != nil {
 		ch.log.Error("Failed to create category", zap.Error(err))
 
-		if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to create category", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -204,7 +195,7 @@ func (ch *CategoryHandler) UpdateCategory(c *gin.Context) {
 			zap.String("id", idStr),
 			zap.Error(err),
 			zap.String("error_type", "invalid_id_format"))
-		utils.ResponseFailed(c, 400, "Invalid category ID. Must be a positive integer", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -213,7 +204,7 @@ func (ch *CategoryHandler) UpdateCategory(c *gin.Context) {
 		ch.log.Warn("Failed to bind request body",
 			zap.Error(err),
 			zap.String("client_ip", c.ClientIP())) // 🔥 Optional: tambah client IP
-		utils.ResponseFailed(c, 400, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
@@ -222,21 +213,21 @@ func (ch *CategoryHandler) UpdateCategory(c *gin.Context) {
 		ch.log.Warn("No changes provided for update",
 			zap.Uint("id", uint(id)),
 			zap.String("client_ip", c.ClientIP()))
-		utils.ResponseFailed(c, 400, "No changes provided", nil)
+		utils.ResponseError(c, utils.ErrNoChangesProvided)
 		return
 	}
 
 	// Validate
-	if validationErrors, err := utils.ValidateErrors(req); err != nil {
+	if err := utils.Validate(req); err != nil {
 		ch.log.Warn("Request validation failed",
-			zap.Any("errors", validationErrors),
+			zap.Error(err),
 			zap.String("client_ip", c.ClientIP()))
-		utils.ResponseFailed(c, 400, "Validation failed", validationErrors)
+		utils.ResponseError(c, err)
 		return
 	}
 
 	// Call service
-	category, err := ch.srv.UpdateCategory(uint(id), req)
+	category, err := ch.srv.UpdateCategory(c, uint(id), req)
 	if err != nil {
 		ch.log.Error("Failed to update category",
 			zap.Uint("id", uint(id)),
@@ -245,16 +236,7 @@ func (ch *CategoryHandler) UpdateCategory(c *gin.Context) {
 			zap.String("client_ip", c.ClientIP()))
 
 		// 🔥 SATU error handling block saja
-		if err == utils.ErrCategoryNotFound {
-			utils.ResponseFailed(c, 404, "Category not found", nil)
-		} else if err.Error() == "no changes provided" {
-			// Ini bisa dari service jika validasi di service
-			utils.ResponseFailed(c, 400, "No changes provided", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to update category", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -286,21 +268,15 @@ func (ch *CategoryHandler) DeleteCategory(c *gin.Context) {
 		ch.log.Warn("Invalid category ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid category ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
 	// Call service
-	if err := ch.srv.DeleteCategory(uint(id)); err != nil {
+	if err := ch.srv.DeleteCategory(c, uint(id)); err != nil {
 		ch.log.Error("Failed to delete category", zap.Uint("id", uint(id)), zap.Error(err))
 
-		if err == utils.ErrCategoryNotFound {
-			utils.ResponseFailed(c, 404, "Category not found", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to delete category", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
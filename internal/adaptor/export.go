@@ -0,0 +1,45 @@
+package adaptor
+
+import (
+	"fmt"
+	"net/http"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// streamExport sends a CSV or XLSX download filled row by row by write. An
+// error is returned only while nothing has reached the client yet, so the
+// caller can still answer with a JSON error; later failures are logged and
+// the download is cut short.
+func streamExport(c *gin.Context, logger *zap.Logger, format, name string, write func(w utils.ExportWriter) error) error {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", utils.ExportContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w, err := utils.NewExportWriter(c.Writer, format)
+	if err == nil {
+		// Close flushes buffered rows, so skip it on failure to keep an
+		// unsent response reportable
+		if err = write(w); err == nil {
+			err = w.Close()
+		}
+	}
+	if err == nil {
+		return nil
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		return err
+	}
+
+	logger.Error("Export aborted after streaming started",
+		zap.String("file", filename),
+		zap.Error(err))
+	return nil
+}
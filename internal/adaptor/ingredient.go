@@ -0,0 +1,281 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type IngredientHandler struct {
+	service usecase.IngredientService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewIngredientHandler(service usecase.IngredientService, log *zap.Logger, config utils.Configuration) IngredientHandler {
+	return IngredientHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "ingredient")),
+		config:  config,
+	}
+}
+
+// CreateIngredient creates a new raw ingredient
+func (h *IngredientHandler) CreateIngredient(c *gin.Context) {
+	var req request.CreateIngredientRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	ingredient, err := h.service.CreateIngredient(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create ingredient")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Ingredient created successfully", ingredient)
+}
+
+// GetIngredients lists ingredients, optionally only those running low
+func (h *IngredientHandler) GetIngredients(c *gin.Context) {
+	var req request.GetIngredientsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	ingredients, pagination, err := h.service.GetIngredients(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get ingredients")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Ingredients retrieved successfully", ingredients, pagination)
+}
+
+// GetLowStockIngredients lists ingredients at or below their minimum stock
+func (h *IngredientHandler) GetLowStockIngredients(c *gin.Context) {
+	var req request.GetIngredientsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+	req.LowStock = true
+
+	ingredients, pagination, err := h.service.GetIngredients(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get low stock ingredients")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Low stock ingredients retrieved successfully", ingredients, pagination)
+}
+
+// GetIngredientByID gets an ingredient by ID
+func (h *IngredientHandler) GetIngredientByID(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid ingredient ID")
+	if !ok {
+		return
+	}
+
+	ingredient, err := h.service.GetIngredientByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get ingredient")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Ingredient retrieved successfully", ingredient)
+}
+
+// UpdateIngredient updates ingredient details (stock changes go through AdjustStock)
+func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid ingredient ID")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateIngredientRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	ingredient, err := h.service.UpdateIngredient(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update ingredient")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Ingredient updated successfully", ingredient)
+}
+
+// DeleteIngredient deletes an ingredient and removes it from recipes
+func (h *IngredientHandler) DeleteIngredient(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid ingredient ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteIngredient(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete ingredient")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Ingredient deleted successfully", nil)
+}
+
+// AdjustStock restocks or adjusts an ingredient and records the log
+func (h *IngredientHandler) AdjustStock(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid ingredient ID")
+	if !ok {
+		return
+	}
+
+	var req request.AdjustIngredientStockRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	log, err := h.service.AdjustStock(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to adjust ingredient stock")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Ingredient stock adjusted successfully", log)
+}
+
+// GetIngredientLogs lists stock movements of an ingredient
+func (h *IngredientHandler) GetIngredientLogs(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid ingredient ID")
+	if !ok {
+		return
+	}
+
+	var req request.PaginationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	logs, pagination, err := h.service.GetIngredientLogs(c.Request.Context(), id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get ingredient logs")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Ingredient logs retrieved successfully", logs, pagination)
+}
+
+// GetProductRecipe gets the ingredients used by one unit of a product
+func (h *IngredientHandler) GetProductRecipe(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	recipe, err := h.service.GetProductRecipe(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get product recipe")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Product recipe retrieved successfully", recipe)
+}
+
+// SetProductRecipe replaces the recipe of a product
+func (h *IngredientHandler) SetProductRecipe(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req request.SetRecipeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	recipe, err := h.service.SetProductRecipe(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to set product recipe")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Product recipe updated successfully", recipe)
+}
+
+// GetModifierRecipe gets the extra ingredients used by a modifier option
+func (h *IngredientHandler) GetModifierRecipe(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier option ID")
+	if !ok {
+		return
+	}
+
+	recipe, err := h.service.GetModifierRecipe(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get modifier recipe")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier recipe retrieved successfully", recipe)
+}
+
+// SetModifierRecipe replaces the recipe of a modifier option
+func (h *IngredientHandler) SetModifierRecipe(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier option ID")
+	if !ok {
+		return
+	}
+
+	var req request.SetRecipeRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	recipe, err := h.service.SetModifierRecipe(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to set modifier recipe")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier recipe updated successfully", recipe)
+}
+
+func (h *IngredientHandler) bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *IngredientHandler) parseID(c *gin.Context, message string) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn(message, zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *IngredientHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
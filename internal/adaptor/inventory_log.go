@@ -5,6 +5,7 @@ import (
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/usecase"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -26,9 +27,30 @@ func NewInventoryLogHandler(service usecase.InventoryLogService, log *zap.Logger
 
 func (h *InventoryLogHandler) GetInventoryLogs(c *gin.Context) {
 	var req request.InventoryLogsFilter
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	// Validation
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if utils.IsExportFormat(req.Format) {
+		err := streamExport(c, h.Logger, req.Format, "inventory-logs", func(w utils.ExportWriter) error {
+			return h.service.ExportInventoryLogs(c, req, w)
+		})
+		if err != nil {
+			utils.ResponseError(c, err)
+		}
+		return
+	}
+
 	result, err := h.service.GetInventoryLogs(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadGateway, "get inventory logs failed", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -38,22 +60,50 @@ func (h *InventoryLogHandler) GetInventoryLogs(c *gin.Context) {
 func (h *InventoryLogHandler) CreateInventoryLog(c *gin.Context) {
 	var req request.CreateInventoryLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	res, err := h.service.CreateInventoryLog(c.Request.Context(), req)
+	res, err := h.service.CreateInventoryLog(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "create inventory log failed", err)
+		utils.ResponseError(c, err)
 		return
 	}
 
 	utils.ResponseSuccess(c, http.StatusCreated, "create inventory log success", res)
+}
+
+func (h *InventoryLogHandler) GetProductStockHistory(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	var req request.StockHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	// Validation
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.GetProductStockHistory(c.Request.Context(), uint(productID), req)
+	if err != nil {
+		h.Logger.Error("get product stock history failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get product stock history success", res)
 }
\ No newline at end of file
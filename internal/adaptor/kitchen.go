@@ -0,0 +1,98 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type KitchenHandler struct {
+	service usecase.KitchenService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewKitchenHandler(service usecase.KitchenService, log *zap.Logger, config utils.Configuration) KitchenHandler {
+	return KitchenHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "kitchen")),
+		config:  config,
+	}
+}
+
+// GetQueue lists the items waiting in the kitchen, grouped by station
+func (h *KitchenHandler) GetQueue(c *gin.Context) {
+	var req request.KitchenQueueRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	stations, err := h.service.GetQueue(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get kitchen queue")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Kitchen queue retrieved successfully", stations)
+}
+
+// BumpItem marks an order item as ready
+func (h *KitchenHandler) BumpItem(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.BumpItem(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to bump item")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Item bumped successfully", result)
+}
+
+// RecallItem puts a bumped order item back in the queue
+func (h *KitchenHandler) RecallItem(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.RecallItem(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to recall item")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Item recalled successfully", result)
+}
+
+func (h *KitchenHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid order item ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *KitchenHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,204 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ModifierHandler struct {
+	service usecase.ModifierService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewModifierHandler(service usecase.ModifierService, log *zap.Logger, config utils.Configuration) ModifierHandler {
+	return ModifierHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "modifier")),
+		config:  config,
+	}
+}
+
+// CreateGroup adds a modifier group to a product
+func (h *ModifierHandler) CreateGroup(c *gin.Context) {
+	productID, ok := h.parseID(c, "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req request.CreateModifierGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	group, err := h.service.CreateGroup(c, productID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create modifier group")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Modifier group created successfully", group)
+}
+
+// GetGroupsByProduct lists the modifier groups of a product
+func (h *ModifierHandler) GetGroupsByProduct(c *gin.Context) {
+	productID, ok := h.parseID(c, "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	groups, err := h.service.GetGroupsByProduct(c.Request.Context(), productID)
+	if err != nil {
+		h.handleError(c, err, "Failed to get modifier groups")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier groups retrieved successfully", groups)
+}
+
+// UpdateGroup updates a modifier group
+func (h *ModifierHandler) UpdateGroup(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier group ID")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateModifierGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	group, err := h.service.UpdateGroup(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update modifier group")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier group updated successfully", group)
+}
+
+// DeleteGroup deletes a modifier group and its options
+func (h *ModifierHandler) DeleteGroup(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier group ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteGroup(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete modifier group")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier group deleted successfully", nil)
+}
+
+// CreateOption adds an option to a modifier group
+func (h *ModifierHandler) CreateOption(c *gin.Context) {
+	groupID, ok := h.parseID(c, "Invalid modifier group ID")
+	if !ok {
+		return
+	}
+
+	var req request.CreateModifierOptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	option, err := h.service.CreateOption(c, groupID, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create modifier option")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Modifier option created successfully", option)
+}
+
+// UpdateOption updates a modifier option
+func (h *ModifierHandler) UpdateOption(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier option ID")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateModifierOptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	option, err := h.service.UpdateOption(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update modifier option")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier option updated successfully", option)
+}
+
+// DeleteOption deletes a modifier option
+func (h *ModifierHandler) DeleteOption(c *gin.Context) {
+	id, ok := h.parseID(c, "Invalid modifier option ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOption(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete modifier option")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Modifier option deleted successfully", nil)
+}
+
+func (h *ModifierHandler) parseID(c *gin.Context, message string) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn(message, zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *ModifierHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,236 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type OrderHandler struct {
+	service usecase.OrderService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewOrderHandler(service usecase.OrderService, log *zap.Logger, config utils.Configuration) OrderHandler {
+	return OrderHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "order")),
+		config:  config,
+	}
+}
+
+// CreateOrder creates a new order with its items
+func (h *OrderHandler) CreateOrder(c *gin.Context) {
+	var req request.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.Error(err),
+			zap.String("path", c.Request.URL.Path))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	order, err := h.service.CreateOrder(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create order")
+		return
+	}
+
+	h.logger.Info("Order created successfully",
+		zap.Uint("order_id", order.ID),
+		zap.String("order_number", order.OrderNumber))
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Order created successfully", order)
+}
+
+// GetOrders gets list of orders
+func (h *OrderHandler) GetOrders(c *gin.Context) {
+	var req request.GetOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if utils.IsExportFormat(req.Format) {
+		err := streamExport(c, h.logger, req.Format, "orders", func(w utils.ExportWriter) error {
+			return h.service.ExportOrders(c.Request.Context(), req, w)
+		})
+		if err != nil {
+			h.handleError(c, err, "Failed to export orders")
+		}
+		return
+	}
+
+	orders, pagination, err := h.service.GetOrders(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get orders")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Orders retrieved successfully", orders, pagination)
+}
+
+// GetOrderByID gets an order by ID
+func (h *OrderHandler) GetOrderByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	order, err := h.service.GetOrderByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Order retrieved successfully", order)
+}
+
+// AddOrderItem adds an item to an open order
+func (h *OrderHandler) AddOrderItem(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.OrderItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	order, err := h.service.AddOrderItem(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to add order item")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Order item added successfully", order)
+}
+
+// UpdateOrderStatus moves an order through its lifecycle
+func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	order, err := h.service.UpdateOrderStatus(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update order status")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Order status updated successfully", order)
+}
+
+func (h *OrderHandler) ApplyDiscount(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ApplyDiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	order, err := h.service.ApplyDiscount(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to apply discount")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Discount applied successfully", order)
+}
+
+func (h *OrderHandler) RefundOrder(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.RefundOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	refund, err := h.service.RefundOrder(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to refund order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Order refunded successfully", refund)
+}
+
+func (h *OrderHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid order ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *OrderHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
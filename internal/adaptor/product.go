@@ -43,27 +43,23 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	// Bind JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Warn("Failed to bind request body", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validate
-	if err := req.Validate(); err != nil {
+	if err := utils.Validate(req); err != nil {
 		h.log.Warn("Request validation failed", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Validation failed", err.Error())
+		utils.ResponseError(c, err)
 		return
 	}
 
 	// Call service
-	product, err := h.srv.CreateProduct(req)
+	product, err := h.srv.CreateProduct(c, req)
 	if err != nil {
 		h.log.Error("Failed to create product", zap.Error(err))
 
-		if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to create product", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -103,7 +99,7 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 	// Bind query parameters
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.log.Warn("Failed to bind query parameters", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid query parameters", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
@@ -118,38 +114,38 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 	// Validate pagination
 	if req.Page <= 0 {
 		h.log.Warn("Invalid page number", zap.Int("page", req.Page))
-		utils.ResponseFailed(c, 400, "Page must be greater than 0", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "page", Code: "min", Param: "1", Message: "page must be greater than 0"}))
 		return
 	}
 
 	if req.Limit <= 0 || req.Limit > 100 {
 		h.log.Warn("Invalid limit value", zap.Int("limit", req.Limit))
-		utils.ResponseFailed(c, 400, "Limit must be between 1 and 100", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "limit", Code: "range", Param: "1-100", Message: "limit must be between 1 and 100"}))
 		return
 	}
 
 	// Validate sort parameters
 	if req.SortByStock != "" && req.SortByStock != "asc" && req.SortByStock != "desc" {
 		h.log.Warn("Invalid sort_by_stock value", zap.String("sort_by_stock", req.SortByStock))
-		utils.ResponseFailed(c, 400, "sort_by_stock must be 'asc' or 'desc'", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "sort_by_stock", Code: "oneof", Param: "asc desc", Message: "sort_by_stock must be 'asc' or 'desc'"}))
 		return
 	}
 
 	if req.SortByPrice != "" && req.SortByPrice != "asc" && req.SortByPrice != "desc" {
 		h.log.Warn("Invalid sort_by_price value", zap.String("sort_by_price", req.SortByPrice))
-		utils.ResponseFailed(c, 400, "sort_by_price must be 'asc' or 'desc'", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "sort_by_price", Code: "oneof", Param: "asc desc", Message: "sort_by_price must be 'asc' or 'desc'"}))
 		return
 	}
 
 	if req.SortByCreatedAt != "" && req.SortByCreatedAt != "asc" && req.SortByCreatedAt != "desc" {
 		h.log.Warn("Invalid sort_by_created_at value", zap.String("sort_by_created_at", req.SortByCreatedAt))
-		utils.ResponseFailed(c, 400, "sort_by_created_at must be 'asc' or 'desc'", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "sort_by_created_at", Code: "oneof", Param: "asc desc", Message: "sort_by_created_at must be 'asc' or 'desc'"}))
 		return
 	}
 
 	if req.SortBySold != "" && req.SortBySold != "asc" && req.SortBySold != "desc" {
 		h.log.Warn("Invalid sort_by_sold value", zap.String("sort_by_sold", req.SortBySold))
-		utils.ResponseFailed(c, 400, "sort_by_sold must be 'asc' or 'desc'", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "sort_by_sold", Code: "oneof", Param: "asc desc", Message: "sort_by_sold must be 'asc' or 'desc'"}))
 		return
 	}
 
@@ -158,7 +154,7 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 		h.log.Warn("Invalid price range",
 			zap.Float64("min_price", req.MinPrice),
 			zap.Float64("max_price", req.MaxPrice))
-		utils.ResponseFailed(c, 400, "min_price cannot be greater than max_price", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "min_price", Code: "ltefield", Param: "max_price", Message: "min_price cannot be greater than max_price"}))
 		return
 	}
 
@@ -173,7 +169,7 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 	result, err := h.srv.GetAllProducts(req)
 	if err != nil {
 		h.log.Error("Failed to get products", zap.Error(err))
-		utils.ResponseFailed(c, 500, "Failed to retrieve products", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -209,7 +205,7 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 		h.log.Warn("Invalid product ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid product ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -218,11 +214,7 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 	if err != nil {
 		h.log.Error("Failed to get product", zap.Uint("id", uint(id)), zap.Error(err))
 
-		if err == utils.ErrProductNotFound {
-			utils.ResponseFailed(c, 404, "Product not found", nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to get product", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -248,21 +240,21 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil || id == 0 {
 		h.log.Warn("Invalid product ID", zap.String("id", idStr), zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid product ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
 	var req request.UpdateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Warn("Failed to bind request body", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validate
-	if err := req.Validate(); err != nil {
+	if err := utils.Validate(req); err != nil {
 		h.log.Warn("Request validation failed", zap.Error(err))
-		utils.ResponseFailed(c, 400, "Validation failed", err.Error())
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -271,28 +263,18 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		req.Stock == 0 && req.MinStock == 0 && req.ImageURL == "" &&
 		req.Status == "" && req.CategoryID == 0 {
 		h.log.Warn("No changes provided for update", zap.Uint("id", uint(id)))
-		utils.ResponseFailed(c, 400, "No changes provided", nil)
+		utils.ResponseError(c, utils.ErrNoChangesProvided)
 		return
 	}
 
 	// Call service
-	product, err := h.srv.UpdateProduct(uint(id), req)
+	product, err := h.srv.UpdateProduct(c, uint(id), req)
 	if err != nil {
 		h.log.Error("Failed to update product",
 			zap.Uint("id", uint(id)),
 			zap.Error(err))
 
-		if err == utils.ErrProductNotFound {
-			utils.ResponseFailed(c, 404, "Product not found", nil)
-		} else if err == utils.ErrCategoryNotFound {
-			utils.ResponseFailed(c, 400, "Category not found", nil)
-		} else if err == utils.ErrNoChangesProvided {
-			utils.ResponseFailed(c, 400, "No changes provided", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to update product", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -320,23 +302,15 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil || id == 0 {
 		h.log.Warn("Invalid product ID", zap.String("id", idStr), zap.Error(err))
-		utils.ResponseFailed(c, 400, "Invalid product ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
 	// Call service
-	if err := h.srv.DeleteProduct(uint(id)); err != nil {
+	if err := h.srv.DeleteProduct(c, uint(id)); err != nil {
 		h.log.Error("Failed to delete product", zap.Uint("id", uint(id)), zap.Error(err))
 
-		if err == utils.ErrProductNotFound {
-			utils.ResponseFailed(c, 404, "Product not found", nil)
-		} else if err == utils.ErrProductHasOrders {
-			utils.ResponseFailed(c, 400, "Cannot delete product with associated orders", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, 400, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, 500, "Failed to delete product", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
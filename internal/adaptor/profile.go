@@ -5,6 +5,7 @@ import (
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/usecase"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -27,7 +28,7 @@ func NewProfileHandler(service usecase.ProfileService, log *zap.Logger, config u
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
 	result, err := h.service.GetProfile(c)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadGateway, "get profile failed", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -37,22 +38,112 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 	var req request.ProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	err = h.service.UpdateProfile(c, &req)
+	err := h.service.UpdateProfile(c, &req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "update profile failed", err)
+		h.handleError(c, err, "update profile failed")
 		return
 	}
 
 	utils.ResponseSuccess(c, http.StatusCreated, "update profile success", nil)
-}
\ No newline at end of file
+}
+
+func (h *ProfileHandler) GetStaffProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	res, err := h.service.GetStaffProfile(c, uint(id))
+	if err != nil {
+		h.handleError(c, err, "get staff profile failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get staff profile success", res)
+}
+
+func (h *ProfileHandler) UpdateStaffProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	var req request.StaffProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.UpdateStaffProfile(c, uint(id), req)
+	if err != nil {
+		h.handleError(c, err, "update staff profile failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "update staff profile success", res)
+}
+
+func (h *ProfileHandler) ChangeSalary(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	var req request.SalaryChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.ChangeSalary(c, uint(id), req)
+	if err != nil {
+		h.handleError(c, err, "change salary failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "change salary success", res)
+}
+
+func (h *ProfileHandler) GetSalaryHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	res, err := h.service.GetSalaryHistory(c, uint(id))
+	if err != nil {
+		h.handleError(c, err, "get salary history failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get salary history success", res)
+}
+
+func (h *ProfileHandler) handleError(c *gin.Context, err error, message string) {
+	h.Logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,182 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PurchaseOrderHandler struct {
+	service usecase.PurchaseOrderService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewPurchaseOrderHandler(service usecase.PurchaseOrderService, log *zap.Logger, config utils.Configuration) PurchaseOrderHandler {
+	return PurchaseOrderHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "purchase_order")),
+		config:  config,
+	}
+}
+
+// CreatePurchaseOrder creates a draft purchase order
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req request.CreatePurchaseOrderRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	po, err := h.service.CreatePurchaseOrder(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Purchase order created successfully", po)
+}
+
+// GetPurchaseOrders gets list of purchase orders
+func (h *PurchaseOrderHandler) GetPurchaseOrders(c *gin.Context) {
+	var req request.GetPurchaseOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	pos, pagination, err := h.service.GetPurchaseOrders(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get purchase orders")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Purchase orders retrieved successfully", pos, pagination)
+}
+
+// GetPurchaseOrderByID gets a purchase order by ID
+func (h *PurchaseOrderHandler) GetPurchaseOrderByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	po, err := h.service.GetPurchaseOrderByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Purchase order retrieved successfully", po)
+}
+
+// UpdatePurchaseOrder updates a draft purchase order
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdatePurchaseOrderRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	po, err := h.service.UpdatePurchaseOrder(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Purchase order updated successfully", po)
+}
+
+// SubmitPurchaseOrder marks a draft purchase order as ordered
+func (h *PurchaseOrderHandler) SubmitPurchaseOrder(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	po, err := h.service.SubmitPurchaseOrder(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to submit purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Purchase order submitted successfully", po)
+}
+
+// ReceivePurchaseOrder records a (partial) delivery and restocks products
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ReceivePurchaseOrderRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	po, err := h.service.ReceivePurchaseOrder(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to receive purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Purchase order received successfully", po)
+}
+
+// CancelPurchaseOrder cancels a purchase order that has not been received
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	po, err := h.service.CancelPurchaseOrder(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to cancel purchase order")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Purchase order cancelled successfully", po)
+}
+
+func (h *PurchaseOrderHandler) bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *PurchaseOrderHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid purchase order ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *PurchaseOrderHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,106 @@
+package adaptor
+
+import (
+	"fmt"
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReceiptHandler struct {
+	service usecase.ReceiptService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewReceiptHandler(service usecase.ReceiptService, log *zap.Logger, config utils.Configuration) ReceiptHandler {
+	return ReceiptHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "receipt")),
+		config:  config,
+	}
+}
+
+// GetReceipt returns the receipt of a completed order as JSON, or rendered as
+// html, pdf or escpos printer bytes
+func (h *ReceiptHandler) GetReceipt(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ReceiptRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if req.Format == "" || req.Format == "json" {
+		receipt, err := h.service.GetReceipt(c.Request.Context(), id)
+		if err != nil {
+			h.handleError(c, err, "Failed to get receipt")
+			return
+		}
+		utils.ResponseSuccess(c, http.StatusOK, "Receipt retrieved successfully", receipt)
+		return
+	}
+
+	body, contentType, err := h.service.RenderReceipt(c.Request.Context(), id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to render receipt")
+		return
+	}
+
+	disposition := "inline"
+	extension := req.Format
+	if req.Format == "escpos" {
+		disposition = "attachment"
+		extension = "bin"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`%s; filename="receipt-%d.%s"`, disposition, id, extension))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// EmailReceipt sends the receipt of a completed order to the customer's email
+func (h *ReceiptHandler) EmailReceipt(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.EmailReceipt(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to email receipt")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Receipt sent successfully", result)
+}
+
+func (h *ReceiptHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid order ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *ReceiptHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,231 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	service usecase.ReportService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewReportHandler(service usecase.ReportService, log *zap.Logger, config utils.Configuration) ReportHandler {
+	return ReportHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "report")),
+		config:  config,
+	}
+}
+
+// GetDashboard returns the headline figures for the admin dashboard
+func (h *ReportHandler) GetDashboard(c *gin.Context) {
+	var req request.ReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+	if utils.IsExportFormat(req.Format) {
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "format", Code: "oneof", Param: "json", Message: "dashboard is only available as json"}))
+		return
+	}
+
+	dashboard, err := h.service.GetDashboard(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get dashboard")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Dashboard retrieved successfully", dashboard)
+}
+
+// GetSummary returns revenue, order count and average ticket
+func (h *ReportHandler) GetSummary(c *gin.Context) {
+	var req request.ReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	summary, err := h.service.GetSummary(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get sales summary")
+		return
+	}
+
+	h.respond(c, req.Format, "sales-summary", "Sales summary retrieved successfully", summary, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("start_date", "end_date", "timezone", "order_count", "subtotal", "tax_amount", "revenue", "average_ticket"); err != nil {
+			return err
+		}
+		return w.WriteRow(summary.StartDate, summary.EndDate, summary.Timezone, summary.OrderCount,
+			summary.Subtotal, summary.TaxAmount, summary.Revenue, summary.AverageTicket)
+	})
+}
+
+// GetRevenue returns revenue per day, week or month
+func (h *ReportHandler) GetRevenue(c *gin.Context) {
+	var req request.RevenueReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	revenue, err := h.service.GetRevenue(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get revenue report")
+		return
+	}
+
+	h.respond(c, req.Format, "revenue", "Revenue report retrieved successfully", revenue, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("period_start", "order_count", "revenue", "average_ticket"); err != nil {
+			return err
+		}
+		for _, p := range revenue.Points {
+			if err := w.WriteRow(p.PeriodStart, p.OrderCount, p.Revenue, p.AverageTicket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetSalesByCategory returns quantity and revenue per category
+func (h *ReportHandler) GetSalesByCategory(c *gin.Context) {
+	var req request.ReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	sales, err := h.service.GetSalesByCategory(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get sales by category")
+		return
+	}
+
+	h.respond(c, req.Format, "sales-by-category", "Sales by category retrieved successfully", sales, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("category_id", "category_name", "quantity", "revenue", "revenue_share"); err != nil {
+			return err
+		}
+		for _, s := range sales {
+			if err := w.WriteRow(s.CategoryID, s.CategoryName, s.Quantity, s.Revenue, s.RevenueShare); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetSalesByProduct returns the best selling products
+func (h *ReportHandler) GetSalesByProduct(c *gin.Context) {
+	var req request.ProductSalesReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	sales, err := h.service.GetSalesByProduct(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get sales by product")
+		return
+	}
+
+	h.respond(c, req.Format, "sales-by-product", "Sales by product retrieved successfully", sales, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("product_id", "product_name", "category_name", "quantity", "revenue"); err != nil {
+			return err
+		}
+		for _, s := range sales {
+			if err := w.WriteRow(s.ProductID, s.ProductName, s.CategoryName, s.Quantity, s.Revenue); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetSalesByPaymentMethod returns payments and refunds per payment method
+func (h *ReportHandler) GetSalesByPaymentMethod(c *gin.Context) {
+	var req request.ReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	sales, err := h.service.GetSalesByPaymentMethod(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get sales by payment method")
+		return
+	}
+
+	h.respond(c, req.Format, "sales-by-payment-method", "Sales by payment method retrieved successfully", sales, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("payment_method_id", "payment_method_name", "transaction_count", "payments", "refunds", "net", "share"); err != nil {
+			return err
+		}
+		for _, s := range sales {
+			if err := w.WriteRow(s.PaymentMethodID, s.PaymentMethodName, s.TransactionCount, s.Payments, s.Refunds, s.Net, s.Share); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetPeakHours returns orders and revenue for each hour of the day
+func (h *ReportHandler) GetPeakHours(c *gin.Context) {
+	var req request.ReportRequest
+	if !h.bindQuery(c, &req) {
+		return
+	}
+
+	hours, err := h.service.GetPeakHours(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get peak hours")
+		return
+	}
+
+	h.respond(c, req.Format, "peak-hours", "Peak hours retrieved successfully", hours, func(w utils.ExportWriter) error {
+		if err := w.WriteRow("hour", "order_count", "revenue", "average_ticket"); err != nil {
+			return err
+		}
+		for _, hour := range hours {
+			if err := w.WriteRow(hour.Hour, hour.OrderCount, hour.Revenue, hour.AverageTicket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// respond writes data as JSON, or as a csv/xlsx table through rows when an
+// export format was requested
+func (h *ReportHandler) respond(c *gin.Context, format, name, message string, data any, rows func(w utils.ExportWriter) error) {
+	if !utils.IsExportFormat(format) {
+		utils.ResponseSuccess(c, http.StatusOK, message, data)
+		return
+	}
+
+	if err := streamExport(c, h.logger, format, name, rows); err != nil {
+		h.handleError(c, err, "Failed to export report")
+	}
+}
+
+func (h *ReportHandler) bindQuery(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *ReportHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -33,29 +33,25 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 		h.logger.Warn("Invalid request body",
 			zap.Error(err),
 			zap.String("path", c.Request.URL.Path))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validate request menggunakan utils dari kamu
-	if validationErrors, err := utils.ValidateErrors(req); err != nil {
+	if err := utils.Validate(req); err != nil {
 		h.logger.Warn("Validation failed",
-			zap.Any("errors", validationErrors))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Validation failed", validationErrors)
+			zap.Error(err))
+		utils.ResponseError(c, err)
 		return
 	}
 
-	reservation, err := h.service.CreateReservation(c.Request.Context(), req)
+	reservation, err := h.service.CreateReservation(c, req)
 	if err != nil {
 		h.logger.Error("Failed to create reservation",
 			zap.Error(err),
 			zap.String("customer_phone", req.Customer.Phone))
 
-		if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to create reservation", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -74,7 +70,24 @@ func (h *ReservationHandler) GetReservations(c *gin.Context) {
 		h.logger.Warn("Invalid query parameters",
 			zap.Error(err),
 			zap.String("path", c.Request.URL.Path))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if utils.IsExportFormat(req.Format) {
+		err := streamExport(c, h.logger, req.Format, "reservations", func(w utils.ExportWriter) error {
+			return h.service.ExportReservations(c.Request.Context(), req, w)
+		})
+		if err != nil {
+			h.logger.Error("Failed to export reservations", zap.Error(err))
+			utils.ResponseError(c, err)
+		}
 		return
 	}
 
@@ -83,7 +96,7 @@ func (h *ReservationHandler) GetReservations(c *gin.Context) {
 		h.logger.Error("Failed to get reservations",
 			zap.Error(err),
 			zap.Any("filters", req))
-		utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to get reservations", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -103,7 +116,7 @@ func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
 		h.logger.Warn("Invalid reservation ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid reservation ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -113,11 +126,7 @@ func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
 			zap.Uint("id", uint(id)),
 			zap.Error(err))
 
-		if err == utils.ErrReservationNotFound {
-			utils.ResponseFailed(c, http.StatusNotFound, "Reservation not found", nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to get reservation", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -132,7 +141,7 @@ func (h *ReservationHandler) UpdateReservationStatus(c *gin.Context) {
 		h.logger.Warn("Invalid reservation ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid reservation ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -141,31 +150,25 @@ func (h *ReservationHandler) UpdateReservationStatus(c *gin.Context) {
 		h.logger.Warn("Invalid request body",
 			zap.Error(err),
 			zap.String("path", c.Request.URL.Path))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validate request
-	if validationErrors, err := utils.ValidateErrors(req); err != nil {
+	if err := utils.Validate(req); err != nil {
 		h.logger.Warn("Validation failed",
-			zap.Any("errors", validationErrors))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Validation failed", validationErrors)
+			zap.Error(err))
+		utils.ResponseError(c, err)
 		return
 	}
 
-	if err := h.service.UpdateReservationStatus(c.Request.Context(), uint(id), req.Status); err != nil {
+	if err := h.service.UpdateReservationStatus(c, uint(id), req.Status); err != nil {
 		h.logger.Error("Failed to update reservation status",
 			zap.Uint("id", uint(id)),
 			zap.String("status", req.Status),
 			zap.Error(err))
 
-		if err == utils.ErrReservationNotFound {
-			utils.ResponseFailed(c, http.StatusNotFound, "Reservation not found", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to update reservation status", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -184,7 +187,7 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 		h.logger.Warn("Invalid reservation ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid reservation ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
@@ -195,23 +198,17 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("Invalid request body",
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
-	if err := h.service.CancelReservation(c.Request.Context(), uint(id), req.Reason); err != nil {
+	if err := h.service.CancelReservation(c, uint(id), req.Reason); err != nil {
 		h.logger.Error("Failed to cancel reservation",
 			zap.Uint("id", uint(id)),
 			zap.String("reason", req.Reason),
 			zap.Error(err))
 
-		if err == utils.ErrReservationNotFound {
-			utils.ResponseFailed(c, http.StatusNotFound, "Reservation not found", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to cancel reservation", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -230,22 +227,16 @@ func (h *ReservationHandler) CheckIn(c *gin.Context) {
 		h.logger.Warn("Invalid reservation ID",
 			zap.String("id", idStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "Invalid reservation ID", nil)
+		utils.ResponseError(c, utils.InvalidParam("id"))
 		return
 	}
 
-	if err := h.service.CheckIn(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.CheckIn(c, uint(id)); err != nil {
 		h.logger.Error("Failed to check in reservation",
 			zap.Uint("id", uint(id)),
 			zap.Error(err))
 
-		if err == utils.ErrReservationNotFound {
-			utils.ResponseFailed(c, http.StatusNotFound, "Reservation not found", nil)
-		} else if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to check in reservation", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -259,12 +250,18 @@ func (h *ReservationHandler) GetAvailableTables(c *gin.Context) {
 	time := c.Query("time")
 	paxStr := c.Query("pax")
 
-	if date == "" || time == "" || paxStr == "" {
+	var missing []utils.FieldError
+	for name, value := range map[string]string{"date": date, "time": time, "pax": paxStr} {
+		if value == "" {
+			missing = append(missing, utils.FieldError{Field: name, Code: "required", Message: name + " is required"})
+		}
+	}
+	if len(missing) > 0 {
 		h.logger.Warn("Missing required parameters",
 			zap.String("date", date),
 			zap.String("time", time),
 			zap.String("pax", paxStr))
-		utils.ResponseFailed(c, http.StatusBadRequest, "date, time, and pax parameters are required", nil)
+		utils.ResponseError(c, utils.ErrInvalidParameter.WithFields(missing...))
 		return
 	}
 
@@ -273,7 +270,7 @@ func (h *ReservationHandler) GetAvailableTables(c *gin.Context) {
 		h.logger.Warn("Invalid pax parameter",
 			zap.String("pax", paxStr),
 			zap.Error(err))
-		utils.ResponseFailed(c, http.StatusBadRequest, "pax must be a positive integer", nil)
+		utils.ResponseError(c, utils.ParamError(utils.FieldError{Field: "pax", Code: "min", Param: "1", Message: "pax must be a positive integer"}))
 		return
 	}
 
@@ -285,11 +282,7 @@ func (h *ReservationHandler) GetAvailableTables(c *gin.Context) {
 			zap.Int("pax", pax),
 			zap.Error(err))
 
-		if utils.IsBusinessError(err) {
-			utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), nil)
-		} else {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "Failed to get available tables", nil)
-		}
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -0,0 +1,189 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	service usecase.PermissionService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewRoleHandler(service usecase.PermissionService, log *zap.Logger, config utils.Configuration) RoleHandler {
+	return RoleHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "role")),
+		config:  config,
+	}
+}
+
+// GetPermissions lists every permission a role can be granted
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	permissions, err := h.service.ListPermissions(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "Failed to get permissions")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Permissions retrieved successfully", permissions)
+}
+
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	roles, err := h.service.ListRoles(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "Failed to get roles")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Roles retrieved successfully", roles)
+}
+
+func (h *RoleHandler) GetRoleByID(c *gin.Context) {
+	id, ok := h.parseID(c, "role")
+	if !ok {
+		return
+	}
+
+	role, err := h.service.GetRole(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get role")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Role retrieved successfully", role)
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req request.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	role, err := h.service.CreateRole(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create role")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Role created successfully", role)
+}
+
+// UpdateRole replaces the permissions of a role
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	id, ok := h.parseID(c, "role")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	role, err := h.service.UpdateRole(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update role")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Role updated successfully", role)
+}
+
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	id, ok := h.parseID(c, "role")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRole(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete role")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// GetUserPermissions shows what a user may do and which overrides apply
+func (h *RoleHandler) GetUserPermissions(c *gin.Context) {
+	id, ok := h.parseID(c, "user")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetUserPermissions(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get user permissions")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "User permissions retrieved successfully", result)
+}
+
+// SetUserPermissions replaces the permission overrides of a user
+func (h *RoleHandler) SetUserPermissions(c *gin.Context) {
+	id, ok := h.parseID(c, "user")
+	if !ok {
+		return
+	}
+
+	var req request.UserPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	result, err := h.service.SetUserPermissions(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update user permissions")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "User permissions updated successfully", result)
+}
+
+func (h *RoleHandler) parseID(c *gin.Context, name string) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid "+name+" ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *RoleHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
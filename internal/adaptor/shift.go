@@ -0,0 +1,187 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ShiftHandler struct {
+	service usecase.ShiftService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewShiftHandler(service usecase.ShiftService, log *zap.Logger, config utils.Configuration) ShiftHandler {
+	return ShiftHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "shift")),
+		config:  config,
+	}
+}
+
+// CreateShift schedules a single shift
+func (h *ShiftHandler) CreateShift(c *gin.Context) {
+	var req request.CreateShiftRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	shift, err := h.service.CreateShift(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create shift")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Shift created successfully", shift)
+}
+
+// BulkAssignShifts schedules the same hours for several staff across several dates
+func (h *ShiftHandler) BulkAssignShifts(c *gin.Context) {
+	var req request.BulkAssignShiftsRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.service.BulkAssignShifts(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to assign shifts")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Shifts assigned successfully", result)
+}
+
+// CopyWeek copies one week's roster into another week
+func (h *ShiftHandler) CopyWeek(c *gin.Context) {
+	var req request.CopyWeekRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.service.CopyWeek(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to copy roster")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Roster copied successfully", result)
+}
+
+// GetShifts gets list of shifts
+func (h *ShiftHandler) GetShifts(c *gin.Context) {
+	var req request.GetShiftsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	shifts, pagination, err := h.service.GetShifts(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get shifts")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Shifts retrieved successfully", shifts, pagination)
+}
+
+// GetShiftByID gets a shift by ID
+func (h *ShiftHandler) GetShiftByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	shift, err := h.service.GetShiftByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get shift")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Shift retrieved successfully", shift)
+}
+
+// UpdateShift moves or edits a shift
+func (h *ShiftHandler) UpdateShift(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateShiftRequest
+	if !h.bindJSON(c, &req) {
+		return
+	}
+
+	shift, err := h.service.UpdateShift(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update shift")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Shift updated successfully", shift)
+}
+
+// DeleteShift removes a shift from the roster
+func (h *ShiftHandler) DeleteShift(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteShift(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete shift")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Shift deleted successfully", nil)
+}
+
+// GetMyShifts lists the logged in user's upcoming shifts
+func (h *ShiftHandler) GetMyShifts(c *gin.Context) {
+	shifts, err := h.service.GetMyUpcomingShifts(c)
+	if err != nil {
+		h.handleError(c, err, "Failed to get shifts")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Shifts retrieved successfully", shifts)
+}
+
+func (h *ShiftHandler) bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return false
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *ShiftHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid shift ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *ShiftHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
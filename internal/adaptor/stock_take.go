@@ -0,0 +1,229 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type StockTakeHandler struct {
+	service usecase.StockTakeService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewStockTakeHandler(service usecase.StockTakeService, log *zap.Logger, config utils.Configuration) StockTakeHandler {
+	return StockTakeHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "stock_take")),
+		config:  config,
+	}
+}
+
+// OpenStockTake starts a new count session
+func (h *StockTakeHandler) OpenStockTake(c *gin.Context) {
+	var req request.CreateStockTakeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	stockTake, err := h.service.OpenStockTake(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to open stock take")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Stock take opened successfully", stockTake)
+}
+
+// GetStockTakes gets list of stock take sessions
+func (h *StockTakeHandler) GetStockTakes(c *gin.Context) {
+	var req request.GetStockTakesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	stockTakes, pagination, err := h.service.GetStockTakes(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get stock takes")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Stock takes retrieved successfully", stockTakes, pagination)
+}
+
+// GetStockTakeByID returns a session with its variance review
+func (h *StockTakeHandler) GetStockTakeByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	stockTake, err := h.service.GetStockTakeByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get stock take")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Stock take retrieved successfully", stockTake)
+}
+
+// RecordCounts enters or corrects counted quantities
+func (h *StockTakeHandler) RecordCounts(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.RecordStockTakeCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	stockTake, err := h.service.RecordCounts(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to record counts")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Counts recorded successfully", stockTake)
+}
+
+// RemoveCount removes a product from an open session
+func (h *StockTakeHandler) RemoveCount(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 32)
+	if err != nil {
+		utils.ResponseError(c, utils.InvalidParam("product_id"))
+		return
+	}
+
+	if err := h.service.RemoveCount(c, id, uint(productID)); err != nil {
+		h.handleError(c, err, "Failed to remove count")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Count removed successfully", nil)
+}
+
+// CommitStockTake applies the counted quantities to product stock
+func (h *StockTakeHandler) CommitStockTake(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	stockTake, err := h.service.CommitStockTake(c, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to commit stock take")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Stock take committed successfully", stockTake)
+}
+
+// CancelStockTake discards an open session
+func (h *StockTakeHandler) CancelStockTake(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CancelStockTake(c, id); err != nil {
+		h.handleError(c, err, "Failed to cancel stock take")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Stock take cancelled successfully", nil)
+}
+
+// DownloadVarianceReport returns the variance review as a csv file, or as
+// xlsx with format=xlsx
+func (h *StockTakeHandler) DownloadVarianceReport(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.StockTakeVarianceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+	if req.Format == "" {
+		req.Format = utils.ExportFormatCSV
+	}
+
+	stockTake, err := h.service.GetStockTakeByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get stock take")
+		return
+	}
+
+	err = streamExport(c, h.logger, req.Format, stockTake.Reference+"-variance", func(w utils.ExportWriter) error {
+		return writeVarianceReport(w, stockTake)
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to export variance report")
+	}
+}
+
+func writeVarianceReport(w utils.ExportWriter, stockTake *response.StockTakeResponse) error {
+	err := w.WriteRow("product_id", "product_name", "expected_quantity", "counted_quantity", "variance", "unit_cost", "variance_value", "notes")
+	if err != nil {
+		return err
+	}
+
+	for _, item := range stockTake.Items {
+		err := w.WriteRow(item.ProductID, item.ProductName, item.ExpectedQuantity, item.CountedQuantity, item.Variance,
+			item.UnitCost, item.VarianceValue, item.Notes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.WriteRow(nil, "TOTAL", nil, nil, nil, nil, stockTake.TotalVarianceValue, nil)
+}
+
+func (h *StockTakeHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid stock take ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *StockTakeHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -0,0 +1,144 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SupplierHandler struct {
+	service usecase.SupplierService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewSupplierHandler(service usecase.SupplierService, log *zap.Logger, config utils.Configuration) SupplierHandler {
+	return SupplierHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "supplier")),
+		config:  config,
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
+	var req request.CreateSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(c, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to create supplier")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusCreated, "Supplier created successfully", supplier)
+}
+
+// GetSuppliers gets list of suppliers
+func (h *SupplierHandler) GetSuppliers(c *gin.Context) {
+	var req request.GetSuppliersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	suppliers, pagination, err := h.service.GetSuppliers(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get suppliers")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Suppliers retrieved successfully", suppliers, pagination)
+}
+
+// GetSupplierByID gets a supplier by ID
+func (h *SupplierHandler) GetSupplierByID(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	supplier, err := h.service.GetSupplierByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get supplier")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Supplier retrieved successfully", supplier)
+}
+
+// UpdateSupplier updates a supplier
+func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplier(c, id, req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update supplier")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Supplier updated successfully", supplier)
+}
+
+// DeleteSupplier deletes a supplier without purchase orders
+func (h *SupplierHandler) DeleteSupplier(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSupplier(c, id); err != nil {
+		h.handleError(c, err, "Failed to delete supplier")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "Supplier deleted successfully", nil)
+}
+
+func (h *SupplierHandler) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid supplier ID", zap.String("id", idStr), zap.Error(err))
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *SupplierHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
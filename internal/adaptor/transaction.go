@@ -0,0 +1,64 @@
+package adaptor
+
+import (
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TransactionHandler struct {
+	service usecase.TransactionService
+	logger  *zap.Logger
+	config  utils.Configuration
+}
+
+func NewTransactionHandler(service usecase.TransactionService, log *zap.Logger, config utils.Configuration) TransactionHandler {
+	return TransactionHandler{
+		service: service,
+		logger:  log.With(zap.String("handler", "transaction")),
+		config:  config,
+	}
+}
+
+// GetTransactions lists transactions, or downloads them when format is csv or xlsx
+func (h *TransactionHandler) GetTransactions(c *gin.Context) {
+	var req request.GetTransactionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", zap.Error(err))
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		h.logger.Warn("Validation failed", zap.Error(err))
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if utils.IsExportFormat(req.Format) {
+		err := streamExport(c, h.logger, req.Format, "transactions", func(w utils.ExportWriter) error {
+			return h.service.ExportTransactions(c.Request.Context(), req, w)
+		})
+		if err != nil {
+			h.handleError(c, err, "Failed to export transactions")
+		}
+		return
+	}
+
+	transactions, pagination, err := h.service.GetTransactions(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err, "Failed to get transactions")
+		return
+	}
+
+	utils.ResponsePagination(c, http.StatusOK, "Transactions retrieved successfully", transactions, pagination)
+}
+
+func (h *TransactionHandler) handleError(c *gin.Context, err error, message string) {
+	h.logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
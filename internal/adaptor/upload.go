@@ -0,0 +1,108 @@
+package adaptor
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// uploadField is the multipart form field carrying the file.
+const uploadField = "file"
+
+// multipartOverhead leaves room for the form's boundaries and headers on
+// top of the file itself.
+const multipartOverhead = 64 << 10
+
+type UploadHandler struct {
+	service usecase.UploadService
+	Logger  *zap.Logger
+	Config  utils.Configuration
+}
+
+func NewUploadHandler(service usecase.UploadService, log *zap.Logger, config utils.Configuration) UploadHandler {
+	return UploadHandler{
+		service: service,
+		Logger:  log,
+		Config:  config,
+	}
+}
+
+func (h *UploadHandler) UploadProfileImage(c *gin.Context) {
+	h.upload(c, "upload profile image", func(file io.Reader) (*response.UploadResponse, error) {
+		return h.service.UploadProfileImage(c, file)
+	})
+}
+
+func (h *UploadHandler) UploadProductImage(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	h.upload(c, "upload product image", func(file io.Reader) (*response.UploadResponse, error) {
+		return h.service.UploadProductImage(c, uint(id), file)
+	})
+}
+
+func (h *UploadHandler) UploadCategoryIcon(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	h.upload(c, "upload category icon", func(file io.Reader) (*response.UploadResponse, error) {
+		return h.service.UploadCategoryIcon(c, uint(id), file)
+	})
+}
+
+// upload reads the file from the multipart form and hands it to store.
+// The body is capped so an oversized upload is refused before it is read
+// in full.
+func (h *UploadHandler) upload(c *gin.Context, action string, store func(io.Reader) (*response.UploadResponse, error)) {
+	limit := usecase.MaxUploadBytes(h.Config.Storage)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+multipartOverhead)
+
+	header, err := c.FormFile(uploadField)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.ResponseError(c, utils.ErrFileTooLarge)
+			return
+		}
+		utils.ResponseError(c, utils.ErrInvalidRequest.WithFields(utils.FieldError{
+			Field:   uploadField,
+			Code:    "required",
+			Message: uploadField + " is required",
+		}))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.handleError(c, err, action+" failed")
+		return
+	}
+	defer file.Close()
+
+	res, err := store(file)
+	if err != nil {
+		h.handleError(c, err, action+" failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, action+" success", res)
+}
+
+func (h *UploadHandler) handleError(c *gin.Context, err error, message string) {
+	h.Logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
+}
@@ -2,7 +2,6 @@ package adaptor
 
 import (
 	"net/http"
-	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/usecase"
 	"project-POS-APP-golang-integer/pkg/utils"
@@ -35,15 +34,14 @@ func (h *UserHandler) GetUserList(c *gin.Context) {
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
 	result, err := h.service.GetUserList(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadGateway, "get user list failed", nil)
+		utils.ResponseError(c, err)
 		return
 	}
 
@@ -53,26 +51,19 @@ func (h *UserHandler) GetUserList(c *gin.Context) {
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req request.UserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
-		return
-	}
-
-	role := c.Value("user_role").(entity.UserRole)
-	if role != entity.RoleSuperAdmin && req.Role == string(entity.RoleSuperAdmin) {
-		utils.ResponseFailed(c, http.StatusForbidden, "create user failed", err)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	res, err := h.service.CreateUser(c.Request.Context(), req)
+	res, err := h.service.CreateUser(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "create user failed", err)
+		h.handleError(c, err, "create user failed")
 		return
 	}
 
@@ -82,26 +73,19 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 func (h *UserHandler) UpdateRole(c *gin.Context) {
 	var req request.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "invalid request", err)
+		utils.ResponseError(c, utils.InvalidRequest(err))
 		return
 	}
 
 	// Validation
-	messages, err := utils.ValidateErrors(req)
-	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, err.Error(), messages)
-		return
-	}
-
-	role := c.Value("user_role").(entity.UserRole)
-	if role != entity.RoleSuperAdmin && req.Role == string(entity.RoleSuperAdmin) {
-		utils.ResponseFailed(c, http.StatusForbidden, "update user failed", err)
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
 		return
 	}
 
-	err = h.service.UpdateRole(c.Request.Context(), req)
+	err := h.service.UpdateRole(c, req)
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "update user failed", err)
+		h.handleError(c, err, "update user failed")
 		return
 	}
 
@@ -111,11 +95,105 @@ func (h *UserHandler) UpdateRole(c *gin.Context) {
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, _ := strconv.Atoi(idStr)
-	err := h.service.DeleteUser(c.Request.Context(), uint(id))
+	err := h.service.DeleteUser(c, uint(id))
 	if err != nil {
-		utils.ResponseFailed(c, http.StatusBadRequest, "delete user failed", err)
+		h.handleError(c, err, "delete user failed")
 		return
 	}
 
 	utils.ResponseSuccess(c, http.StatusCreated, "delete user success", nil)
+}
+
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	if err := h.service.RestoreUser(c, uint(id)); err != nil {
+		h.handleError(c, err, "restore user failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "restore user success", nil)
+}
+
+// ResendInvitation emails a new invitation to a user who has not joined yet
+func (h *UserHandler) ResendInvitation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	res, err := h.service.ResendInvitation(c, uint(id))
+	if err != nil {
+		h.handleError(c, err, "resend invitation failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "resend invitation success", res)
+}
+
+func (h *UserHandler) RevokeInvitation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.ResponseError(c, utils.InvalidParam("id"))
+		return
+	}
+
+	if err := h.service.RevokeInvitation(c, uint(id)); err != nil {
+		h.handleError(c, err, "revoke invitation failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "revoke invitation success", nil)
+}
+
+// GetInvitation lets the accept page show who an invitation is for
+func (h *UserHandler) GetInvitation(c *gin.Context) {
+	var req request.InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	res, err := h.service.GetInvitation(c, req.Token)
+	if err != nil {
+		h.handleError(c, err, "get invitation failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "get invitation success", res)
+}
+
+func (h *UserHandler) AcceptInvitation(c *gin.Context) {
+	var req request.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ResponseError(c, utils.InvalidRequest(err))
+		return
+	}
+
+	if err := utils.Validate(req); err != nil {
+		utils.ResponseError(c, err)
+		return
+	}
+
+	if err := h.service.AcceptInvitation(c, req); err != nil {
+		h.handleError(c, err, "accept invitation failed")
+		return
+	}
+
+	utils.ResponseSuccess(c, http.StatusOK, "invitation accepted, you can now log in", nil)
+}
+
+func (h *UserHandler) handleError(c *gin.Context, err error, message string) {
+	h.Logger.Error(message, zap.Error(err))
+	utils.ResponseError(c, err)
 }
\ No newline at end of file
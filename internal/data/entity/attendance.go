@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+)
+
+// Attendance is one clock-in/clock-out punch. The minute fields are computed
+// against the linked shift whenever the punch is closed or corrected. A
+// profile has at most one open punch, enforced by a partial unique index.
+type Attendance struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	ProfileID         uint       `gorm:"index;uniqueIndex:idx_attendances_open_profile,where:clock_out IS NULL;not null" json:"profile_id"`
+	ShiftID           *uint      `gorm:"index" json:"shift_id,omitempty"`
+	ClockIn           time.Time  `gorm:"index;not null" json:"clock_in"`
+	ClockOut          *time.Time `json:"clock_out,omitempty"`
+	WorkedMinutes     int        `gorm:"not null;default:0" json:"worked_minutes"`
+	LateMinutes       int        `gorm:"not null;default:0" json:"late_minutes"`
+	EarlyLeaveMinutes int        `gorm:"not null;default:0" json:"early_leave_minutes"`
+	OvertimeMinutes   int        `gorm:"not null;default:0" json:"overtime_minutes"`
+	Notes             string     `json:"notes,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	// Relations
+	Profile     Profile                `gorm:"foreignKey:ProfileID" json:"profile,omitempty"`
+	Shift       *Shift                 `gorm:"foreignKey:ShiftID" json:"shift,omitempty"`
+	Corrections []AttendanceCorrection `gorm:"foreignKey:AttendanceID" json:"corrections,omitempty"`
+}
+
+// IsOpen reports whether the punch is still waiting for a clock-out.
+func (a *Attendance) IsOpen() bool {
+	return a.ClockOut == nil
+}
+
+// AttendanceCorrection records a manager's change to a punch together with
+// the values it replaced.
+type AttendanceCorrection struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	AttendanceID     uint       `gorm:"index;not null" json:"attendance_id"`
+	CorrectedBy      uint       `gorm:"index;not null" json:"corrected_by"`
+	PreviousClockIn  time.Time  `json:"previous_clock_in"`
+	PreviousClockOut *time.Time `json:"previous_clock_out,omitempty"`
+	NewClockIn       time.Time  `json:"new_clock_in"`
+	NewClockOut      *time.Time `json:"new_clock_out,omitempty"`
+	Note             string     `gorm:"type:text;not null" json:"note"`
+	CreatedAt        time.Time  `json:"created_at"`
+
+	// Relations
+	Corrector User `gorm:"foreignKey:CorrectedBy" json:"-"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// AuditAction enum
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditLog records one change made through the API. Before and After hold
+// only the fields that changed, as JSON objects; a create has no Before and
+// a delete has no After.
+type AuditLog struct {
+	ID         uint        `gorm:"primaryKey" json:"id"`
+	ActorID    *uint       `gorm:"index" json:"actor_id,omitempty"`
+	Action     AuditAction `gorm:"type:varchar(20);not null;index" json:"action"`
+	EntityType string      `gorm:"type:varchar(50);not null;index:idx_audit_entity" json:"entity_type"`
+	EntityID   uint        `gorm:"not null;index:idx_audit_entity" json:"entity_id"`
+	Before     string      `gorm:"type:jsonb" json:"before,omitempty"`
+	After      string      `gorm:"type:jsonb" json:"after,omitempty"`
+	IPAddress  string      `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	RequestID  string      `gorm:"type:varchar(64);index" json:"request_id,omitempty"`
+	CreatedAt  time.Time   `gorm:"index" json:"created_at"`
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+)
+
+// CashDrawerStatus enum
+type CashDrawerStatus string
+
+const (
+	CashDrawerStatusOpen   CashDrawerStatus = "open"
+	CashDrawerStatusClosed CashDrawerStatus = "closed"
+)
+
+// CashMovementType enum
+type CashMovementType string
+
+const (
+	CashMovementPayIn  CashMovementType = "pay_in"
+	CashMovementPayOut CashMovementType = "pay_out"
+)
+
+func (t CashMovementType) IsValid() bool {
+	switch t {
+	case CashMovementPayIn, CashMovementPayOut:
+		return true
+	}
+	return false
+}
+
+// CashDrawerSession is one cashier's till from opening float to close-out.
+// The cash totals are frozen when the session is closed; while it is open
+// they are derived from the cashier's completed transactions.
+type CashDrawerSession struct {
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	CashierID    uint             `gorm:"index;not null" json:"cashier_id"`
+	Status       CashDrawerStatus `gorm:"type:varchar(20);default:'open';index" json:"status"`
+	OpeningFloat float64          `gorm:"not null;default:0" json:"opening_float"`
+	OpenedAt     time.Time        `gorm:"not null" json:"opened_at"`
+	ClosedAt     *time.Time       `json:"closed_at,omitempty"`
+	ClosedBy     *uint            `json:"closed_by,omitempty"`
+	CashSales    float64          `gorm:"not null;default:0" json:"cash_sales"`
+	CashRefunds  float64          `gorm:"not null;default:0" json:"cash_refunds"`
+	PayIns       float64          `gorm:"not null;default:0" json:"pay_ins"`
+	PayOuts      float64          `gorm:"not null;default:0" json:"pay_outs"`
+	ExpectedCash float64          `gorm:"not null;default:0" json:"expected_cash"`
+	CountedCash  *float64         `json:"counted_cash,omitempty"`
+	Variance     *float64         `json:"variance,omitempty"`
+	Notes        string           `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+
+	// Relations
+	Cashier   User           `gorm:"foreignKey:CashierID" json:"-"`
+	Movements []CashMovement `gorm:"foreignKey:SessionID" json:"movements,omitempty"`
+}
+
+// CashMovement is cash put into or taken out of the drawer outside of sales.
+type CashMovement struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	SessionID uint             `gorm:"index;not null" json:"session_id"`
+	Type      CashMovementType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount    float64          `gorm:"not null" json:"amount"`
+	Reason    string           `gorm:"not null" json:"reason"`
+	CreatedBy uint             `gorm:"index;not null" json:"created_by"`
+	CreatedAt time.Time        `json:"created_at"`
+}
@@ -6,11 +6,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// DefaultStation is the KDS station for categories without one.
+const DefaultStation = "kitchen"
+
 type Category struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Name        string         `gorm:"uniqueIndex;not null" json:"name"`
 	IconURL     string         `json:"icon_url,omitempty"`
 	Description string         `json:"description,omitempty"`
+	Station     string         `gorm:"type:varchar(50);not null;default:'kitchen';index" json:"station"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IngredientUnit enum
+type IngredientUnit string
+
+const (
+	IngredientUnitGram       IngredientUnit = "g"
+	IngredientUnitKilogram   IngredientUnit = "kg"
+	IngredientUnitMilliliter IngredientUnit = "ml"
+	IngredientUnitLiter      IngredientUnit = "l"
+	IngredientUnitPiece      IngredientUnit = "pcs"
+)
+
+type Ingredient struct {
+	gorm.Model
+	Name        string         `gorm:"uniqueIndex;not null" json:"name"`
+	Unit        IngredientUnit `gorm:"type:varchar(10);not null" json:"unit"`
+	Stock       float64        `gorm:"not null;default:0" json:"stock"`
+	MinStock    float64        `gorm:"not null;default:0" json:"min_stock"`
+	CostPerUnit float64        `gorm:"not null;default:0" json:"cost_per_unit"`
+
+	// Relations
+	Logs []IngredientLog `gorm:"foreignKey:IngredientID" json:"-"`
+}
+
+func (i Ingredient) IsLowStock() bool {
+	return i.Stock <= i.MinStock
+}
+
+// RecipeItem maps either a product or a modifier option to the amount of an
+// ingredient consumed for a single unit sold.
+type RecipeItem struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	ProductID        *uint     `gorm:"index" json:"product_id,omitempty"`
+	ModifierOptionID *uint     `gorm:"index" json:"modifier_option_id,omitempty"`
+	IngredientID     uint      `gorm:"index;not null" json:"ingredient_id"`
+	Quantity         float64   `gorm:"not null" json:"quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relations
+	Ingredient Ingredient `gorm:"foreignKey:IngredientID" json:"ingredient"`
+}
+
+type IngredientLog struct {
+	ID                uint             `gorm:"primaryKey" json:"id"`
+	IngredientID      uint             `gorm:"index;not null" json:"ingredient_id"`
+	Type              InventoryLogType `gorm:"type:varchar(20);not null" json:"type"`
+	QuantityChange    float64          `gorm:"not null" json:"quantity_change"`
+	CurrentStockAfter float64          `gorm:"not null" json:"current_stock_after"`
+	ReferenceID       *uint            `gorm:"index" json:"reference_id,omitempty"`
+	ReferenceType     string           `gorm:"type:varchar(50)" json:"reference_type,omitempty"`
+	Notes             string           `json:"notes,omitempty"`
+	CreatedBy         uint             `gorm:"index;not null" json:"created_by"`
+	CreatedAt         time.Time        `json:"created_at"`
+
+	// Relations
+	Ingredient Ingredient `gorm:"foreignKey:IngredientID" json:"ingredient"`
+	Creator    User       `gorm:"foreignKey:CreatedBy" json:"creator"`
+}
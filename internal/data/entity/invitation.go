@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Invitation lets a newly created user set their own password. Only the hash
+// of the emailed token is stored; a user is pending until one is accepted.
+type Invitation struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index;not null" json:"user_id"`
+	TokenHash  string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	InvitedBy  *uint      `json:"invited_by"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// LoginThrottle counts recent failed logins for Key, which is either an
+// account ("account:<user id>") or a client address ("ip:<address>").
+type LoginThrottle struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Key           string     `gorm:"type:varchar(100);uniqueIndex;not null" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ModifierGroup is a set of options offered with a product. Customers pick
+// between MinSelect and MaxSelect of them; a MaxSelect of 0 means no limit.
+type ModifierGroup struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	ProductID  uint           `gorm:"index;not null" json:"product_id"`
+	Name       string         `gorm:"not null" json:"name"`
+	IsRequired bool           `gorm:"default:false" json:"is_required"`
+	MinSelect  int            `gorm:"not null;default:0" json:"min_select"`
+	MaxSelect  int            `gorm:"not null" json:"max_select"`
+	SortOrder  int            `gorm:"default:0" json:"sort_order"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Relations
+	Product Product          `gorm:"foreignKey:ProductID" json:"-"`
+	Options []ModifierOption `gorm:"foreignKey:GroupID" json:"options,omitempty"`
+}
+
+type ModifierOption struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	GroupID    uint           `gorm:"index;not null" json:"group_id"`
+	Name       string         `gorm:"not null" json:"name"`
+	PriceDelta float64        `gorm:"not null;default:0" json:"price_delta"`
+	IsActive   bool           `gorm:"default:true" json:"is_active"`
+	SortOrder  int            `gorm:"default:0" json:"sort_order"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Relations
+	Group ModifierGroup `gorm:"foreignKey:GroupID" json:"-"`
+}
+
+// OrderItemModifier keeps a snapshot of the selected option so later
+// price or name changes don't rewrite past orders.
+type OrderItemModifier struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	OrderItemID      uint      `gorm:"index;not null" json:"order_item_id"`
+	ModifierOptionID uint      `gorm:"index;not null" json:"modifier_option_id"`
+	GroupName        string    `gorm:"not null" json:"group_name"`
+	OptionName       string    `gorm:"not null" json:"option_name"`
+	PriceDelta       float64   `gorm:"not null;default:0" json:"price_delta"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	// Relations
+	OrderItem      OrderItem      `gorm:"foreignKey:OrderItemID" json:"-"`
+	ModifierOption ModifierOption `gorm:"foreignKey:ModifierOptionID" json:"-"`
+}
@@ -15,6 +15,14 @@ const (
 	OrderStatusCancelled OrderStatus = "cancelled"
 )
 
+// DiscountType enum
+type DiscountType string
+
+const (
+	DiscountTypeAmount  DiscountType = "amount"
+	DiscountTypePercent DiscountType = "percent"
+)
+
 type Order struct {
 	gorm.Model
 	OrderNumber     string      `gorm:"uniqueIndex;not null" json:"order_number"`
@@ -29,6 +37,19 @@ type Order struct {
 	CreatedBy       uint        `gorm:"index;not null" json:"created_by"`
 	Notes           string      `json:"notes,omitempty"`
 
+	// Discount is applied to the subtotal before tax. A percent discount
+	// follows the subtotal as items are added.
+	DiscountType       DiscountType `gorm:"type:varchar(10)" json:"discount_type,omitempty"`
+	DiscountValue      float64      `gorm:"not null;default:0" json:"discount_value"`
+	DiscountAmount     float64      `gorm:"not null;default:0" json:"discount_amount"`
+	DiscountReason     string       `gorm:"type:varchar(255)" json:"discount_reason,omitempty"`
+	DiscountedBy       *uint        `json:"discounted_by,omitempty"`
+	DiscountApprovedBy *uint        `json:"discount_approved_by,omitempty"`
+
+	// VoidedBy asked for the cancellation and VoidApprovedBy allowed it.
+	VoidedBy       *uint `json:"voided_by,omitempty"`
+	VoidApprovedBy *uint `json:"void_approved_by,omitempty"`
+
 	// Relations
 	Customer      Customer      `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
 	Table         Table         `gorm:"foreignKey:TableID" json:"table"`
@@ -4,12 +4,22 @@ import (
 	"time"
 )
 
+// OTPPurpose keeps a code sent for one flow from being redeemed in another.
+type OTPPurpose string
+
+const (
+	OTPPurposePasswordReset OTPPurpose = "password_reset"
+	OTPPurposeTwoFactor     OTPPurpose = "two_factor"
+)
+
 type OTP struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	UserID    uint      `gorm:"index;not null" json:"user_id"`
 	OTPCode   string    `gorm:"not null" json:"otp_code"`
+	Purpose   OTPPurpose `gorm:"type:varchar(20);not null;default:'password_reset'" json:"purpose"`
 	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
 	IsUsed    bool      `gorm:"default:false" json:"is_used"`
+	Attempts  int       `gorm:"not null;default:0" json:"attempts"`
 	CreatedAt time.Time `json:"created_at"`
 
 	// Relations
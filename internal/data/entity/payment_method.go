@@ -8,6 +8,7 @@ type PaymentMethod struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"uniqueIndex;not null" json:"name"`
 	IsActive  bool      `gorm:"default:true" json:"is_active"`
+	IsCash    bool      `gorm:"default:false" json:"is_cash"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -0,0 +1,123 @@
+package entity
+
+import "time"
+
+// Permission names checked by routes and services. Roles are granted any
+// subset of them; superadmins implicitly hold all of them.
+const (
+	PermUsersManage       = "users.manage"
+	PermSalaryManage      = "salary.manage"
+	PermRolesManage       = "roles.manage"
+	PermCatalogManage     = "catalog.manage"
+	PermInventoryView     = "inventory.view"
+	PermInventoryAdjust   = "inventory.adjust"
+	PermOrdersManage      = "orders.manage"
+	PermOrdersVoid        = "orders.void"
+	PermOrdersDiscount    = "orders.discount"
+	PermOrdersRefund      = "orders.refund"
+	PermKitchenOperate    = "kitchen.operate"
+	PermIngredientsView   = "ingredients.view"
+	PermIngredientsManage = "ingredients.manage"
+	PermSuppliersManage   = "suppliers.manage"
+	PermPurchasingReceive = "purchasing.receive"
+	PermPurchasingManage  = "purchasing.manage"
+	PermStockTakesCount   = "stock_takes.count"
+	PermStockTakesManage  = "stock_takes.manage"
+	PermShiftsManage      = "shifts.manage"
+	PermAttendanceClock   = "attendance.clock"
+	PermAttendanceManage  = "attendance.manage"
+	PermCashDrawerOperate = "cash_drawer.operate"
+	PermCashDrawerManage  = "cash_drawer.manage"
+	PermReportsView       = "reports.view"
+	PermTransactionsView  = "transactions.view"
+	PermAuditView         = "audit.view"
+)
+
+// PermissionCatalog lists every permission the application checks. It is
+// synced into the permissions table on startup.
+var PermissionCatalog = []Permission{
+	{Name: PermUsersManage, Description: "Create, update, delete and unlock users"},
+	{Name: PermSalaryManage, Description: "View and change staff salaries"},
+	{Name: PermRolesManage, Description: "Edit roles, their permissions and per-user overrides"},
+	{Name: PermCatalogManage, Description: "Manage categories, products, modifiers and recipes"},
+	{Name: PermInventoryView, Description: "View inventory logs and stock history"},
+	{Name: PermInventoryAdjust, Description: "Record inventory adjustments"},
+	{Name: PermOrdersManage, Description: "Take orders, update their status and print receipts"},
+	{Name: PermOrdersVoid, Description: "Cancel orders or approve a cancellation with a manager PIN"},
+	{Name: PermOrdersDiscount, Description: "Discount orders or approve a discount with a manager PIN"},
+	{Name: PermOrdersRefund, Description: "Refund orders or approve a refund with a manager PIN"},
+	{Name: PermKitchenOperate, Description: "Use the kitchen display"},
+	{Name: PermIngredientsView, Description: "View ingredients and their stock"},
+	{Name: PermIngredientsManage, Description: "Manage ingredients and adjust their stock"},
+	{Name: PermSuppliersManage, Description: "Manage suppliers"},
+	{Name: PermPurchasingReceive, Description: "View purchase orders and receive deliveries"},
+	{Name: PermPurchasingManage, Description: "Create, submit and cancel purchase orders"},
+	{Name: PermStockTakesCount, Description: "Open stock takes and record counts"},
+	{Name: PermStockTakesManage, Description: "Review, commit and cancel stock takes"},
+	{Name: PermShiftsManage, Description: "Schedule shifts"},
+	{Name: PermAttendanceClock, Description: "Clock in and out"},
+	{Name: PermAttendanceManage, Description: "View and correct everyone's attendance"},
+	{Name: PermCashDrawerOperate, Description: "Run an own cash drawer"},
+	{Name: PermCashDrawerManage, Description: "View and act on every cash drawer"},
+	{Name: PermReportsView, Description: "View sales reports and the dashboard"},
+	{Name: PermTransactionsView, Description: "View and export transactions"},
+	{Name: PermAuditView, Description: "View the audit trail"},
+}
+
+// OverridePermissions are the privileged order actions a user without the
+// permission may still perform with the PIN of a manager who holds it.
+var OverridePermissions = []string{PermOrdersVoid, PermOrdersDiscount, PermOrdersRefund}
+
+// DefaultRolePermissions is what the built-in roles are granted when they are
+// first created, and when a permission is added to the catalog later.
+// Superadmins are not listed because they hold everything.
+var DefaultRolePermissions = map[UserRole][]string{
+	RoleAdmin: {
+		PermUsersManage, PermSalaryManage, PermCatalogManage, PermInventoryView, PermInventoryAdjust,
+		PermOrdersManage, PermOrdersVoid, PermOrdersDiscount, PermOrdersRefund,
+		PermKitchenOperate, PermIngredientsView, PermIngredientsManage, PermSuppliersManage,
+		PermPurchasingReceive, PermPurchasingManage,
+		PermStockTakesCount, PermStockTakesManage, PermShiftsManage,
+		PermAttendanceClock, PermAttendanceManage, PermCashDrawerOperate,
+		PermCashDrawerManage, PermReportsView, PermTransactionsView, PermAuditView,
+	},
+	RoleStaff: {
+		PermInventoryView, PermInventoryAdjust, PermOrdersManage, PermKitchenOperate,
+		PermIngredientsView, PermPurchasingReceive, PermStockTakesCount,
+		PermAttendanceClock, PermCashDrawerOperate,
+	},
+}
+
+type Permission struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:varchar(255)" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Role is a named set of permissions. Users refer to it by Name. System
+// roles are the built-in ones and cannot be deleted.
+type Role struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(20);uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:varchar(255)" json:"description"`
+	IsSystem    bool      `gorm:"not null;default:false" json:"is_system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relations
+	Permissions []Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
+}
+
+// UserPermission grants (Granted true) or withdraws (Granted false) a single
+// permission for one user on top of what their role gives.
+type UserPermission struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"uniqueIndex:idx_user_permission;not null" json:"user_id"`
+	PermissionID uint      `gorm:"uniqueIndex:idx_user_permission;not null" json:"permission_id"`
+	Granted      bool      `gorm:"not null" json:"granted"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relations
+	Permission Permission `gorm:"foreignKey:PermissionID" json:"permission"`
+}
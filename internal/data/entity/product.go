@@ -16,6 +16,7 @@ type Product struct {
 	Name        string        `gorm:"not null" json:"name"`
 	Description string        `json:"description,omitempty"`
 	Price       float64       `gorm:"not null;default:0" json:"price"`
+	CostPrice   float64       `gorm:"not null;default:0" json:"cost_price"`
 	Stock       int           `gorm:"default:0" json:"stock"`
 	MinStock    int           `gorm:"default:5" json:"min_stock"`
 	Status      ProductStatus `gorm:"type:varchar(20);default:'active'" json:"status"`
@@ -23,7 +24,8 @@ type Product struct {
 	CategoryID  uint          `gorm:"index;not null" json:"category_id"`
 
 	// Relations
-	Category      Category       `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
-	OrderItems    []OrderItem    `gorm:"foreignKey:ProductID" json:"-"`
-	InventoryLogs []InventoryLog `gorm:"foreignKey:ProductID" json:"-"`
+	Category       Category        `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	OrderItems     []OrderItem     `gorm:"foreignKey:ProductID" json:"-"`
+	InventoryLogs  []InventoryLog  `gorm:"foreignKey:ProductID" json:"-"`
+	ModifierGroups []ModifierGroup `gorm:"foreignKey:ProductID" json:"modifier_groups,omitempty"`
 }
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PurchaseOrderStatus enum
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusOrdered           PurchaseOrderStatus = "ordered"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+type PurchaseOrder struct {
+	gorm.Model
+	PONumber    string              `gorm:"uniqueIndex;not null" json:"po_number"`
+	SupplierID  uint                `gorm:"index;not null" json:"supplier_id"`
+	Status      PurchaseOrderStatus `gorm:"type:varchar(20);default:'draft'" json:"status"`
+	ExpectedAt  *time.Time          `json:"expected_at,omitempty"`
+	OrderedAt   *time.Time          `json:"ordered_at,omitempty"`
+	ReceivedAt  *time.Time          `json:"received_at,omitempty"`
+	TotalAmount float64             `gorm:"not null;default:0" json:"total_amount"`
+	Notes       string              `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy   uint                `gorm:"index;not null" json:"created_by"`
+
+	// Relations
+	Supplier Supplier            `gorm:"foreignKey:SupplierID" json:"supplier"`
+	Creator  User                `gorm:"foreignKey:CreatedBy" json:"-"`
+	Items    []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"items"`
+}
+
+type PurchaseOrderItem struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	PurchaseOrderID  uint      `gorm:"index;not null" json:"purchase_order_id"`
+	ProductID        uint      `gorm:"index;not null" json:"product_id"`
+	QuantityOrdered  int       `gorm:"not null" json:"quantity_ordered"`
+	QuantityReceived int       `gorm:"not null;default:0" json:"quantity_received"`
+	UnitCost         float64   `gorm:"not null;default:0" json:"unit_cost"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relations
+	PurchaseOrder PurchaseOrder `gorm:"foreignKey:PurchaseOrderID" json:"-"`
+	Product       Product       `gorm:"foreignKey:ProductID" json:"product"`
+}
+
+func (i PurchaseOrderItem) RemainingQuantity() int {
+	return i.QuantityOrdered - i.QuantityReceived
+}
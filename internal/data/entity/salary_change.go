@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// SalaryChange records the salary a user is paid from EffectiveDate on.
+// The profile keeps the salary of the latest change already in effect.
+type SalaryChange struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	UserID         uint      `gorm:"index;not null" json:"user_id"`
+	Salary         float64   `gorm:"not null" json:"salary"`
+	PreviousSalary float64   `gorm:"not null;default:0" json:"previous_salary"`
+	EffectiveDate  time.Time `gorm:"type:date;not null" json:"effective_date"`
+	Note           string    `gorm:"type:varchar(255)" json:"note,omitempty"`
+	ChangedBy      *uint     `json:"changed_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
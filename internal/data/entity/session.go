@@ -6,13 +6,23 @@ import (
 	"github.com/google/uuid"
 )
 
+// Session is one signed-in device. Token is the short-lived access token and
+// is slid forward on activity; the refresh token is stored only as a hash and
+// rotated on every refresh, with the previous hash kept to detect reuse.
 type Session struct {
-	ID        uint       `gorm:"primaryKey" json:"id"`
-	UserID    uint       `gorm:"index;not null" json:"user_id"`
-	Token     uuid.UUID     `gorm:"uniqueIndex;not null" json:"token"`
-	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
-	RevokedAt *time.Time `json:"revoked_at,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
+	ID                  uint       `gorm:"primaryKey" json:"id"`
+	UserID              uint       `gorm:"index;not null" json:"user_id"`
+	Token               uuid.UUID  `gorm:"uniqueIndex;not null" json:"token"`
+	ExpiresAt           time.Time  `gorm:"not null" json:"expires_at"`
+	RefreshTokenHash    string     `gorm:"type:varchar(64);index" json:"-"`
+	PreviousRefreshHash string     `gorm:"type:varchar(64);index" json:"-"`
+	RefreshExpiresAt    time.Time  `json:"refresh_expires_at"`
+	DeviceName          string     `gorm:"type:varchar(100)" json:"device_name,omitempty"`
+	IPAddress           string     `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent           string     `gorm:"type:varchar(255)" json:"user_agent,omitempty"`
+	LastUsedAt          time.Time  `json:"last_used_at"`
+	RevokedAt           *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
 
 	// Relations
 	User User `gorm:"foreignKey:UserID" json:"user"`
@@ -4,13 +4,16 @@ import (
 	"time"
 )
 
+// Shift is a single scheduled block of work. WeekNumber and Year hold the
+// ISO week of ShiftStart so rosters can be queried and copied per week.
 type Shift struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
-	ProfileID    uint      `gorm:"not null" json:"profile_id"`
-	WeekNumber int       `gorm:"not null" json:"week_number"`
-	ShiftStart time.Time `json:"shift_start"`
-	ShiftEnd   time.Time `json:"shift_end"`
-	Year       int       `gorm:"not null" json:"year"`
+	ProfileID  uint      `gorm:"not null;uniqueIndex:idx_shift_profile_start" json:"profile_id"`
+	WeekNumber int       `gorm:"not null;index:idx_shift_week" json:"week_number"`
+	ShiftStart time.Time `gorm:"not null;uniqueIndex:idx_shift_profile_start" json:"shift_start"`
+	ShiftEnd   time.Time `gorm:"not null" json:"shift_end"`
+	Year       int       `gorm:"not null;index:idx_shift_week" json:"year"`
+	Notes      string    `json:"notes,omitempty"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 
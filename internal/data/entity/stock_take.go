@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockTakeStatus enum
+type StockTakeStatus string
+
+const (
+	StockTakeStatusOpen      StockTakeStatus = "open"
+	StockTakeStatusCommitted StockTakeStatus = "committed"
+	StockTakeStatusCancelled StockTakeStatus = "cancelled"
+)
+
+type StockTake struct {
+	gorm.Model
+	Reference   string          `gorm:"uniqueIndex;not null" json:"reference"`
+	Status      StockTakeStatus `gorm:"type:varchar(20);default:'open'" json:"status"`
+	Notes       string          `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy   uint            `gorm:"index;not null" json:"created_by"`
+	CommittedBy *uint           `gorm:"index" json:"committed_by,omitempty"`
+	CommittedAt *time.Time      `json:"committed_at,omitempty"`
+
+	// Relations
+	Creator User            `gorm:"foreignKey:CreatedBy" json:"-"`
+	Items   []StockTakeItem `gorm:"foreignKey:StockTakeID" json:"items"`
+}
+
+// StockTakeItem holds the counted quantity of one product. ExpectedQuantity
+// and Variance are frozen when the session is committed; while the session
+// is open they are derived from the live product stock.
+type StockTakeItem struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	StockTakeID      uint      `gorm:"uniqueIndex:idx_stock_take_product;not null" json:"stock_take_id"`
+	ProductID        uint      `gorm:"uniqueIndex:idx_stock_take_product;not null" json:"product_id"`
+	CountedQuantity  int       `gorm:"not null" json:"counted_quantity"`
+	ExpectedQuantity int       `gorm:"not null;default:0" json:"expected_quantity"`
+	Variance         int       `gorm:"not null;default:0" json:"variance"`
+	Notes            string    `json:"notes,omitempty"`
+	CountedBy        uint      `gorm:"index;not null" json:"counted_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Relations
+	StockTake StockTake `gorm:"foreignKey:StockTakeID" json:"-"`
+	Product   Product   `gorm:"foreignKey:ProductID" json:"product"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+type Supplier struct {
+	gorm.Model
+	Name        string `gorm:"not null" json:"name"`
+	ContactName string `json:"contact_name,omitempty"`
+	Phone       string `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Address     string `gorm:"type:text" json:"address,omitempty"`
+	Notes       string `gorm:"type:text" json:"notes,omitempty"`
+	IsActive    bool   `gorm:"default:true" json:"is_active"`
+
+	// Relations
+	PurchaseOrders []PurchaseOrder `gorm:"foreignKey:SupplierID" json:"-"`
+}
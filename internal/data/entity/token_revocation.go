@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// TokenRevocation denies signed access tokens issued for Key (a session or a
+// user) at or before RevokedBefore. It only matters until ExpiresAt, when
+// every such token has expired anyway.
+type TokenRevocation struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Key           string    `gorm:"type:varchar(50);index;not null" json:"key"`
+	RevokedBefore time.Time `gorm:"not null" json:"revoked_before"`
+	ExpiresAt     time.Time `gorm:"index;not null" json:"expires_at"`
+	CreatedAt     time.Time `gorm:"index" json:"created_at"`
+}
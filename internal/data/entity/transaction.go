@@ -33,6 +33,7 @@ type Transaction struct {
 	Status            TransactionStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
 	Notes             string            `json:"notes,omitempty"`
 	CreatedBy         uint              `gorm:"index;not null" json:"created_by"`
+	ApprovedBy        *uint             `json:"approved_by,omitempty"`
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 
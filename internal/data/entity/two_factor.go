@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// TwoFactorMethod is how the second login step is answered.
+type TwoFactorMethod string
+
+const (
+	TwoFactorMethodTOTP     TwoFactorMethod = "totp"
+	TwoFactorMethodRecovery TwoFactorMethod = "recovery_code"
+	TwoFactorMethodEmail    TwoFactorMethod = "email"
+)
+
+// UserTwoFactor is a user's authenticator app. It stays pending until a
+// first code confirms it and EnabledAt is set.
+type UserTwoFactor struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"uniqueIndex;not null" json:"user_id"`
+	Secret       string     `gorm:"type:varchar(64);not null" json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// RecoveryCode stands in for the authenticator app once. Only its hash is
+// stored.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LoginChallenge is a login whose password was right but still needs a
+// second factor. It keeps the client details so the session can be created
+// once the challenge is answered.
+type LoginChallenge struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"index;not null" json:"user_id"`
+	TokenHash   string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	DeviceName  string     `gorm:"type:varchar(100)" json:"device_name"`
+	IPAddress   string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent   string     `gorm:"type:varchar(255)" json:"user_agent"`
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
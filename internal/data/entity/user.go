@@ -18,6 +18,7 @@ type User struct {
 	ID           uint           `gorm:"primaryKey" json:"id"`
 	Email        string         `gorm:"uniqueIndex;not null" json:"email"`
 	PasswordHash string         `gorm:"not null" json:"-"`
+	ManagerPIN   string         `gorm:"type:varchar(255)" json:"-"`
 	Role         UserRole       `gorm:"type:varchar(20);not null" json:"role"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
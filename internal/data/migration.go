@@ -7,19 +7,43 @@ import (
 )
 
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		// Auth
 		&entity.User{}, 
+		&entity.Permission{},
+		&entity.Role{},
+		&entity.UserPermission{},
 		&entity.Profile{}, 
+		&entity.SalaryChange{},
 		&entity.Shift{},
+		&entity.Attendance{},
+		&entity.AttendanceCorrection{},
 		&entity.OTP{},
 		&entity.Session{},
+		&entity.TokenRevocation{},
+		&entity.LoginThrottle{},
 		&entity.PasswordReset{},
+		&entity.UserTwoFactor{},
+		&entity.RecoveryCode{},
+		&entity.LoginChallenge{},
+		&entity.Invitation{},
 
 		// Menu
 		&entity.Category{},
 		&entity.Product{},
+		&entity.ModifierGroup{},
+		&entity.ModifierOption{},
 		&entity.InventoryLog{},
+		&entity.Ingredient{},
+		&entity.RecipeItem{},
+		&entity.IngredientLog{},
+		&entity.StockTake{},
+		&entity.StockTakeItem{},
+
+		// Purchasing
+		&entity.Supplier{},
+		&entity.PurchaseOrder{},
+		&entity.PurchaseOrderItem{},
 		
 		// Order
 		&entity.Table{},
@@ -27,11 +51,34 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.Order{},
 		&entity.Reservation{},
 		&entity.OrderItem{},
+		&entity.OrderItemModifier{},
 		
 		// Payment
 		&entity.PaymentMethod{},
 		&entity.Transaction{},
+		&entity.CashDrawerSession{},
+		&entity.CashMovement{},
 
 		&entity.Notification{},
+		&entity.AuditLog{},
 	)
+	if err != nil {
+		return err
+	}
+
+	return backfillCashMethod(db)
+}
+
+// backfillCashMethod flags the seeded "Cash" method on databases created
+// before payment methods had is_cash; without it no sale counts toward the
+// drawer. Nothing changes once any method is flagged.
+func backfillCashMethod(db *gorm.DB) error {
+	var flagged int64
+	if err := db.Model(&entity.PaymentMethod{}).Where("is_cash = ?", true).Count(&flagged).Error; err != nil {
+		return err
+	}
+	if flagged > 0 {
+		return nil
+	}
+	return db.Model(&entity.PaymentMethod{}).Where("LOWER(name) = ?", "cash").Update("is_cash", true).Error
 }
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttendanceParams struct {
+	Offset    int
+	Limit     int
+	ProfileID uint
+	StartDate *time.Time
+	EndDate   *time.Time // exclusive
+}
+
+type AttendanceRepository interface {
+	Create(ctx context.Context, attendance *entity.Attendance) (*entity.Attendance, error)
+	FindByID(ctx context.Context, id uint) (*entity.Attendance, error)
+	FindOpenByProfile(ctx context.Context, profileID uint) (*entity.Attendance, error)
+	FindAll(ctx context.Context, params AttendanceParams) ([]entity.Attendance, int64, error)
+	FindInPeriod(ctx context.Context, start, end time.Time) ([]entity.Attendance, error)
+	Update(ctx context.Context, attendance *entity.Attendance) error
+	CreateCorrection(ctx context.Context, correction *entity.AttendanceCorrection) error
+}
+
+type attendanceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewAttendanceRepo(db *gorm.DB, log *zap.Logger) AttendanceRepository {
+	return &attendanceRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "attendance")),
+	}
+}
+
+func (r *attendanceRepository) Create(ctx context.Context, attendance *entity.Attendance) (*entity.Attendance, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating attendance",
+		zap.Uint("profile_id", attendance.ProfileID),
+		zap.Time("clock_in", attendance.ClockIn))
+
+	if err := db.Omit(clause.Associations).Create(attendance).Error; err != nil {
+		r.logger.Error("Failed to create attendance", zap.Error(err))
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+func (r *attendanceRepository) FindByID(ctx context.Context, id uint) (*entity.Attendance, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var attendance entity.Attendance
+	err := db.
+		Preload("Profile").
+		Preload("Shift").
+		Preload("Corrections", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&attendance, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Attendance not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find attendance", zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
+// FindOpenByProfile returns the punch still waiting for a clock-out, if any.
+func (r *attendanceRepository) FindOpenByProfile(ctx context.Context, profileID uint) (*entity.Attendance, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var attendance entity.Attendance
+	err := db.
+		Preload("Shift").
+		Where("profile_id = ? AND clock_out IS NULL", profileID).
+		Order("clock_in DESC").
+		First(&attendance).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to find open attendance", zap.Uint("profile_id", profileID), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
+func (r *attendanceRepository) FindAll(ctx context.Context, params AttendanceParams) ([]entity.Attendance, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var attendances []entity.Attendance
+	var total int64
+
+	query := db.Model(&entity.Attendance{})
+
+	if params.ProfileID > 0 {
+		query = query.Where("profile_id = ?", params.ProfileID)
+	}
+	if params.StartDate != nil {
+		query = query.Where("clock_in >= ?", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query = query.Where("clock_in < ?", *params.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count attendance", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Profile").
+		Preload("Shift").
+		Order("clock_in DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(&attendances).Error
+	if err != nil {
+		r.logger.Error("Failed to find attendance", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return attendances, total, nil
+}
+
+// FindInPeriod returns every punch that started in [start, end).
+func (r *attendanceRepository) FindInPeriod(ctx context.Context, start, end time.Time) ([]entity.Attendance, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var attendances []entity.Attendance
+	err := db.
+		Preload("Profile").
+		Where("clock_in >= ? AND clock_in < ?", start, end).
+		Order("profile_id ASC, clock_in ASC").
+		Find(&attendances).Error
+	if err != nil {
+		r.logger.Error("Failed to find attendance in period", zap.Error(err))
+		return nil, err
+	}
+
+	return attendances, nil
+}
+
+func (r *attendanceRepository) Update(ctx context.Context, attendance *entity.Attendance) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(attendance).Error; err != nil {
+		r.logger.Error("Failed to update attendance", zap.Uint("id", attendance.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *attendanceRepository) CreateCorrection(ctx context.Context, correction *entity.AttendanceCorrection) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Create(correction).Error; err != nil {
+		r.logger.Error("Failed to create attendance correction",
+			zap.Uint("attendance_id", correction.AttendanceID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuditLogParams struct {
+	Offset     int
+	Limit      int
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	StartDate  *time.Time
+	EndDate    *time.Time // exclusive
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+	FindAll(ctx context.Context, params AuditLogParams) ([]entity.AuditLog, int64, error)
+}
+
+type auditLogRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewAuditLogRepo(db *gorm.DB, log *zap.Logger) AuditLogRepository {
+	return &auditLogRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "audit_log")),
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit("Actor").Create(log).Error; err != nil {
+		r.logger.Error("Failed to create audit log",
+			zap.String("entity_type", log.EntityType),
+			zap.Uint("entity_id", log.EntityID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) FindAll(ctx context.Context, params AuditLogParams) ([]entity.AuditLog, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var logs []entity.AuditLog
+	var total int64
+
+	query := r.applyFilters(db.Model(&entity.AuditLog{}), params)
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count audit logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Actor").
+		Order("created_at DESC, id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(&logs).Error
+	if err != nil {
+		r.logger.Error("Failed to find audit logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func (r *auditLogRepository) applyFilters(query *gorm.DB, params AuditLogParams) *gorm.DB {
+	if params.ActorID > 0 {
+		query = query.Where("actor_id = ?", params.ActorID)
+	}
+	if params.Action != "" {
+		query = query.Where("action = ?", params.Action)
+	}
+	if params.EntityType != "" {
+		query = query.Where("entity_type = ?", params.EntityType)
+	}
+	if params.EntityID > 0 {
+		query = query.Where("entity_id = ?", params.EntityID)
+	}
+	if params.StartDate != nil {
+		query = query.Where("created_at >= ?", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query = query.Where("created_at < ?", *params.EndDate)
+	}
+	return query
+}
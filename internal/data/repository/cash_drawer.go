@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CashDrawerParams struct {
+	Offset    int
+	Limit     int
+	CashierID uint
+	Status    string
+}
+
+// PaymentSummaryRow is the total of completed transactions for one payment
+// method and transaction type.
+type PaymentSummaryRow struct {
+	PaymentMethodID   uint
+	PaymentMethodName string
+	IsCash            bool
+	TransactionType   entity.TransactionType
+	Count             int64
+	Total             float64
+}
+
+type CashDrawerRepository interface {
+	Create(ctx context.Context, session *entity.CashDrawerSession) (*entity.CashDrawerSession, error)
+	FindByID(ctx context.Context, id uint) (*entity.CashDrawerSession, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*entity.CashDrawerSession, error)
+	FindOpenByCashier(ctx context.Context, cashierID uint) (*entity.CashDrawerSession, error)
+	FindAll(ctx context.Context, params CashDrawerParams) ([]entity.CashDrawerSession, int64, error)
+	Update(ctx context.Context, session *entity.CashDrawerSession) error
+	CreateMovement(ctx context.Context, movement *entity.CashMovement) error
+	SummarizeTransactions(ctx context.Context, cashierID uint, from, to time.Time) ([]PaymentSummaryRow, error)
+}
+
+type cashDrawerRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewCashDrawerRepo(db *gorm.DB, log *zap.Logger) CashDrawerRepository {
+	return &cashDrawerRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "cash_drawer")),
+	}
+}
+
+func (r *cashDrawerRepository) Create(ctx context.Context, session *entity.CashDrawerSession) (*entity.CashDrawerSession, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Opening cash drawer session",
+		zap.Uint("cashier_id", session.CashierID),
+		zap.Float64("opening_float", session.OpeningFloat))
+
+	if err := db.Omit(clause.Associations).Create(session).Error; err != nil {
+		r.logger.Error("Failed to create cash drawer session", zap.Error(err))
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *cashDrawerRepository) FindByID(ctx context.Context, id uint) (*entity.CashDrawerSession, error) {
+	return r.findByID(ctx, infra.GetDB(ctx, r.db), id)
+}
+
+// FindByIDForUpdate locks the session row so concurrent pay-outs and close-out
+// see a consistent state.
+func (r *cashDrawerRepository) FindByIDForUpdate(ctx context.Context, id uint) (*entity.CashDrawerSession, error) {
+	db := infra.GetDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"})
+	return r.findByID(ctx, db, id)
+}
+
+func (r *cashDrawerRepository) findByID(ctx context.Context, db *gorm.DB, id uint) (*entity.CashDrawerSession, error) {
+	var session entity.CashDrawerSession
+	err := db.
+		Preload("Movements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&session, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Cash drawer session not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find cash drawer session", zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *cashDrawerRepository) FindOpenByCashier(ctx context.Context, cashierID uint) (*entity.CashDrawerSession, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var session entity.CashDrawerSession
+	err := db.
+		Preload("Movements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("cashier_id = ? AND status = ?", cashierID, entity.CashDrawerStatusOpen).
+		First(&session).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to find open cash drawer session", zap.Uint("cashier_id", cashierID), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *cashDrawerRepository) FindAll(ctx context.Context, params CashDrawerParams) ([]entity.CashDrawerSession, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var sessions []entity.CashDrawerSession
+	var total int64
+
+	query := db.Model(&entity.CashDrawerSession{})
+
+	if params.CashierID > 0 {
+		query = query.Where("cashier_id = ?", params.CashierID)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count cash drawer sessions", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("opened_at DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(&sessions).Error
+	if err != nil {
+		r.logger.Error("Failed to find cash drawer sessions", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return sessions, total, nil
+}
+
+func (r *cashDrawerRepository) Update(ctx context.Context, session *entity.CashDrawerSession) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(session).Error; err != nil {
+		r.logger.Error("Failed to update cash drawer session", zap.Uint("id", session.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *cashDrawerRepository) CreateMovement(ctx context.Context, movement *entity.CashMovement) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Create(movement).Error; err != nil {
+		r.logger.Error("Failed to create cash movement",
+			zap.Uint("session_id", movement.SessionID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// SummarizeTransactions totals the cashier's completed transactions created
+// in [from, to), grouped by payment method and transaction type.
+func (r *cashDrawerRepository) SummarizeTransactions(ctx context.Context, cashierID uint, from, to time.Time) ([]PaymentSummaryRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []PaymentSummaryRow
+	err := db.Table("transactions t").
+		Select(`t.payment_method_id,
+			pm.name AS payment_method_name,
+			pm.is_cash,
+			t.transaction_type,
+			COUNT(*) AS count,
+			COALESCE(SUM(t.amount), 0) AS total`).
+		Joins("JOIN payment_methods pm ON pm.id = t.payment_method_id").
+		Where("t.status = ? AND t.created_by = ? AND t.created_at >= ? AND t.created_at < ?",
+			entity.TransactionStatusCompleted, cashierID, from, to).
+		Group("t.payment_method_id, pm.name, pm.is_cash, t.transaction_type").
+		Order("pm.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to summarize transactions",
+			zap.Uint("cashier_id", cashierID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
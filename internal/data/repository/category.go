@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
 	"project-POS-APP-golang-integer/pkg/utils"
 
 	"go.uber.org/zap"
@@ -13,10 +15,11 @@ type CategoryRepository interface {
 	FindAllWithPagination(name string, page, perPage int) ([]entity.Category, int64, error)
 	FindByID(id uint) (*entity.Category, error)
 	FindByName(name string) (*entity.Category, error)
-	Create(category *entity.Category) error
-	Update(category *entity.Category) error
-	SoftDelete(id uint) error
+	Create(ctx context.Context, category *entity.Category) error
+	Update(ctx context.Context, category *entity.Category) error
+	SoftDelete(ctx context.Context, id uint) error
 	CheckHasProducts(id uint) (bool, error)
+	UpdateIconURL(ctx context.Context, id uint, url string) error
 }
 
 type categoryRepository struct {
@@ -78,16 +81,22 @@ func (r *categoryRepository) FindByName(name string) (*entity.Category, error) {
 	return &category, nil
 }
 
-func (r *categoryRepository) Create(category *entity.Category) error {
-	return r.db.Create(category).Error
+func (r *categoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	return infra.GetDB(ctx, r.db).Create(category).Error
 }
 
-func (r *categoryRepository) Update(category *entity.Category) error {
-	return r.db.Save(category).Error
+func (r *categoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	return infra.GetDB(ctx, r.db).Save(category).Error
 }
 
-func (r *categoryRepository) SoftDelete(id uint) error {
-	result := r.db.Model(&entity.Category{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()"))
+// UpdateIconURL sets only the icon, leaving the rest of the row as it is.
+func (r *categoryRepository) UpdateIconURL(ctx context.Context, id uint, url string) error {
+	db := infra.GetDB(ctx, r.db)
+	return db.Model(&entity.Category{}).Where("id = ?", id).Update("icon_url", url).Error
+}
+
+func (r *categoryRepository) SoftDelete(ctx context.Context, id uint) error {
+	result := infra.GetDB(ctx, r.db).Model(&entity.Category{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()"))
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IngredientRepository interface {
+	Create(ctx context.Context, ingredient *entity.Ingredient) (*entity.Ingredient, error)
+	FindByID(ctx context.Context, id uint) (*entity.Ingredient, error)
+	FindByName(ctx context.Context, name string) (*entity.Ingredient, error)
+	FindAll(ctx context.Context, params request.GetIngredientsRequest) ([]entity.Ingredient, int64, error)
+	Update(ctx context.Context, ingredient *entity.Ingredient) error
+	Delete(ctx context.Context, id uint) error
+	AdjustStock(ctx context.Context, id uint, delta float64) (*entity.Ingredient, error)
+	CreateLog(ctx context.Context, log *entity.IngredientLog) (*entity.IngredientLog, error)
+	FindLogs(ctx context.Context, ingredientID uint, params request.PaginationRequest) ([]entity.IngredientLog, int64, error)
+}
+
+type ingredientRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewIngredientRepo(db *gorm.DB, log *zap.Logger) IngredientRepository {
+	return &ingredientRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "ingredient")),
+	}
+}
+
+func (r *ingredientRepository) Create(ctx context.Context, ingredient *entity.Ingredient) (*entity.Ingredient, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating ingredient", zap.String("name", ingredient.Name))
+
+	err := db.Create(ingredient).Error
+	if err != nil {
+		r.logger.Error("Failed to create ingredient",
+			zap.String("name", ingredient.Name),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+func (r *ingredientRepository) FindByID(ctx context.Context, id uint) (*entity.Ingredient, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var ingredient entity.Ingredient
+	err := db.First(&ingredient, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Ingredient not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find ingredient",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &ingredient, nil
+}
+
+func (r *ingredientRepository) FindByName(ctx context.Context, name string) (*entity.Ingredient, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var ingredient entity.Ingredient
+	err := db.Where("LOWER(name) = LOWER(?)", name).First(&ingredient).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingredient, nil
+}
+
+func (r *ingredientRepository) FindAll(ctx context.Context, params request.GetIngredientsRequest) ([]entity.Ingredient, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Debug("Finding ingredients",
+		zap.String("search", params.Search),
+		zap.Bool("low_stock", params.LowStock))
+
+	var ingredients []entity.Ingredient
+	var total int64
+
+	query := db.Model(&entity.Ingredient{})
+
+	if params.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+params.Search+"%")
+	}
+	if params.LowStock {
+		query = query.Where("stock <= min_stock")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count ingredients", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("name ASC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&ingredients).Error
+	if err != nil {
+		r.logger.Error("Failed to find ingredients", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return ingredients, total, nil
+}
+
+func (r *ingredientRepository) Update(ctx context.Context, ingredient *entity.Ingredient) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating ingredient", zap.Uint("id", ingredient.ID))
+
+	err := db.Omit(clause.Associations).Save(ingredient).Error
+	if err != nil {
+		r.logger.Error("Failed to update ingredient",
+			zap.Uint("id", ingredient.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *ingredientRepository) Delete(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Deleting ingredient", zap.Uint("id", id))
+
+	if err := db.Where("ingredient_id = ?", id).Delete(&entity.RecipeItem{}).Error; err != nil {
+		r.logger.Error("Failed to delete recipe items of ingredient",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	if err := db.Delete(&entity.Ingredient{}, id).Error; err != nil {
+		r.logger.Error("Failed to delete ingredient",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AdjustStock locks the ingredient row, applies delta and returns the
+// updated ingredient so callers can log the resulting stock level.
+func (r *ingredientRepository) AdjustStock(ctx context.Context, id uint, delta float64) (*entity.Ingredient, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var ingredient entity.Ingredient
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ingredient, id).Error
+	if err != nil {
+		r.logger.Error("Failed to lock ingredient",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return nil, err
+	}
+
+	ingredient.Stock += delta
+	err = db.Model(&ingredient).Update("stock", ingredient.Stock).Error
+	if err != nil {
+		r.logger.Error("Failed to adjust ingredient stock",
+			zap.Uint("id", id),
+			zap.Float64("delta", delta),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return &ingredient, nil
+}
+
+func (r *ingredientRepository) CreateLog(ctx context.Context, log *entity.IngredientLog) (*entity.IngredientLog, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Omit(clause.Associations).Create(log).Error
+	if err != nil {
+		r.logger.Error("Failed to create ingredient log",
+			zap.Uint("ingredient_id", log.IngredientID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return log, nil
+}
+
+func (r *ingredientRepository) FindLogs(ctx context.Context, ingredientID uint, params request.PaginationRequest) ([]entity.IngredientLog, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var logs []entity.IngredientLog
+	var total int64
+
+	query := db.Model(&entity.IngredientLog{}).Where("ingredient_id = ?", ingredientID)
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count ingredient logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&logs).Error
+	if err != nil {
+		r.logger.Error("Failed to find ingredient logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
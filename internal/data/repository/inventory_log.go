@@ -4,6 +4,7 @@ import (
 	"context"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/infra"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -11,7 +12,10 @@ import (
 
 type InventoryLogRepository interface {
 	GetInventoryLogs(ctx context.Context, f InventoryLogParams) ([]entity.InventoryLog, int64, error)
+	StreamInventoryLogs(ctx context.Context, f InventoryLogParams, fn func([]entity.InventoryLog) error) error
 	CreateInventoryLog(ctx context.Context, inventory *entity.InventoryLog) (*entity.InventoryLog, error)
+	GetProductHistory(ctx context.Context, productID uint, start, end *time.Time) ([]entity.InventoryLog, error)
+	GetLastLogBefore(ctx context.Context, productID uint, before time.Time) (*entity.InventoryLog, error)
 }
 
 type inventoryLogRepository struct {
@@ -22,6 +26,14 @@ type inventoryLogRepository struct {
 type InventoryLogParams struct {
 	Offset int
 	Limit int
+	ProductID uint
+	Type string
+	ReferenceType string
+	CreatedBy uint
+	StartDate *time.Time
+	EndDate *time.Time // exclusive
+	SortBy string
+	SortOrder string
 }
 
 func NewInventoryLogRepo(db *gorm.DB, log *zap.Logger) InventoryLogRepository {
@@ -35,14 +47,24 @@ func (r *inventoryLogRepository) GetInventoryLogs(ctx context.Context, f Invento
 	db := infra.GetDB(ctx, r.db)
 	var logs []entity.InventoryLog
 	var total int64
-	query := db.Model(&entity.InventoryLog{})
-	
+	query := r.applyLogFilters(db.Model(&entity.InventoryLog{}), f)
+
 	// Get total inventory logs
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Limit(f.Limit).Offset(f.Offset).Find(&logs).Error
+	// Sort columns are whitelisted by the request validation
+	sortBy := "created_at"
+	if f.SortBy != "" {
+		sortBy = f.SortBy
+	}
+	sortOrder := "DESC"
+	if f.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+
+	err := query.Order(sortBy + " " + sortOrder + ", id " + sortOrder).Limit(f.Limit).Offset(f.Offset).Find(&logs).Error
 	if err != nil {
 		r.Logger.Error("Error query get inventory logs", zap.Error(err))
 		return nil, 0, err
@@ -51,6 +73,46 @@ func (r *inventoryLogRepository) GetInventoryLogs(ctx context.Context, f Invento
 	return logs, total, nil
 }
 
+// StreamInventoryLogs walks every log matching f in batches of exportBatchSize.
+func (r *inventoryLogRepository) StreamInventoryLogs(ctx context.Context, f InventoryLogParams, fn func([]entity.InventoryLog) error) error {
+	db := infra.GetDB(ctx, r.db)
+
+	var batch []entity.InventoryLog
+	err := r.applyLogFilters(db.Model(&entity.InventoryLog{}), f).
+		Preload("Product").
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		r.Logger.Error("Error query stream inventory logs", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *inventoryLogRepository) applyLogFilters(query *gorm.DB, f InventoryLogParams) *gorm.DB {
+	if f.ProductID > 0 {
+		query = query.Where("product_id = ?", f.ProductID)
+	}
+	if f.Type != "" {
+		query = query.Where("type = ?", f.Type)
+	}
+	if f.ReferenceType != "" {
+		query = query.Where("reference_type = ?", f.ReferenceType)
+	}
+	if f.CreatedBy > 0 {
+		query = query.Where("created_by = ?", f.CreatedBy)
+	}
+	if f.StartDate != nil {
+		query = query.Where("created_at >= ?", *f.StartDate)
+	}
+	if f.EndDate != nil {
+		query = query.Where("created_at < ?", *f.EndDate)
+	}
+	return query
+}
+
 func (r *inventoryLogRepository) CreateInventoryLog(ctx context.Context, inventory *entity.InventoryLog) (*entity.InventoryLog, error) {
 	db := infra.GetDB(ctx, r.db)
 	err := db.Create(&inventory).Error
@@ -59,4 +121,39 @@ func (r *inventoryLogRepository) CreateInventoryLog(ctx context.Context, invento
 		return nil, err
 	}
 	return inventory, err
-}
\ No newline at end of file
+}
+
+func (r *inventoryLogRepository) GetProductHistory(ctx context.Context, productID uint, start, end *time.Time) ([]entity.InventoryLog, error) {
+	db := infra.GetDB(ctx, r.db)
+	var logs []entity.InventoryLog
+
+	query := db.Where("product_id = ?", productID)
+	if start != nil {
+		query = query.Where("created_at >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where("created_at < ?", *end)
+	}
+
+	err := query.Order("created_at ASC, id ASC").Find(&logs).Error
+	if err != nil {
+		r.Logger.Error("Error query get product stock history", zap.Error(err))
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (r *inventoryLogRepository) GetLastLogBefore(ctx context.Context, productID uint, before time.Time) (*entity.InventoryLog, error) {
+	db := infra.GetDB(ctx, r.db)
+	var log entity.InventoryLog
+
+	err := db.Where("product_id = ? AND created_at < ?", productID, before).
+		Order("created_at DESC, id DESC").
+		First(&log).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
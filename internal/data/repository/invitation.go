@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) error
+	FindValidByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	FindLatestByUser(ctx context.Context, userID uint) (*entity.Invitation, error)
+	MarkAccepted(ctx context.Context, id uint) error
+	RevokeByUser(ctx context.Context, userID uint) (int64, error)
+}
+
+type invitationRepository struct {
+	db     *gorm.DB
+	Logger *zap.Logger
+}
+
+func NewInvitationRepo(db *gorm.DB, log *zap.Logger) InvitationRepository {
+	return &invitationRepository{
+		db:     db,
+		Logger: log,
+	}
+}
+
+func (r *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	if err := infra.GetDB(ctx, r.db).Create(invitation).Error; err != nil {
+		r.Logger.Error("Error create invitation", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindValidByTokenHash returns the invitation if it is neither accepted,
+// revoked nor expired.
+func (r *invitationRepository) FindValidByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+	err := infra.GetDB(ctx, r.db).
+		Where("token_hash = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindLatestByUser returns the user's most recent invitation, whatever its
+// state.
+func (r *invitationRepository) FindLatestByUser(ctx context.Context, userID uint) (*entity.Invitation, error) {
+	var invitation entity.Invitation
+	err := infra.GetDB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// MarkAccepted redeems the invitation. It returns gorm.ErrRecordNotFound
+// when it was already accepted or revoked, so it works only once.
+func (r *invitationRepository) MarkAccepted(ctx context.Context, id uint) error {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("accepted_at", time.Now())
+	if res.Error != nil {
+		r.Logger.Error("Error accept invitation", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RevokeByUser voids every outstanding invitation of the user and returns
+// how many there were.
+func (r *invitationRepository) RevokeByUser(ctx context.Context, userID uint) (int64, error) {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.Invitation{}).
+		Where("user_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		r.Logger.Error("Error revoke invitations", zap.Error(res.Error))
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
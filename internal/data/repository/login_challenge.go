@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type LoginChallengeRepository interface {
+	Create(ctx context.Context, challenge *entity.LoginChallenge) error
+	FindActiveByTokenHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error)
+	RecordFailedAttempt(ctx context.Context, id uint, maxAttempts int) (int, error)
+	Complete(ctx context.Context, id uint) error
+}
+
+type loginChallengeRepository struct {
+	db     *gorm.DB
+	Logger *zap.Logger
+}
+
+func NewLoginChallengeRepo(db *gorm.DB, log *zap.Logger) LoginChallengeRepository {
+	return &loginChallengeRepository{
+		db:     db,
+		Logger: log,
+	}
+}
+
+func (r *loginChallengeRepository) Create(ctx context.Context, challenge *entity.LoginChallenge) error {
+	if err := infra.GetDB(ctx, r.db).Create(challenge).Error; err != nil {
+		r.Logger.Error("Error create login challenge", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *loginChallengeRepository) FindActiveByTokenHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error) {
+	var challenge entity.LoginChallenge
+	err := infra.GetDB(ctx, r.db).
+		Where("token_hash = ? AND completed_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&challenge).Error
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordFailedAttempt counts a wrong code against the challenge and closes
+// it once maxAttempts is reached. It returns the attempts made so far.
+func (r *loginChallengeRepository) RecordFailedAttempt(ctx context.Context, id uint, maxAttempts int) (int, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Model(&entity.LoginChallenge{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"completed_at": gorm.Expr("CASE WHEN attempts + 1 >= ? THEN ? ELSE completed_at END", maxAttempts, time.Now()),
+		}).Error
+	if err != nil {
+		r.Logger.Error("Error record login challenge attempt", zap.Error(err))
+		return 0, err
+	}
+
+	var challenge entity.LoginChallenge
+	if err := db.Select("attempts").First(&challenge, id).Error; err != nil {
+		r.Logger.Error("Error get login challenge attempts", zap.Error(err))
+		return 0, err
+	}
+	return challenge.Attempts, nil
+}
+
+// Complete closes the challenge. It returns gorm.ErrRecordNotFound when it
+// was already closed, so one answer cannot open two sessions.
+func (r *loginChallengeRepository) Complete(ctx context.Context, id uint) error {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.LoginChallenge{}).
+		Where("id = ? AND completed_at IS NULL", id).
+		Update("completed_at", time.Now())
+	if res.Error != nil {
+		r.Logger.Error("Error complete login challenge", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginThrottleRepository interface {
+	Find(ctx context.Context, key string) (*entity.LoginThrottle, error)
+	FindForUpdate(ctx context.Context, key string) (*entity.LoginThrottle, error)
+	Save(ctx context.Context, throttle *entity.LoginThrottle) error
+	Delete(ctx context.Context, key string) error
+}
+
+type loginThrottleRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewLoginThrottleRepo(db *gorm.DB, log *zap.Logger) LoginThrottleRepository {
+	return &loginThrottleRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "login_throttle")),
+	}
+}
+
+// Find returns the throttle for key, or an empty one when the key has no
+// recorded failures.
+func (r *loginThrottleRepository) Find(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	return r.find(infra.GetDB(ctx, r.db), key)
+}
+
+// FindForUpdate is Find with the row locked until the transaction ends. The
+// row is created first when missing, so the first attempts for a key queue
+// on the lock as well.
+func (r *loginThrottleRepository) FindForUpdate(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.LoginThrottle{Key: key}).Error
+	if err != nil {
+		r.logger.Error("Failed to create login throttle", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	return r.find(db.Clauses(clause.Locking{Strength: "UPDATE"}), key)
+}
+
+func (r *loginThrottleRepository) find(db *gorm.DB, key string) (*entity.LoginThrottle, error) {
+	var throttle entity.LoginThrottle
+	err := db.Where("key = ?", key).First(&throttle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.LoginThrottle{Key: key}, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to find login throttle", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	return &throttle, nil
+}
+
+// Save inserts or updates the throttle by key, so two first failures racing
+// for the same key do not collide on the unique index.
+func (r *loginThrottleRepository) Save(ctx context.Context, throttle *entity.LoginThrottle) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"failures", "last_failure_at", "locked_until", "updated_at"}),
+	}).Create(throttle).Error
+	if err != nil {
+		r.logger.Error("Failed to save login throttle", zap.String("key", throttle.Key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *loginThrottleRepository) Delete(ctx context.Context, key string) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Where("key = ?", key).Delete(&entity.LoginThrottle{}).Error; err != nil {
+		r.logger.Error("Failed to delete login throttle", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ModifierRepository interface {
+	CreateGroup(ctx context.Context, group *entity.ModifierGroup) (*entity.ModifierGroup, error)
+	FindGroupByID(ctx context.Context, id uint) (*entity.ModifierGroup, error)
+	FindGroupsByProductID(ctx context.Context, productID uint) ([]entity.ModifierGroup, error)
+	UpdateGroup(ctx context.Context, group *entity.ModifierGroup) error
+	DeleteGroup(ctx context.Context, id uint) error
+	CreateOption(ctx context.Context, option *entity.ModifierOption) (*entity.ModifierOption, error)
+	FindOptionByID(ctx context.Context, id uint) (*entity.ModifierOption, error)
+	UpdateOption(ctx context.Context, option *entity.ModifierOption) error
+	DeleteOption(ctx context.Context, id uint) error
+}
+
+type modifierRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewModifierRepo(db *gorm.DB, log *zap.Logger) ModifierRepository {
+	return &modifierRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "modifier")),
+	}
+}
+
+func (r *modifierRepository) CreateGroup(ctx context.Context, group *entity.ModifierGroup) (*entity.ModifierGroup, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating modifier group",
+		zap.Uint("product_id", group.ProductID),
+		zap.String("name", group.Name))
+
+	err := db.Create(group).Error
+	if err != nil {
+		r.logger.Error("Failed to create modifier group",
+			zap.Uint("product_id", group.ProductID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (r *modifierRepository) FindGroupByID(ctx context.Context, id uint) (*entity.ModifierGroup, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Debug("Finding modifier group by ID", zap.Uint("id", id))
+
+	var group entity.ModifierGroup
+	err := db.
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
+		First(&group, id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Modifier group not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find modifier group",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *modifierRepository) FindGroupsByProductID(ctx context.Context, productID uint) ([]entity.ModifierGroup, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Debug("Finding modifier groups by product", zap.Uint("product_id", productID))
+
+	var groups []entity.ModifierGroup
+	err := db.
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
+		Where("product_id = ?", productID).
+		Order("sort_order ASC, id ASC").
+		Find(&groups).Error
+
+	if err != nil {
+		r.logger.Error("Failed to find modifier groups by product",
+			zap.Uint("product_id", productID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *modifierRepository) UpdateGroup(ctx context.Context, group *entity.ModifierGroup) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating modifier group", zap.Uint("id", group.ID))
+
+	err := db.Omit("Options", "Product").Save(group).Error
+	if err != nil {
+		r.logger.Error("Failed to update modifier group",
+			zap.Uint("id", group.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *modifierRepository) DeleteGroup(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Deleting modifier group", zap.Uint("id", id))
+
+	// Options belong to the group, remove them together
+	err := db.Where("group_id = ?", id).Delete(&entity.ModifierOption{}).Error
+	if err != nil {
+		r.logger.Error("Failed to delete modifier options",
+			zap.Uint("group_id", id),
+			zap.Error(err))
+		return err
+	}
+
+	err = db.Delete(&entity.ModifierGroup{}, id).Error
+	if err != nil {
+		r.logger.Error("Failed to delete modifier group",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *modifierRepository) CreateOption(ctx context.Context, option *entity.ModifierOption) (*entity.ModifierOption, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating modifier option",
+		zap.Uint("group_id", option.GroupID),
+		zap.String("name", option.Name))
+
+	err := db.Create(option).Error
+	if err != nil {
+		r.logger.Error("Failed to create modifier option",
+			zap.Uint("group_id", option.GroupID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (r *modifierRepository) FindOptionByID(ctx context.Context, id uint) (*entity.ModifierOption, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Debug("Finding modifier option by ID", zap.Uint("id", id))
+
+	var option entity.ModifierOption
+	err := db.First(&option, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Modifier option not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find modifier option",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+func (r *modifierRepository) UpdateOption(ctx context.Context, option *entity.ModifierOption) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating modifier option", zap.Uint("id", option.ID))
+
+	err := db.Omit("Group").Save(option).Error
+	if err != nil {
+		r.logger.Error("Failed to update modifier option",
+			zap.Uint("id", option.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *modifierRepository) DeleteOption(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Deleting modifier option", zap.Uint("id", id))
+
+	err := db.Delete(&entity.ModifierOption{}, id).Error
+	if err != nil {
+		r.logger.Error("Failed to delete modifier option",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *entity.Order) (*entity.Order, error)
+	FindByID(ctx context.Context, id uint) (*entity.Order, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*entity.Order, error)
+	FindAll(ctx context.Context, params request.GetOrdersRequest) ([]entity.Order, int64, error)
+	Stream(ctx context.Context, params request.GetOrdersRequest, fn func([]entity.Order) error) error
+	Update(ctx context.Context, order *entity.Order) error
+	CreateItem(ctx context.Context, item *entity.OrderItem) (*entity.OrderItem, error)
+	FindItemByID(ctx context.Context, id uint) (*entity.OrderItem, error)
+	SetItemBumpedAt(ctx context.Context, id uint, bumpedAt *time.Time) error
+	FindKitchenItems(ctx context.Context, station string) ([]entity.OrderItem, error)
+}
+
+type orderRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewOrderRepo(db *gorm.DB, log *zap.Logger) OrderRepository {
+	return &orderRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "order")),
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating order",
+		zap.String("order_number", order.OrderNumber),
+		zap.Uint("table_id", order.TableID),
+		zap.Int("items", len(order.OrderItems)))
+
+	// Items and their modifiers are inserted together with the order
+	err := db.Omit("Customer", "Table", "Creator").Create(order).Error
+	if err != nil {
+		r.logger.Error("Failed to create order",
+			zap.String("order_number", order.OrderNumber),
+			zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Order created successfully",
+		zap.Uint("id", order.ID),
+		zap.String("order_number", order.OrderNumber))
+
+	return order, nil
+}
+
+func (r *orderRepository) FindByID(ctx context.Context, id uint) (*entity.Order, error) {
+	return r.findByID(infra.GetDB(ctx, r.db), id)
+}
+
+// FindByIDForUpdate locks the order row so concurrent KDS bumps roll the
+// order status up one at a time.
+func (r *orderRepository) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Order, error) {
+	db := infra.GetDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"})
+	return r.findByID(db, id)
+}
+
+func (r *orderRepository) findByID(db *gorm.DB, id uint) (*entity.Order, error) {
+	r.logger.Debug("Finding order by ID", zap.Uint("id", id))
+
+	var order entity.Order
+	err := db.
+		Preload("Table").
+		Preload("Customer").
+		Preload("OrderItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("OrderItems.Product").
+		Preload("OrderItems.Modifiers").
+		First(&order, id).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Order not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find order",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *orderRepository) FindAll(ctx context.Context, params request.GetOrdersRequest) ([]entity.Order, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Debug("Finding orders",
+		zap.String("status", params.Status),
+		zap.String("date", params.Date),
+		zap.Int("page", params.GetPage()),
+		zap.Int("per_page", params.GetPerPage()))
+
+	var orders []entity.Order
+	var total int64
+
+	query := r.applyOrderFilters(db.Model(&entity.Order{}), params).
+		Preload("Table").
+		Preload("OrderItems").
+		Preload("OrderItems.Modifiers")
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count orders", zap.Error(err))
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	offset := params.GetOffset()
+	limit := params.GetPerPage()
+
+	err := query.
+		Offset(offset).
+		Limit(limit).
+		Order("created_at DESC").
+		Find(&orders).Error
+
+	if err != nil {
+		r.logger.Error("Failed to find orders",
+			zap.Error(err),
+			zap.Int("offset", offset),
+			zap.Int("limit", limit))
+		return nil, 0, err
+	}
+
+	r.logger.Debug("Orders retrieved",
+		zap.Int("count", len(orders)),
+		zap.Int64("total", total))
+
+	return orders, total, nil
+}
+
+// Stream walks every order matching params in batches of exportBatchSize,
+// oldest first, so exports never hold the whole result in memory.
+func (r *orderRepository) Stream(ctx context.Context, params request.GetOrdersRequest, fn func([]entity.Order) error) error {
+	db := infra.GetDB(ctx, r.db)
+
+	var batch []entity.Order
+	err := r.applyOrderFilters(db.Model(&entity.Order{}), params).
+		Preload("Table").
+		Preload("OrderItems").
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to stream orders", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *orderRepository) applyOrderFilters(query *gorm.DB, params request.GetOrdersRequest) *gorm.DB {
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if params.TableID > 0 {
+		query = query.Where("table_id = ?", params.TableID)
+	}
+
+	if params.Date != "" {
+		date, err := time.Parse("2006-01-02", params.Date)
+		if err == nil {
+			query = query.Where("DATE(created_at) = ?", date.Format("2006-01-02"))
+		}
+	}
+
+	if params.StartDate != "" {
+		if start, err := time.ParseInLocation("2006-01-02", params.StartDate, time.Local); err == nil {
+			query = query.Where("created_at >= ?", start)
+		}
+	}
+	if params.EndDate != "" {
+		if end, err := time.ParseInLocation("2006-01-02", params.EndDate, time.Local); err == nil {
+			query = query.Where("created_at < ?", end.AddDate(0, 0, 1))
+		}
+	}
+
+	return query
+}
+
+func (r *orderRepository) Update(ctx context.Context, order *entity.Order) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating order",
+		zap.Uint("id", order.ID),
+		zap.String("status", string(order.Status)))
+
+	err := db.Omit(clause.Associations).Save(order).Error
+	if err != nil {
+		r.logger.Error("Failed to update order",
+			zap.Uint("id", order.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *orderRepository) CreateItem(ctx context.Context, item *entity.OrderItem) (*entity.OrderItem, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Adding order item",
+		zap.Uint("order_id", item.OrderID),
+		zap.Uint("product_id", item.ProductID),
+		zap.Int("quantity", item.Quantity))
+
+	err := db.Omit("Order", "Product").Create(item).Error
+	if err != nil {
+		r.logger.Error("Failed to add order item",
+			zap.Uint("order_id", item.OrderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (r *orderRepository) FindItemByID(ctx context.Context, id uint) (*entity.OrderItem, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var item entity.OrderItem
+	if err := db.First(&item, id).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.logger.Error("Failed to find order item", zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// SetItemBumpedAt marks an item as done on the KDS, or clears the mark when
+// bumpedAt is nil.
+func (r *orderRepository) SetItemBumpedAt(ctx context.Context, id uint, bumpedAt *time.Time) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Model(&entity.OrderItem{}).Where("id = ?", id).Update("bumped_at", bumpedAt).Error
+	if err != nil {
+		r.logger.Error("Failed to update order item bump", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// FindKitchenItems returns the items still waiting on the KDS, oldest first.
+// Items only count while their order is open; station filters on the
+// product category's station when set.
+func (r *orderRepository) FindKitchenItems(ctx context.Context, station string) ([]entity.OrderItem, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	query := db.Model(&entity.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id AND orders.deleted_at IS NULL").
+		Joins("JOIN products ON products.id = order_items.product_id").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Where("order_items.bumped_at IS NULL").
+		Where("orders.status IN ?", []entity.OrderStatus{
+			entity.OrderStatusPending,
+			entity.OrderStatusInProcess,
+			entity.OrderStatusCooking,
+		})
+
+	if station != "" {
+		query = query.Where("COALESCE(NULLIF(categories.station, ''), ?) = ?", entity.DefaultStation, station)
+	}
+
+	var items []entity.OrderItem
+	err := query.
+		Preload("Order").
+		Preload("Order.Table").
+		Preload("Product").
+		Preload("Product.Category").
+		Preload("Modifiers").
+		Order("order_items.created_at ASC, order_items.id ASC").
+		Find(&items).Error
+	if err != nil {
+		r.logger.Error("Failed to find kitchen items", zap.String("station", station), zap.Error(err))
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -13,7 +13,10 @@ import (
 type OTPRepository interface {
 	Create(ctx context.Context, otp *entity.OTP) error
 	MarkUsed(ctx context.Context, otpID uint) error
-	GetValidByUser(ctx context.Context, userID uint) (*entity.OTP, error)
+	GetValidByUser(ctx context.Context, userID uint, purpose entity.OTPPurpose) (*entity.OTP, error)
+	RecordFailedAttempt(ctx context.Context, otpID uint, maxAttempts int) (int, error)
+	InvalidateByUser(ctx context.Context, userID uint) error
+	InvalidateByPurpose(ctx context.Context, userID uint, purpose entity.OTPPurpose) error
 }
 
 type otpRepository struct {
@@ -37,13 +40,14 @@ func (r *otpRepository) Create(ctx context.Context, otp *entity.OTP) error {
 	return nil
 }
 
-func (r *otpRepository) GetValidByUser(ctx context.Context, userID uint) (*entity.OTP, error) {
+func (r *otpRepository) GetValidByUser(ctx context.Context, userID uint, purpose entity.OTPPurpose) (*entity.OTP, error) {
 	var otp entity.OTP
 
-	err := r.db.
+	err := infra.GetDB(ctx, r.db).
 		Where(
-			"user_id = ? AND expires_at > ? AND is_used = false",
+			"user_id = ? AND purpose = ? AND expires_at > ? AND is_used = false",
 			userID,
+			purpose,
 			time.Now(),
 		).
 		Order("created_at DESC").
@@ -57,16 +61,77 @@ func (r *otpRepository) GetValidByUser(ctx context.Context, userID uint) (*entit
 	return &otp, nil
 }
 
+// MarkUsed consumes the OTP. It returns gorm.ErrRecordNotFound when the OTP
+// was already used, so a code cannot be redeemed twice.
 func (r *otpRepository) MarkUsed(ctx context.Context, otpID uint) error {
-	err := r.db.
+	res := infra.GetDB(ctx, r.db).
 		Model(&entity.OTP{}).
-		Where("id = ?", otpID).
+		Where("id = ? AND is_used = false", otpID).
+		Update("is_used", true)
+
+	if res.Error != nil {
+		r.Logger.Error("Error mark OTP used", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// InvalidateByUser voids every outstanding OTP of the user.
+func (r *otpRepository) InvalidateByUser(ctx context.Context, userID uint) error {
+	err := infra.GetDB(ctx, r.db).
+		Model(&entity.OTP{}).
+		Where("user_id = ? AND is_used = false", userID).
+		Update("is_used", true).
+		Error
+
+	if err != nil {
+		r.Logger.Error("Error invalidate OTPs", zap.Error(err))
+	}
+
+	return err
+}
+
+// InvalidateByPurpose voids the outstanding OTPs of the user sent for one
+// flow, leaving codes of other flows alone.
+func (r *otpRepository) InvalidateByPurpose(ctx context.Context, userID uint, purpose entity.OTPPurpose) error {
+	err := infra.GetDB(ctx, r.db).
+		Model(&entity.OTP{}).
+		Where("user_id = ? AND purpose = ? AND is_used = false", userID, purpose).
 		Update("is_used", true).
 		Error
 
 	if err != nil {
-		r.Logger.Error("Error mark OTP used", zap.Error(err))
+		r.Logger.Error("Error invalidate OTPs", zap.Error(err))
 	}
 
 	return err
 }
+
+// RecordFailedAttempt counts a wrong code against the OTP and marks it used
+// once maxAttempts is reached. It returns the attempts made so far.
+func (r *otpRepository) RecordFailedAttempt(ctx context.Context, otpID uint, maxAttempts int) (int, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Model(&entity.OTP{}).
+		Where("id = ?", otpID).
+		Updates(map[string]any{
+			"attempts": gorm.Expr("attempts + 1"),
+			"is_used":  gorm.Expr("is_used OR attempts + 1 >= ?", maxAttempts),
+		}).Error
+	if err != nil {
+		r.Logger.Error("Error record OTP attempt", zap.Error(err))
+		return 0, err
+	}
+
+	var otp entity.OTP
+	if err := db.Select("attempts").First(&otp, otpID).Error; err != nil {
+		r.Logger.Error("Error get OTP attempts", zap.Error(err))
+		return 0, err
+	}
+
+	return otp.Attempts, nil
+}
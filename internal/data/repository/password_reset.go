@@ -13,7 +13,8 @@ import (
 type PasswordResetRepository interface {
 	Create(ctx context.Context, reset *entity.PasswordReset) error
 	MarkUsed(ctx context.Context, tokenID uint) error
-	GetValidByUser(ctx context.Context, userID uint) (*entity.PasswordReset, error)
+	FindValidByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordReset, error)
+	InvalidateByUser(ctx context.Context, userID uint) error
 }
 
 type passwordResetRepository struct {
@@ -37,35 +38,53 @@ func (r *passwordResetRepository) Create(ctx context.Context, reset *entity.Pass
 	return nil
 }
 
-func (r *passwordResetRepository) GetValidByUser(ctx context.Context, userID uint) (*entity.PasswordReset, error) {
+func (r *passwordResetRepository) FindValidByTokenHash(ctx context.Context, tokenHash string) (*entity.PasswordReset, error) {
 	var reset entity.PasswordReset
 
-	err := r.db.
+	err := infra.GetDB(ctx, r.db).
 		Where(
-			"user_id = ? AND expired_at > ? AND used_at IS NULL",
-			userID,
+			"reset_token_hash = ? AND expired_at > ? AND used_at IS NULL",
+			tokenHash,
 			time.Now(),
 		).
-		Order("created_at DESC").
 		First(&reset).Error
 
 	if err != nil {
-		r.Logger.Error("Error get valid reset password", zap.Error(err))
 		return nil, err
 	}
 
 	return &reset, nil
 }
 
+// MarkUsed consumes the reset. It returns gorm.ErrRecordNotFound when the
+// reset was already used, so a token cannot be redeemed twice.
 func (r *passwordResetRepository) MarkUsed(ctx context.Context, tokenID uint) error {
-	err := r.db.
+	res := infra.GetDB(ctx, r.db).
 		Model(&entity.PasswordReset{}).
-		Where("id = ?", tokenID).
+		Where("id = ? AND used_at IS NULL", tokenID).
+		Update("used_at", time.Now())
+
+	if res.Error != nil {
+		r.Logger.Error("Error mark reset password used", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// InvalidateByUser voids every outstanding reset of the user.
+func (r *passwordResetRepository) InvalidateByUser(ctx context.Context, userID uint) error {
+	err := infra.GetDB(ctx, r.db).
+		Model(&entity.PasswordReset{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
 		Update("used_at", time.Now()).
 		Error
 
 	if err != nil {
-		r.Logger.Error("Error mark reset password used", zap.Error(err))
+		r.Logger.Error("Error invalidate reset passwords", zap.Error(err))
 	}
 
 	return err
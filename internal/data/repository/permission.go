@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PermissionRepository interface {
+	List(ctx context.Context) ([]entity.Permission, error)
+	FindByNames(ctx context.Context, names []string) ([]entity.Permission, error)
+	ListUserOverrides(ctx context.Context, userID uint) ([]entity.UserPermission, error)
+	ListAllOverrides(ctx context.Context) ([]entity.UserPermission, error)
+	ReplaceUserOverrides(ctx context.Context, userID uint, overrides []entity.UserPermission) error
+}
+
+type permissionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewPermissionRepo(db *gorm.DB, log *zap.Logger) PermissionRepository {
+	return &permissionRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "permission")),
+	}
+}
+
+func (r *permissionRepository) List(ctx context.Context) ([]entity.Permission, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var permissions []entity.Permission
+	if err := db.Order("name ASC").Find(&permissions).Error; err != nil {
+		r.logger.Error("Failed to list permissions", zap.Error(err))
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) FindByNames(ctx context.Context, names []string) ([]entity.Permission, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var permissions []entity.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	if err := db.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		r.logger.Error("Failed to find permissions", zap.Error(err))
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *permissionRepository) ListUserOverrides(ctx context.Context, userID uint) ([]entity.UserPermission, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var overrides []entity.UserPermission
+	if err := db.Preload("Permission").Where("user_id = ?", userID).Find(&overrides).Error; err != nil {
+		r.logger.Error("Failed to list user permissions", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+func (r *permissionRepository) ListAllOverrides(ctx context.Context) ([]entity.UserPermission, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var overrides []entity.UserPermission
+	if err := db.Preload("Permission").Find(&overrides).Error; err != nil {
+		r.logger.Error("Failed to list user permissions", zap.Error(err))
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// ReplaceUserOverrides sets the user's overrides to exactly the given ones.
+func (r *permissionRepository) ReplaceUserOverrides(ctx context.Context, userID uint, overrides []entity.UserPermission) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Where("user_id = ?", userID).Delete(&entity.UserPermission{}).Error; err != nil {
+		r.logger.Error("Failed to clear user permissions", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	if err := db.Omit(clause.Associations).Create(&overrides).Error; err != nil {
+		r.logger.Error("Failed to create user permissions", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -1,26 +1,33 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
 	"project-POS-APP-golang-integer/pkg/utils"
 	"strings"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
-	Create(product *entity.Product) error
+	Create(ctx context.Context, product *entity.Product) error
 	FindByID(id uint) (*entity.Product, error)
-	Update(product *entity.Product) error
-	SoftDelete(id uint) error
+	Update(ctx context.Context, product *entity.Product) error
+	SoftDelete(ctx context.Context, id uint) error
 	CheckHasOrderItems(id uint) (bool, error)
 	FindByNameAndCategory(name string, categoryID uint) (*entity.Product, error)
 	FindAllWithFilter(req request.GetProductsRequest) ([]entity.Product, int64, error) // 🔥 TAMBAH
 	GetSoldCount(productID uint) (int64, error)                                        // 🔥 TAMBAH
+	AdjustStock(ctx context.Context, id uint, delta int) (*entity.Product, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*entity.Product, error)
+	UpdateCostPrice(ctx context.Context, id uint, cost float64) error
+	UpdateImageURL(ctx context.Context, id uint, url string) error
 }
 
 type productRepository struct {
@@ -35,9 +42,9 @@ func NewProductRepository(db *gorm.DB, log *zap.Logger) ProductRepository {
 	}
 }
 
-func (r *productRepository) Create(product *entity.Product) error {
+func (r *productRepository) Create(ctx context.Context, product *entity.Product) error {
 	r.log.Debug("Creating product", zap.String("name", product.Name))
-	return r.db.Create(product).Error
+	return infra.GetDB(ctx, r.db).Create(product).Error
 }
 
 func (r *productRepository) FindByID(id uint) (*entity.Product, error) {
@@ -58,15 +65,15 @@ func (r *productRepository) FindByID(id uint) (*entity.Product, error) {
 	return &product, nil
 }
 
-func (r *productRepository) Update(product *entity.Product) error {
+func (r *productRepository) Update(ctx context.Context, product *entity.Product) error {
 	r.log.Debug("Updating product", zap.Uint("id", product.ID), zap.String("name", product.Name))
-	return r.db.Save(product).Error
+	return infra.GetDB(ctx, r.db).Save(product).Error
 }
 
-func (r *productRepository) SoftDelete(id uint) error {
+func (r *productRepository) SoftDelete(ctx context.Context, id uint) error {
 	r.log.Info("Soft deleting product", zap.Uint("id", id))
 
-	result := r.db.Model(&entity.Product{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()"))
+	result := infra.GetDB(ctx, r.db).Model(&entity.Product{}).Where("id = ?", id).Update("deleted_at", gorm.Expr("NOW()"))
 	if result.Error != nil {
 		r.log.Error("Failed to soft delete product", zap.Uint("id", id), zap.Error(result.Error))
 		return result.Error
@@ -171,13 +178,17 @@ func (r *productRepository) FindAllWithFilter(req request.GetProductsRequest) ([
 	return products, total, nil
 }
 
+// soldQuantityExpr is the sold-count aggregate over order_items, shared by
+// GetSoldCount and the sales reports.
+const soldQuantityExpr = "COALESCE(SUM(order_items.quantity), 0)"
+
 // GetSoldCount - Get total sold quantity for a product
 func (r *productRepository) GetSoldCount(productID uint) (int64, error) {
 	r.log.Debug("Getting sold count for product", zap.Uint("product_id", productID))
 
 	var totalSold int64
 	err := r.db.Model(&entity.OrderItem{}).
-		Select("COALESCE(SUM(quantity), 0)").
+		Select(soldQuantityExpr).
 		Where("product_id = ?", productID).
 		Scan(&totalSold).Error
 
@@ -256,12 +267,57 @@ func (r *productRepository) applyProductSorting(query *gorm.DB, req request.GetP
 	return query
 }
 
-// OPTIONAL: Method untuk mendapatkan products dengan sold count dalam satu query
-func (r *productRepository) FindAllWithSoldCount(req request.GetProductsRequest) ([]struct {
-	entity.Product
-	SoldCount int64 `json:"sold_count"`
-}, int64, error) {
-	// Advanced query dengan subquery untuk sold count
-	// Implement jika butuh performance lebih baik
-	return nil, 0, nil
+// AdjustStock locks the product row inside the current transaction, applies
+// delta to its stock and returns the product with the new stock level.
+func (r *productRepository) AdjustStock(ctx context.Context, id uint, delta int) (*entity.Product, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var product entity.Product
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+	if err != nil {
+		r.log.Error("Failed to lock product", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	product.Stock += delta
+	if err := db.Model(&product).Update("stock", product.Stock).Error; err != nil {
+		r.log.Error("Failed to adjust product stock",
+			zap.Uint("id", id),
+			zap.Int("delta", delta),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+func (r *productRepository) UpdateCostPrice(ctx context.Context, id uint, cost float64) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.log.Debug("Updating product cost price", zap.Uint("id", id), zap.Float64("cost", cost))
+	return db.Model(&entity.Product{}).Where("id = ?", id).Update("cost_price", cost).Error
+}
+
+// UpdateImageURL sets only the image, so a stock change made meanwhile is
+// not written over.
+func (r *productRepository) UpdateImageURL(ctx context.Context, id uint, url string) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.log.Debug("Updating product image", zap.Uint("id", id))
+	return db.Model(&entity.Product{}).Where("id = ?", id).Update("image_url", url).Error
+}
+
+// FindByIDForUpdate loads the product and holds its row lock until the
+// surrounding transaction ends.
+func (r *productRepository) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Product, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var product entity.Product
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+	if err != nil {
+		r.log.Error("Failed to lock product", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &product, nil
 }
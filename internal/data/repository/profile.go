@@ -13,6 +13,8 @@ type ProfileRepository interface {
 	GetProfileByID(ctx context.Context, id uint) (*entity.User, error)
 	CreateProfile(ctx context.Context, profile *entity.Profile) (*entity.Profile, error)
 	UpdateProfile(ctx context.Context, data *entity.Profile) error
+	UpdateSalary(ctx context.Context, userID uint, salary float64) error
+	GetByProfileID(ctx context.Context, id uint) (*entity.Profile, error)
 }
 
 type profileRepository struct {
@@ -67,3 +69,34 @@ func (r *profileRepository) UpdateProfile(ctx context.Context, p *entity.Profile
 
 	return nil
 }
+
+// UpdateSalary sets the salary on its own, so it can also be set to zero.
+func (r *profileRepository) UpdateSalary(ctx context.Context, userID uint, salary float64) error {
+	db := infra.GetDB(ctx, r.db)
+
+	result := db.Model(&entity.Profile{}).
+		Where("user_id = ?", userID).
+		Update("salary", salary)
+
+	if result.Error != nil {
+		r.Logger.Error("Error query update salary", zap.Error(result.Error))
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *profileRepository) GetByProfileID(ctx context.Context, id uint) (*entity.Profile, error) {
+	db := infra.GetDB(ctx, r.db)
+	var profile entity.Profile
+	err := db.Preload("User").First(&profile, id).Error
+	if err != nil {
+		r.Logger.Error("Error query get profile by profile id", zap.Error(err))
+		return nil, err
+	}
+	return &profile, nil
+}
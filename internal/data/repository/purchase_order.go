@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error)
+	FindByID(ctx context.Context, id uint) (*entity.PurchaseOrder, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*entity.PurchaseOrder, error)
+	FindAll(ctx context.Context, params request.GetPurchaseOrdersRequest) ([]entity.PurchaseOrder, int64, error)
+	Update(ctx context.Context, po *entity.PurchaseOrder) error
+	ReplaceItems(ctx context.Context, poID uint, items []entity.PurchaseOrderItem) error
+	UpdateItem(ctx context.Context, item *entity.PurchaseOrderItem) error
+	CountBySupplier(ctx context.Context, supplierID uint) (int64, error)
+}
+
+type purchaseOrderRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewPurchaseOrderRepo(db *gorm.DB, log *zap.Logger) PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "purchase_order")),
+	}
+}
+
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *entity.PurchaseOrder) (*entity.PurchaseOrder, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating purchase order",
+		zap.String("po_number", po.PONumber),
+		zap.Uint("supplier_id", po.SupplierID),
+		zap.Int("items", len(po.Items)))
+
+	// Items are inserted together with the purchase order
+	if err := db.Omit("Supplier", "Creator").Create(po).Error; err != nil {
+		r.logger.Error("Failed to create purchase order",
+			zap.String("po_number", po.PONumber),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (r *purchaseOrderRepository) FindByID(ctx context.Context, id uint) (*entity.PurchaseOrder, error) {
+	return r.findByID(infra.GetDB(ctx, r.db), id)
+}
+
+// FindByIDForUpdate locks the purchase order row so concurrent receipts are
+// booked one after the other.
+func (r *purchaseOrderRepository) FindByIDForUpdate(ctx context.Context, id uint) (*entity.PurchaseOrder, error) {
+	db := infra.GetDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"})
+	return r.findByID(db, id)
+}
+
+func (r *purchaseOrderRepository) findByID(db *gorm.DB, id uint) (*entity.PurchaseOrder, error) {
+	var po entity.PurchaseOrder
+	err := db.
+		Preload("Supplier").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Items.Product").
+		First(&po, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Purchase order not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find purchase order",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &po, nil
+}
+
+func (r *purchaseOrderRepository) FindAll(ctx context.Context, params request.GetPurchaseOrdersRequest) ([]entity.PurchaseOrder, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var pos []entity.PurchaseOrder
+	var total int64
+
+	query := db.Model(&entity.PurchaseOrder{})
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.SupplierID > 0 {
+		query = query.Where("supplier_id = ?", params.SupplierID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count purchase orders", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Supplier").
+		Preload("Items").
+		Order("created_at DESC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&pos).Error
+	if err != nil {
+		r.logger.Error("Failed to find purchase orders", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return pos, total, nil
+}
+
+func (r *purchaseOrderRepository) Update(ctx context.Context, po *entity.PurchaseOrder) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating purchase order",
+		zap.Uint("id", po.ID),
+		zap.String("status", string(po.Status)))
+
+	if err := db.Omit(clause.Associations).Save(po).Error; err != nil {
+		r.logger.Error("Failed to update purchase order",
+			zap.Uint("id", po.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) ReplaceItems(ctx context.Context, poID uint, items []entity.PurchaseOrderItem) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Where("purchase_order_id = ?", poID).Delete(&entity.PurchaseOrderItem{}).Error; err != nil {
+		r.logger.Error("Failed to clear purchase order items",
+			zap.Uint("purchase_order_id", poID),
+			zap.Error(err))
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	for i := range items {
+		items[i].PurchaseOrderID = poID
+	}
+
+	if err := db.Omit(clause.Associations).Create(&items).Error; err != nil {
+		r.logger.Error("Failed to create purchase order items",
+			zap.Uint("purchase_order_id", poID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) UpdateItem(ctx context.Context, item *entity.PurchaseOrderItem) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(item).Error; err != nil {
+		r.logger.Error("Failed to update purchase order item",
+			zap.Uint("id", item.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) CountBySupplier(ctx context.Context, supplierID uint) (int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var count int64
+	err := db.Model(&entity.PurchaseOrder{}).Where("supplier_id = ?", supplierID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type RecipeRepository interface {
+	FindByProductID(ctx context.Context, productID uint) ([]entity.RecipeItem, error)
+	FindByProductIDs(ctx context.Context, productIDs []uint) ([]entity.RecipeItem, error)
+	FindByModifierOptionID(ctx context.Context, optionID uint) ([]entity.RecipeItem, error)
+	FindByModifierOptionIDs(ctx context.Context, optionIDs []uint) ([]entity.RecipeItem, error)
+	ReplaceForProduct(ctx context.Context, productID uint, items []entity.RecipeItem) error
+	ReplaceForModifierOption(ctx context.Context, optionID uint, items []entity.RecipeItem) error
+}
+
+type recipeRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewRecipeRepo(db *gorm.DB, log *zap.Logger) RecipeRepository {
+	return &recipeRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "recipe")),
+	}
+}
+
+func (r *recipeRepository) FindByProductID(ctx context.Context, productID uint) ([]entity.RecipeItem, error) {
+	return r.FindByProductIDs(ctx, []uint{productID})
+}
+
+func (r *recipeRepository) FindByProductIDs(ctx context.Context, productIDs []uint) ([]entity.RecipeItem, error) {
+	return r.find(ctx, "product_id IN ?", productIDs)
+}
+
+func (r *recipeRepository) FindByModifierOptionID(ctx context.Context, optionID uint) ([]entity.RecipeItem, error) {
+	return r.FindByModifierOptionIDs(ctx, []uint{optionID})
+}
+
+func (r *recipeRepository) FindByModifierOptionIDs(ctx context.Context, optionIDs []uint) ([]entity.RecipeItem, error) {
+	return r.find(ctx, "modifier_option_id IN ?", optionIDs)
+}
+
+func (r *recipeRepository) find(ctx context.Context, where string, ids []uint) ([]entity.RecipeItem, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var items []entity.RecipeItem
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	err := db.Preload("Ingredient").Where(where, ids).Order("id ASC").Find(&items).Error
+	if err != nil {
+		r.logger.Error("Failed to find recipe items", zap.Error(err))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *recipeRepository) ReplaceForProduct(ctx context.Context, productID uint, items []entity.RecipeItem) error {
+	for i := range items {
+		items[i].ProductID = &productID
+		items[i].ModifierOptionID = nil
+	}
+	return r.replace(ctx, "product_id = ?", productID, items)
+}
+
+func (r *recipeRepository) ReplaceForModifierOption(ctx context.Context, optionID uint, items []entity.RecipeItem) error {
+	for i := range items {
+		items[i].ProductID = nil
+		items[i].ModifierOptionID = &optionID
+	}
+	return r.replace(ctx, "modifier_option_id = ?", optionID, items)
+}
+
+func (r *recipeRepository) replace(ctx context.Context, where string, id uint, items []entity.RecipeItem) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Replacing recipe", zap.String("owner", where), zap.Uint("id", id), zap.Int("items", len(items)))
+
+	if err := db.Where(where, id).Delete(&entity.RecipeItem{}).Error; err != nil {
+		r.logger.Error("Failed to clear recipe", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	if err := db.Omit("Ingredient").Create(&items).Error; err != nil {
+		r.logger.Error("Failed to create recipe items", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
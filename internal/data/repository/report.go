@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ReportRange is the window the sales reports aggregate over. From and To
+// are instants (To exclusive); Timezone is the IANA name used to bucket
+// orders into local days and hours.
+type ReportRange struct {
+	From     time.Time
+	To       time.Time
+	Timezone string
+}
+
+type SalesSummaryRow struct {
+	OrderCount int64
+	Subtotal   float64
+	TaxAmount  float64
+	Revenue    float64
+}
+
+type RevenueBucketRow struct {
+	Bucket     time.Time
+	OrderCount int64
+	Revenue    float64
+}
+
+type CategorySalesRow struct {
+	CategoryID   uint
+	CategoryName string
+	Quantity     int64
+	Revenue      float64
+}
+
+type ProductSalesRow struct {
+	ProductID    uint
+	ProductName  string
+	CategoryName string
+	Quantity     int64
+	Revenue      float64
+}
+
+type PaymentMethodSalesRow struct {
+	PaymentMethodID   uint
+	PaymentMethodName string
+	TransactionCount  int64
+	Payments          float64
+	Refunds           float64
+}
+
+type PeakHourRow struct {
+	Hour       int
+	OrderCount int64
+	Revenue    float64
+}
+
+type ReportRepository interface {
+	GetSalesSummary(ctx context.Context, rng ReportRange) (*SalesSummaryRow, error)
+	GetRevenueSeries(ctx context.Context, rng ReportRange, period string) ([]RevenueBucketRow, error)
+	GetSalesByCategory(ctx context.Context, rng ReportRange) ([]CategorySalesRow, error)
+	GetSalesByProduct(ctx context.Context, rng ReportRange, limit int) ([]ProductSalesRow, error)
+	GetSalesByPaymentMethod(ctx context.Context, rng ReportRange) ([]PaymentMethodSalesRow, error)
+	GetPeakHours(ctx context.Context, rng ReportRange) ([]PeakHourRow, error)
+}
+
+type reportRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewReportRepo(db *gorm.DB, log *zap.Logger) ReportRepository {
+	return &reportRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "report")),
+	}
+}
+
+// completedOrders scopes a query on "orders o" to completed orders in range.
+func (r *reportRepository) completedOrders(db *gorm.DB, rng ReportRange) *gorm.DB {
+	return db.
+		Where("o.deleted_at IS NULL").
+		Where("o.status = ?", entity.OrderStatusCompleted).
+		Where("o.created_at >= ? AND o.created_at < ?", rng.From, rng.To)
+}
+
+func (r *reportRepository) GetSalesSummary(ctx context.Context, rng ReportRange) (*SalesSummaryRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var row SalesSummaryRow
+	err := r.completedOrders(db.Table("orders o"), rng).
+		Select(`COUNT(*) AS order_count,
+			COALESCE(SUM(o.subtotal), 0) AS subtotal,
+			COALESCE(SUM(o.tax_amount), 0) AS tax_amount,
+			COALESCE(SUM(o.total), 0) AS revenue`).
+		Scan(&row).Error
+	if err != nil {
+		r.logger.Error("Failed to get sales summary", zap.Error(err))
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// GetRevenueSeries buckets revenue by day, week (ISO, Monday start) or month
+// in the report timezone. Buckets are local wall-clock midnights.
+func (r *reportRepository) GetRevenueSeries(ctx context.Context, rng ReportRange, period string) ([]RevenueBucketRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []RevenueBucketRow
+	err := r.completedOrders(db.Table("orders o"), rng).
+		Select(`date_trunc(?, o.created_at AT TIME ZONE ?) AS bucket,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(o.total), 0) AS revenue`, period, rng.Timezone).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get revenue series", zap.String("period", period), zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *reportRepository) GetSalesByCategory(ctx context.Context, rng ReportRange) ([]CategorySalesRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []CategorySalesRow
+	err := r.completedOrders(db.Table("order_items"), rng).
+		Select(`c.id AS category_id,
+			c.name AS category_name,
+			`+soldQuantityExpr+` AS quantity,
+			COALESCE(SUM(order_items.total_price), 0) AS revenue`).
+		Joins("JOIN orders o ON o.id = order_items.order_id").
+		Joins("JOIN products p ON p.id = order_items.product_id").
+		Joins("JOIN categories c ON c.id = p.category_id").
+		Group("c.id, c.name").
+		Order("revenue DESC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get sales by category", zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *reportRepository) GetSalesByProduct(ctx context.Context, rng ReportRange, limit int) ([]ProductSalesRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []ProductSalesRow
+	err := r.completedOrders(db.Table("order_items"), rng).
+		Select(`p.id AS product_id,
+			p.name AS product_name,
+			c.name AS category_name,
+			`+soldQuantityExpr+` AS quantity,
+			COALESCE(SUM(order_items.total_price), 0) AS revenue`).
+		Joins("JOIN orders o ON o.id = order_items.order_id").
+		Joins("JOIN products p ON p.id = order_items.product_id").
+		Joins("LEFT JOIN categories c ON c.id = p.category_id").
+		Group("p.id, p.name, c.name").
+		Order("quantity DESC, revenue DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get sales by product", zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetSalesByPaymentMethod totals completed transactions recorded in range.
+func (r *reportRepository) GetSalesByPaymentMethod(ctx context.Context, rng ReportRange) ([]PaymentMethodSalesRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []PaymentMethodSalesRow
+	err := db.Table("transactions t").
+		Select(`pm.id AS payment_method_id,
+			pm.name AS payment_method_name,
+			COUNT(*) AS transaction_count,
+			COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN 0 ELSE t.amount END), 0) AS payments,
+			COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN t.amount ELSE 0 END), 0) AS refunds`,
+			entity.TransactionTypeRefund, entity.TransactionTypeRefund).
+		Joins("JOIN payment_methods pm ON pm.id = t.payment_method_id").
+		Where("t.status = ?", entity.TransactionStatusCompleted).
+		Where("t.created_at >= ? AND t.created_at < ?", rng.From, rng.To).
+		Group("pm.id, pm.name").
+		Order("payments DESC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get sales by payment method", zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetPeakHours groups orders by local hour of day. Hours without orders are
+// left out.
+func (r *reportRepository) GetPeakHours(ctx context.Context, rng ReportRange) ([]PeakHourRow, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var rows []PeakHourRow
+	err := r.completedOrders(db.Table("orders o"), rng).
+		Select(`CAST(EXTRACT(HOUR FROM o.created_at AT TIME ZONE ?) AS INTEGER) AS hour,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(o.total), 0) AS revenue`, rng.Timezone).
+		Group("hour").
+		Order("hour ASC").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to get peak hours", zap.Error(err))
+		return nil, err
+	}
+
+	return rows, nil
+}
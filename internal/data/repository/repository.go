@@ -5,6 +5,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// exportBatchSize is how many rows Stream methods load per query.
+const exportBatchSize = 500
+
 type Repository struct {
 	UserRepo        UserRepository
 	ProfileRepo ProfileRepository
@@ -18,6 +21,27 @@ type Repository struct {
 	InventoryLogRepo InventoryLogRepository
 	Product          ProductRepository
 	Category         CategoryRepository
+	ModifierRepo     ModifierRepository
+	OrderRepo        OrderRepository
+	IngredientRepo   IngredientRepository
+	RecipeRepo       RecipeRepository
+	SupplierRepo     SupplierRepository
+	PurchaseOrderRepo PurchaseOrderRepository
+	StockTakeRepo    StockTakeRepository
+	ShiftRepo        ShiftRepository
+	AttendanceRepo   AttendanceRepository
+	CashDrawerRepo   CashDrawerRepository
+	ReportRepo       ReportRepository
+	TransactionRepo  TransactionRepository
+	TokenRevocationRepo TokenRevocationRepository
+	LoginThrottleRepo LoginThrottleRepository
+	RoleRepo RoleRepository
+	PermissionRepo PermissionRepository
+	AuditLogRepo AuditLogRepository
+	TwoFactorRepo TwoFactorRepository
+	LoginChallengeRepo LoginChallengeRepository
+	InvitationRepo InvitationRepository
+	SalaryChangeRepo SalaryChangeRepository
 }
 
 func NewRepository(db *gorm.DB, log *zap.Logger) *Repository {
@@ -34,5 +58,26 @@ func NewRepository(db *gorm.DB, log *zap.Logger) *Repository {
 		InventoryLogRepo: NewInventoryLogRepo(db, log),
 		Product:          NewProductRepository(db, log),
 		Category:         NewCategoryRepository(db, log),
+		ModifierRepo:     NewModifierRepo(db, log),
+		OrderRepo:        NewOrderRepo(db, log),
+		IngredientRepo:   NewIngredientRepo(db, log),
+		RecipeRepo:       NewRecipeRepo(db, log),
+		SupplierRepo:     NewSupplierRepo(db, log),
+		PurchaseOrderRepo: NewPurchaseOrderRepo(db, log),
+		StockTakeRepo:    NewStockTakeRepo(db, log),
+		ShiftRepo:        NewShiftRepo(db, log),
+		AttendanceRepo:   NewAttendanceRepo(db, log),
+		CashDrawerRepo:   NewCashDrawerRepo(db, log),
+		ReportRepo:       NewReportRepo(db, log),
+		TransactionRepo:  NewTransactionRepo(db, log),
+		TokenRevocationRepo: NewTokenRevocationRepo(db, log),
+		LoginThrottleRepo: NewLoginThrottleRepo(db, log),
+		RoleRepo: NewRoleRepo(db, log),
+		PermissionRepo: NewPermissionRepo(db, log),
+		AuditLogRepo: NewAuditLogRepo(db, log),
+		TwoFactorRepo: NewTwoFactorRepo(db, log),
+		LoginChallengeRepo: NewLoginChallengeRepo(db, log),
+		InvitationRepo: NewInvitationRepo(db, log),
+		SalaryChangeRepo: NewSalaryChangeRepo(db, log),
 	}
 }
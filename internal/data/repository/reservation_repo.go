@@ -15,6 +15,7 @@ type ReservationRepository interface {
 	Create(ctx context.Context, reservation *entity.Reservation) (*entity.Reservation, error)
 	FindByID(ctx context.Context, id uint) (*entity.Reservation, error)
 	FindAll(ctx context.Context, params request.GetReservationsRequest) ([]entity.Reservation, int64, error)
+	Stream(ctx context.Context, params request.GetReservationsRequest, fn func([]entity.Reservation) error) error
 	FindByCustomerID(ctx context.Context, customerID uint) ([]entity.Reservation, error)
 	FindByDate(ctx context.Context, date time.Time) ([]entity.Reservation, error)
 	IsTableAvailable(ctx context.Context, tableID uint, date time.Time, reservationTime time.Time) (bool, error)
@@ -97,30 +98,10 @@ func (r *reservationRepository) FindAll(ctx context.Context, params request.GetR
 	var reservations []entity.Reservation
 	var total int64
 
-	query := db.Model(&entity.Reservation{}).
+	query := r.applyReservationFilters(db.Model(&entity.Reservation{}), params).
 		Preload("Customer").
 		Preload("Table")
 
-	// Apply filters
-	if params.Date != "" {
-		date, err := time.Parse("2006-01-02", params.Date)
-		if err == nil {
-			query = query.Where("DATE(reservation_date) = ?", date.Format("2006-01-02"))
-		}
-	}
-
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-	}
-
-	if params.CustomerID > 0 {
-		query = query.Where("customer_id = ?", params.CustomerID)
-	}
-
-	if params.TableID > 0 {
-		query = query.Where("table_id = ?", params.TableID)
-	}
-
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
 		r.logger.Error("Failed to count reservations", zap.Error(err))
@@ -292,3 +273,52 @@ func (r *reservationRepository) Delete(ctx context.Context, id uint) error {
 	r.logger.Info("Reservation deleted", zap.Uint("id", id))
 	return nil
 }
+
+// Stream walks every reservation matching params in batches of exportBatchSize.
+func (r *reservationRepository) Stream(ctx context.Context, params request.GetReservationsRequest, fn func([]entity.Reservation) error) error {
+	db := infra.GetDB(ctx, r.db)
+
+	var batch []entity.Reservation
+	err := r.applyReservationFilters(db.Model(&entity.Reservation{}), params).
+		Preload("Customer").
+		Preload("Table").
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to stream reservations", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *reservationRepository) applyReservationFilters(query *gorm.DB, params request.GetReservationsRequest) *gorm.DB {
+	if params.Date != "" {
+		date, err := time.Parse("2006-01-02", params.Date)
+		if err == nil {
+			query = query.Where("DATE(reservation_date) = ?", date.Format("2006-01-02"))
+		}
+	}
+
+	if params.StartDate != "" {
+		query = query.Where("DATE(reservation_date) >= ?", params.StartDate)
+	}
+	if params.EndDate != "" {
+		query = query.Where("DATE(reservation_date) <= ?", params.EndDate)
+	}
+
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if params.CustomerID > 0 {
+		query = query.Where("customer_id = ?", params.CustomerID)
+	}
+
+	if params.TableID > 0 {
+		query = query.Where("table_id = ?", params.TableID)
+	}
+
+	return query
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRepository interface {
+	List(ctx context.Context) ([]entity.Role, error)
+	FindByID(ctx context.Context, id uint) (*entity.Role, error)
+	FindByName(ctx context.Context, name string) (*entity.Role, error)
+	Create(ctx context.Context, role *entity.Role) error
+	Update(ctx context.Context, role *entity.Role) error
+	Delete(ctx context.Context, id uint) error
+	ReplacePermissions(ctx context.Context, role *entity.Role, permissions []entity.Permission) error
+	CountUsers(ctx context.Context, name string) (int64, error)
+}
+
+type roleRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewRoleRepo(db *gorm.DB, log *zap.Logger) RoleRepository {
+	return &roleRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "role")),
+	}
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]entity.Role, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var roles []entity.Role
+	if err := db.Preload("Permissions").Order("id ASC").Find(&roles).Error; err != nil {
+		r.logger.Error("Failed to list roles", zap.Error(err))
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id uint) (*entity.Role, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var role entity.Role
+	if err := db.Preload("Permissions").First(&role, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var role entity.Role
+	if err := db.Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Create(role).Error; err != nil {
+		r.logger.Error("Failed to create role", zap.String("name", role.Name), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(role).Error; err != nil {
+		r.logger.Error("Failed to update role", zap.Uint("id", role.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	role := entity.Role{ID: id}
+	if err := db.Model(&role).Association("Permissions").Clear(); err != nil {
+		r.logger.Error("Failed to clear role permissions", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	if err := db.Delete(&role).Error; err != nil {
+		r.logger.Error("Failed to delete role", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) ReplacePermissions(ctx context.Context, role *entity.Role, permissions []entity.Permission) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Model(role).Association("Permissions").Replace(permissions); err != nil {
+		r.logger.Error("Failed to replace role permissions", zap.Uint("id", role.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CountUsers counts the users, deleted ones excluded, holding the role.
+func (r *roleRepository) CountUsers(ctx context.Context, name string) (int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var count int64
+	if err := db.Model(&entity.User{}).Where("role = ?", name).Count(&count).Error; err != nil {
+		r.logger.Error("Failed to count role users", zap.String("name", name), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SalaryChangeRepository interface {
+	Create(ctx context.Context, change *entity.SalaryChange) error
+	FindByUser(ctx context.Context, userID uint) ([]entity.SalaryChange, error)
+	FindEffective(ctx context.Context, userID uint, at time.Time) (*entity.SalaryChange, error)
+}
+
+type salaryChangeRepository struct {
+	db     *gorm.DB
+	Logger *zap.Logger
+}
+
+func NewSalaryChangeRepo(db *gorm.DB, log *zap.Logger) SalaryChangeRepository {
+	return &salaryChangeRepository{
+		db:     db,
+		Logger: log,
+	}
+}
+
+func (r *salaryChangeRepository) Create(ctx context.Context, change *entity.SalaryChange) error {
+	if err := infra.GetDB(ctx, r.db).Create(change).Error; err != nil {
+		r.Logger.Error("Error create salary change", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindByUser returns the user's salary history, latest effective first.
+func (r *salaryChangeRepository) FindByUser(ctx context.Context, userID uint) ([]entity.SalaryChange, error) {
+	var changes []entity.SalaryChange
+	err := infra.GetDB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("effective_date DESC, id DESC").
+		Find(&changes).Error
+	if err != nil {
+		r.Logger.Error("Error query salary changes", zap.Error(err))
+		return nil, err
+	}
+	return changes, nil
+}
+
+// FindEffective returns the change in effect at the given time: the one
+// with the latest effective date not after it, the last recorded on ties.
+func (r *salaryChangeRepository) FindEffective(ctx context.Context, userID uint, at time.Time) (*entity.SalaryChange, error) {
+	var change entity.SalaryChange
+	err := infra.GetDB(ctx, r.db).
+		Where("user_id = ? AND effective_date <= ?", userID, at).
+		Order("effective_date DESC, id DESC").
+		First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
@@ -4,18 +4,24 @@ import (
 	"context"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/infra"
-	"project-POS-APP-golang-integer/pkg/utils"
 	"time"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SessionRepository interface {
-	Create(ctx context.Context, userID uint) (uuid.UUID, error)
+	Create(ctx context.Context, session *entity.Session) error
+	Update(ctx context.Context, session *entity.Session) error
 	Revoke(ctx context.Context, token string) error
-	ValidateToken(ctx context.Context, token string) (*uint, error)
+	FindActiveByToken(ctx context.Context, token string) (*entity.Session, error)
+	FindByRefreshHash(ctx context.Context, hash string) (*entity.Session, error)
+	FindByID(ctx context.Context, id uint) (*entity.Session, error)
+	FindActiveByUser(ctx context.Context, userID uint) ([]entity.Session, error)
+	Touch(ctx context.Context, id uint, expiresAt time.Time) error
+	RevokeByID(ctx context.Context, id uint) error
+	RevokeAllByUser(ctx context.Context, userID uint, exceptID uint) (int64, error)
 }
 
 type sessionRepository struct {
@@ -30,52 +36,116 @@ func NewSessionRepo(db *gorm.DB, log *zap.Logger) SessionRepository {
 	}
 }
 
-func (r *sessionRepository) Create(ctx context.Context, userID uint) (uuid.UUID, error) {
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
 	db := infra.GetDB(ctx, r.db)
-	// Create session after login and register
-	token, err := utils.GenerateRandomToken(16)
+	// Create session after login
+	err := db.Omit(clause.Associations).Create(session).Error
 	if err != nil {
-		r.Logger.Error("Error create token: ", zap.Error(err))
-		return uuid.Nil, err
+		r.Logger.Error("Error query create session: ", zap.Error(err))
+		return err
 	}
 
-	session := entity.Session{
-		UserID:    uint(userID),
-		Token:     token,
-		ExpiresAt: time.Now().AddDate(0, 0, 5),
-		CreatedAt: time.Now(),
-	}
+	return nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *entity.Session) error {
+	db := infra.GetDB(ctx, r.db)
 
-	err = db.Create(&session).Error
+	err := db.Omit(clause.Associations).Save(session).Error
 	if err != nil {
-		r.Logger.Error("Error query create session: ", zap.Error(err))
-		return uuid.Nil, err
+		r.Logger.Error("Error query update session: ", zap.Error(err))
+		return err
 	}
 
-	return token, nil
+	return nil
 }
 
-func (r *sessionRepository) ValidateToken(ctx context.Context, token string) (*uint, error) {
+// FindActiveByToken returns the session an access token belongs to while it
+// is neither expired nor revoked.
+func (r *sessionRepository) FindActiveByToken(ctx context.Context, token string) (*entity.Session, error) {
 	db := infra.GetDB(ctx, r.db)
-	// Validate token to authorize user
-	type result struct {
-		UserID uint
-	}
-
-	var res result
 
+	var session entity.Session
 	err := db.
-		Model(&entity.Session{}).
-		Select("user_id").
 		Where("token = ? AND expires_at > NOW() AND revoked_at IS NULL", token).
-		First(&res).
+		First(&session).
 		Error
 	if err != nil {
 		r.Logger.Error("Error query validate token: ", zap.Error(err))
 		return nil, err
 	}
 
-	return &res.UserID, nil
+	return &session, nil
+}
+
+// FindByRefreshHash looks a refresh token up by its current or previous hash,
+// locking the row so two refreshes with the same token cannot both rotate it.
+func (r *sessionRepository) FindByRefreshHash(ctx context.Context, hash string) (*entity.Session, error) {
+	db := infra.GetDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"})
+
+	var session entity.Session
+	err := db.
+		Where("refresh_token_hash = ? OR previous_refresh_hash = ?", hash, hash).
+		First(&session).
+		Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.Logger.Error("Error query find session by refresh token: ", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) FindByID(ctx context.Context, id uint) (*entity.Session, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var session entity.Session
+	if err := db.First(&session, id).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			r.Logger.Error("Error query find session: ", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// FindActiveByUser lists the sessions a user can still refresh, most recently
+// used first.
+func (r *sessionRepository) FindActiveByUser(ctx context.Context, userID uint) ([]entity.Session, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var sessions []entity.Session
+	err := db.
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Where("expires_at > NOW() OR refresh_expires_at > NOW()").
+		Order("last_used_at DESC, id DESC").
+		Find(&sessions).Error
+	if err != nil {
+		r.Logger.Error("Error query find user sessions: ", zap.Error(err))
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Touch slides the access token expiry after activity on the session.
+func (r *sessionRepository) Touch(ctx context.Context, id uint, expiresAt time.Time) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Model(&entity.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"expires_at":   expiresAt,
+			"last_used_at": time.Now(),
+		}).Error
+	if err != nil {
+		r.Logger.Error("Error query touch session: ", zap.Error(err))
+	}
+
+	return err
 }
 
 func (r *sessionRepository) Revoke(ctx context.Context, token string) error {
@@ -87,4 +157,36 @@ func (r *sessionRepository) Revoke(ctx context.Context, token string) error {
 	}
 
 	return err
-}
\ No newline at end of file
+}
+
+func (r *sessionRepository) RevokeByID(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Model(&entity.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		r.Logger.Error("Error query revoke session by id: ", zap.Error(err))
+	}
+
+	return err
+}
+
+// RevokeAllByUser revokes every live session of a user except exceptID,
+// which may be 0 to revoke them all.
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID uint, exceptID uint) (int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	query := db.Model(&entity.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != 0 {
+		query = query.Where("id <> ?", exceptID)
+	}
+
+	result := query.Update("revoked_at", time.Now())
+	if result.Error != nil {
+		r.Logger.Error("Error query revoke user sessions: ", zap.Error(result.Error))
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
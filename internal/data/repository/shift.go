@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *entity.Shift) (*entity.Shift, error)
+	CreateBatch(ctx context.Context, shifts []entity.Shift) error
+	FindByID(ctx context.Context, id uint) (*entity.Shift, error)
+	FindAll(ctx context.Context, params request.GetShiftsRequest) ([]entity.Shift, int64, error)
+	FindByWeek(ctx context.Context, year, week int, profileIDs []uint) ([]entity.Shift, error)
+	FindUpcomingByProfile(ctx context.Context, profileID uint, from time.Time, limit int) ([]entity.Shift, error)
+	FindOverlapping(ctx context.Context, profileID uint, start, end time.Time, excludeID uint) ([]entity.Shift, error)
+	Update(ctx context.Context, shift *entity.Shift) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type shiftRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewShiftRepo(db *gorm.DB, log *zap.Logger) ShiftRepository {
+	return &shiftRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "shift")),
+	}
+}
+
+func (r *shiftRepository) Create(ctx context.Context, shift *entity.Shift) (*entity.Shift, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating shift",
+		zap.Uint("profile_id", shift.ProfileID),
+		zap.Time("start", shift.ShiftStart))
+
+	if err := db.Omit(clause.Associations).Create(shift).Error; err != nil {
+		r.logger.Error("Failed to create shift", zap.Error(err))
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+func (r *shiftRepository) CreateBatch(ctx context.Context, shifts []entity.Shift) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if len(shifts) == 0 {
+		return nil
+	}
+
+	r.logger.Info("Creating shifts", zap.Int("count", len(shifts)))
+
+	if err := db.Omit(clause.Associations).Create(&shifts).Error; err != nil {
+		r.logger.Error("Failed to create shifts", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *shiftRepository) FindByID(ctx context.Context, id uint) (*entity.Shift, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var shift entity.Shift
+	err := db.Preload("Profile").First(&shift, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Shift not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find shift", zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &shift, nil
+}
+
+func (r *shiftRepository) FindAll(ctx context.Context, params request.GetShiftsRequest) ([]entity.Shift, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var shifts []entity.Shift
+	var total int64
+
+	query := db.Model(&entity.Shift{})
+
+	if params.ProfileID > 0 {
+		query = query.Where("profile_id = ?", params.ProfileID)
+	}
+	if params.Year > 0 {
+		query = query.Where("year = ?", params.Year)
+	}
+	if params.Week > 0 {
+		query = query.Where("week_number = ?", params.Week)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count shifts", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Profile").
+		Order("shift_start ASC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&shifts).Error
+	if err != nil {
+		r.logger.Error("Failed to find shifts", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return shifts, total, nil
+}
+
+func (r *shiftRepository) FindByWeek(ctx context.Context, year, week int, profileIDs []uint) ([]entity.Shift, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var shifts []entity.Shift
+	query := db.Where("year = ? AND week_number = ?", year, week)
+	if len(profileIDs) > 0 {
+		query = query.Where("profile_id IN ?", profileIDs)
+	}
+
+	if err := query.Order("shift_start ASC").Find(&shifts).Error; err != nil {
+		r.logger.Error("Failed to find shifts by week",
+			zap.Int("year", year),
+			zap.Int("week", week),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+func (r *shiftRepository) FindUpcomingByProfile(ctx context.Context, profileID uint, from time.Time, limit int) ([]entity.Shift, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var shifts []entity.Shift
+	err := db.
+		Where("profile_id = ? AND shift_end >= ?", profileID, from).
+		Order("shift_start ASC").
+		Limit(limit).
+		Find(&shifts).Error
+	if err != nil {
+		r.logger.Error("Failed to find upcoming shifts",
+			zap.Uint("profile_id", profileID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+// FindOverlapping returns the profile's shifts that intersect [start, end).
+func (r *shiftRepository) FindOverlapping(ctx context.Context, profileID uint, start, end time.Time, excludeID uint) ([]entity.Shift, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var shifts []entity.Shift
+	query := db.Where("profile_id = ? AND shift_start < ? AND shift_end > ?", profileID, end, start)
+	if excludeID > 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	if err := query.Find(&shifts).Error; err != nil {
+		r.logger.Error("Failed to find overlapping shifts",
+			zap.Uint("profile_id", profileID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+func (r *shiftRepository) Update(ctx context.Context, shift *entity.Shift) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(shift).Error; err != nil {
+		r.logger.Error("Failed to update shift", zap.Uint("id", shift.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *shiftRepository) Delete(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Delete(&entity.Shift{}, id).Error; err != nil {
+		r.logger.Error("Failed to delete shift", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
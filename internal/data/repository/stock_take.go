@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepository interface {
+	Create(ctx context.Context, stockTake *entity.StockTake) (*entity.StockTake, error)
+	FindByID(ctx context.Context, id uint) (*entity.StockTake, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*entity.StockTake, error)
+	FindAll(ctx context.Context, params request.GetStockTakesRequest) ([]entity.StockTake, int64, error)
+	Update(ctx context.Context, stockTake *entity.StockTake) error
+	UpsertItem(ctx context.Context, item *entity.StockTakeItem) error
+	UpdateItem(ctx context.Context, item *entity.StockTakeItem) error
+	DeleteItem(ctx context.Context, stockTakeID, productID uint) error
+}
+
+type stockTakeRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewStockTakeRepo(db *gorm.DB, log *zap.Logger) StockTakeRepository {
+	return &stockTakeRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "stock_take")),
+	}
+}
+
+func (r *stockTakeRepository) Create(ctx context.Context, stockTake *entity.StockTake) (*entity.StockTake, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating stock take", zap.String("reference", stockTake.Reference))
+
+	if err := db.Omit(clause.Associations).Create(stockTake).Error; err != nil {
+		r.logger.Error("Failed to create stock take", zap.Error(err))
+		return nil, err
+	}
+
+	return stockTake, nil
+}
+
+func (r *stockTakeRepository) FindByID(ctx context.Context, id uint) (*entity.StockTake, error) {
+	return r.findByID(infra.GetDB(ctx, r.db), id)
+}
+
+// FindByIDForUpdate locks the stock take row so counts, commits and cancels
+// of the same session run one after the other.
+func (r *stockTakeRepository) FindByIDForUpdate(ctx context.Context, id uint) (*entity.StockTake, error) {
+	db := infra.GetDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"})
+	return r.findByID(db, id)
+}
+
+func (r *stockTakeRepository) findByID(db *gorm.DB, id uint) (*entity.StockTake, error) {
+	var stockTake entity.StockTake
+	err := db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("product_id ASC")
+		}).
+		Preload("Items.Product").
+		First(&stockTake, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Stock take not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find stock take",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &stockTake, nil
+}
+
+func (r *stockTakeRepository) FindAll(ctx context.Context, params request.GetStockTakesRequest) ([]entity.StockTake, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var stockTakes []entity.StockTake
+	var total int64
+
+	query := db.Model(&entity.StockTake{})
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count stock takes", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&stockTakes).Error
+	if err != nil {
+		r.logger.Error("Failed to find stock takes", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return stockTakes, total, nil
+}
+
+func (r *stockTakeRepository) Update(ctx context.Context, stockTake *entity.StockTake) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(stockTake).Error; err != nil {
+		r.logger.Error("Failed to update stock take",
+			zap.Uint("id", stockTake.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// UpsertItem records a count, replacing an earlier count of the same product
+// in the same session.
+func (r *stockTakeRepository) UpsertItem(ctx context.Context, item *entity.StockTakeItem) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "stock_take_id"}, {Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"counted_quantity", "notes", "counted_by", "updated_at"}),
+	}).Create(item).Error
+	if err != nil {
+		r.logger.Error("Failed to record stock take count",
+			zap.Uint("stock_take_id", item.StockTakeID),
+			zap.Uint("product_id", item.ProductID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *stockTakeRepository) UpdateItem(ctx context.Context, item *entity.StockTakeItem) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Omit(clause.Associations).Save(item).Error; err != nil {
+		r.logger.Error("Failed to update stock take item",
+			zap.Uint("id", item.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *stockTakeRepository) DeleteItem(ctx context.Context, stockTakeID, productID uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	return db.Where("stock_take_id = ? AND product_id = ?", stockTakeID, productID).
+		Delete(&entity.StockTakeItem{}).Error
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error)
+	FindByID(ctx context.Context, id uint) (*entity.Supplier, error)
+	FindAll(ctx context.Context, params request.GetSuppliersRequest) ([]entity.Supplier, int64, error)
+	Update(ctx context.Context, supplier *entity.Supplier) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type supplierRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewSupplierRepo(db *gorm.DB, log *zap.Logger) SupplierRepository {
+	return &supplierRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "supplier")),
+	}
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier *entity.Supplier) (*entity.Supplier, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Creating supplier", zap.String("name", supplier.Name))
+
+	if err := db.Create(supplier).Error; err != nil {
+		r.logger.Error("Failed to create supplier",
+			zap.String("name", supplier.Name),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return supplier, nil
+}
+
+func (r *supplierRepository) FindByID(ctx context.Context, id uint) (*entity.Supplier, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var supplier entity.Supplier
+	err := db.First(&supplier, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			r.logger.Warn("Supplier not found", zap.Uint("id", id))
+		} else {
+			r.logger.Error("Failed to find supplier",
+				zap.Uint("id", id),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (r *supplierRepository) FindAll(ctx context.Context, params request.GetSuppliersRequest) ([]entity.Supplier, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var suppliers []entity.Supplier
+	var total int64
+
+	query := db.Model(&entity.Supplier{})
+
+	if params.Search != "" {
+		search := "%" + params.Search + "%"
+		query = query.Where("name ILIKE ? OR contact_name ILIKE ?", search, search)
+	}
+	if params.IsActive != nil {
+		query = query.Where("is_active = ?", *params.IsActive)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count suppliers", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("name ASC").
+		Limit(params.GetPerPage()).
+		Offset(params.GetOffset()).
+		Find(&suppliers).Error
+	if err != nil {
+		r.logger.Error("Failed to find suppliers", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return suppliers, total, nil
+}
+
+func (r *supplierRepository) Update(ctx context.Context, supplier *entity.Supplier) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Updating supplier", zap.Uint("id", supplier.ID))
+
+	if err := db.Omit(clause.Associations).Save(supplier).Error; err != nil {
+		r.logger.Error("Failed to update supplier",
+			zap.Uint("id", supplier.ID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *supplierRepository) Delete(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	r.logger.Info("Deleting supplier", zap.Uint("id", id))
+
+	if err := db.Delete(&entity.Supplier{}, id).Error; err != nil {
+		r.logger.Error("Failed to delete supplier",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type TokenRevocationRepository interface {
+	Create(ctx context.Context, revocation *entity.TokenRevocation) error
+	FindActiveSince(ctx context.Context, since time.Time) ([]entity.TokenRevocation, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type tokenRevocationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewTokenRevocationRepo(db *gorm.DB, log *zap.Logger) TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "token_revocation")),
+	}
+}
+
+func (r *tokenRevocationRepository) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Create(revocation).Error; err != nil {
+		r.logger.Error("Failed to create token revocation", zap.String("key", revocation.Key), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// FindActiveSince returns revocations recorded after since that have not
+// expired yet.
+func (r *tokenRevocationRepository) FindActiveSince(ctx context.Context, since time.Time) ([]entity.TokenRevocation, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var revocations []entity.TokenRevocation
+	err := db.
+		Where("created_at > ? AND expires_at > NOW()", since).
+		Order("created_at ASC").
+		Find(&revocations).Error
+	if err != nil {
+		r.logger.Error("Failed to find token revocations", zap.Error(err))
+		return nil, err
+	}
+
+	return revocations, nil
+}
+
+func (r *tokenRevocationRepository) DeleteExpired(ctx context.Context) error {
+	db := infra.GetDB(ctx, r.db)
+
+	if err := db.Where("expires_at <= NOW()").Delete(&entity.TokenRevocation{}).Error; err != nil {
+		r.logger.Error("Failed to delete expired token revocations", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
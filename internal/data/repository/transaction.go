@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransactionParams struct {
+	Offset          int
+	Limit           int
+	OrderID         uint
+	PaymentMethodID uint
+	Type            string
+	Status          string
+	StartDate       *time.Time
+	EndDate         *time.Time // exclusive
+}
+
+type TransactionRepository interface {
+	FindAll(ctx context.Context, params TransactionParams) ([]entity.Transaction, int64, error)
+	Stream(ctx context.Context, params TransactionParams, fn func([]entity.Transaction) error) error
+	FindByOrderID(ctx context.Context, orderID uint) ([]entity.Transaction, error)
+	Create(ctx context.Context, transaction *entity.Transaction) error
+}
+
+type transactionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewTransactionRepo(db *gorm.DB, log *zap.Logger) TransactionRepository {
+	return &transactionRepository{
+		db:     db,
+		logger: log.With(zap.String("repository", "transaction")),
+	}
+}
+
+func (r *transactionRepository) FindAll(ctx context.Context, params TransactionParams) ([]entity.Transaction, int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var transactions []entity.Transaction
+	var total int64
+
+	query := r.applyFilters(db.Model(&entity.Transaction{}), params)
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count transactions", zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Order").
+		Preload("PaymentMethod").
+		Order("created_at DESC, id DESC").
+		Limit(params.Limit).
+		Offset(params.Offset).
+		Find(&transactions).Error
+	if err != nil {
+		r.logger.Error("Failed to find transactions", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+// Stream walks every transaction matching params in batches of exportBatchSize.
+func (r *transactionRepository) Stream(ctx context.Context, params TransactionParams, fn func([]entity.Transaction) error) error {
+	db := infra.GetDB(ctx, r.db)
+
+	var batch []entity.Transaction
+	err := r.applyFilters(db.Model(&entity.Transaction{}), params).
+		Preload("Order").
+		Preload("PaymentMethod").
+		FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to stream transactions", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// FindByOrderID returns the transactions of an order in the order they were made.
+func (r *transactionRepository) FindByOrderID(ctx context.Context, orderID uint) ([]entity.Transaction, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var transactions []entity.Transaction
+	err := db.
+		Preload("PaymentMethod").
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&transactions).Error
+	if err != nil {
+		r.logger.Error("Failed to find order transactions", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *transactionRepository) Create(ctx context.Context, transaction *entity.Transaction) error {
+	db := infra.GetDB(ctx, r.db)
+
+	err := db.Omit(clause.Associations).Create(transaction).Error
+	if err != nil {
+		r.logger.Error("Failed to create transaction", zap.Uint("order_id", transaction.OrderID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) applyFilters(query *gorm.DB, params TransactionParams) *gorm.DB {
+	if params.OrderID > 0 {
+		query = query.Where("order_id = ?", params.OrderID)
+	}
+	if params.PaymentMethodID > 0 {
+		query = query.Where("payment_method_id = ?", params.PaymentMethodID)
+	}
+	if params.Type != "" {
+		query = query.Where("transaction_type = ?", params.Type)
+	}
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.StartDate != nil {
+		query = query.Where("created_at >= ?", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query = query.Where("created_at < ?", *params.EndDate)
+	}
+	return query
+}
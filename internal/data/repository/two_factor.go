@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepository interface {
+	FindByUser(ctx context.Context, userID uint) (*entity.UserTwoFactor, error)
+	Save(ctx context.Context, tf *entity.UserTwoFactor) error
+	Enable(ctx context.Context, userID uint, step int64) error
+	UseStep(ctx context.Context, userID uint, step int64) error
+	Delete(ctx context.Context, userID uint) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codes []entity.RecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error
+	CountRecoveryCodes(ctx context.Context, userID uint) (int64, error)
+}
+
+type twoFactorRepository struct {
+	db     *gorm.DB
+	Logger *zap.Logger
+}
+
+func NewTwoFactorRepo(db *gorm.DB, log *zap.Logger) TwoFactorRepository {
+	return &twoFactorRepository{
+		db:     db,
+		Logger: log,
+	}
+}
+
+func (r *twoFactorRepository) FindByUser(ctx context.Context, userID uint) (*entity.UserTwoFactor, error) {
+	var tf entity.UserTwoFactor
+	err := infra.GetDB(ctx, r.db).
+		Where("user_id = ?", userID).
+		First(&tf).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// Save stores a new pending enrollment, replacing whatever the user had
+// started or enabled before.
+func (r *twoFactorRepository) Save(ctx context.Context, tf *entity.UserTwoFactor) error {
+	err := infra.GetDB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "updated_at"}),
+		}).
+		Create(tf).Error
+	if err != nil {
+		r.Logger.Error("Error save two factor", zap.Error(err))
+	}
+	return err
+}
+
+// Enable confirms a pending enrollment with the step of its first code.
+func (r *twoFactorRepository) Enable(ctx context.Context, userID uint, step int64) error {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.UserTwoFactor{}).
+		Where("user_id = ? AND enabled_at IS NULL", userID).
+		Updates(map[string]any{
+			"enabled_at":     time.Now(),
+			"last_used_step": step,
+		})
+	if res.Error != nil {
+		r.Logger.Error("Error enable two factor", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UseStep records the step of an accepted code. It returns
+// gorm.ErrRecordNotFound when that step or a later one was already used, so
+// a code cannot be replayed.
+func (r *twoFactorRepository) UseStep(ctx context.Context, userID uint, step int64) error {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.UserTwoFactor{}).
+		Where("user_id = ? AND enabled_at IS NOT NULL AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		r.Logger.Error("Error use two factor step", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete turns two factor off for the user, recovery codes included.
+func (r *twoFactorRepository) Delete(ctx context.Context, userID uint) error {
+	db := infra.GetDB(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		r.Logger.Error("Error delete recovery codes", zap.Error(err))
+		return err
+	}
+	if err := db.Where("user_id = ?", userID).Delete(&entity.UserTwoFactor{}).Error; err != nil {
+		r.Logger.Error("Error delete two factor", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codes []entity.RecoveryCode) error {
+	db := infra.GetDB(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		r.Logger.Error("Error delete recovery codes", zap.Error(err))
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	if err := db.Create(&codes).Error; err != nil {
+		r.Logger.Error("Error create recovery codes", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes a recovery code. It returns
+// gorm.ErrRecordNotFound when the code is unknown or already used.
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) error {
+	res := infra.GetDB(ctx, r.db).
+		Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		r.Logger.Error("Error use recovery code", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountRecoveryCodes counts the codes the user has left.
+func (r *twoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := infra.GetDB(ctx, r.db).
+		Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		r.Logger.Error("Error count recovery codes", zap.Error(err))
+	}
+	return count, err
+}
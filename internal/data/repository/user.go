@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
@@ -19,6 +20,9 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, id uint) (entity.User, error)
 	UpdateUser(ctx context.Context, id uint, data *entity.User) error
 	DeleteUser(ctx context.Context, id uint) error
+	CountByRole(ctx context.Context, role entity.UserRole) (int64, error)
+	FindDeletedByID(ctx context.Context, id uint) (entity.User, error)
+	RestoreUser(ctx context.Context, id uint) error
 }
 
 type userRepository struct {
@@ -54,8 +58,8 @@ func (r *userRepository) CreateUser(ctx context.Context, user *entity.User) (*en
 func (r *userRepository) FindUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	db := infra.GetDB(ctx, r.db)
 	var user entity.User
-	query := db.WithContext(ctx).Model(&user).Where("email = ?", email).Limit(1)
-	err := query.Find(&user).Error
+	query := db.WithContext(ctx).Model(&user).Where("email = ?", email)
+	err := query.First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -150,4 +154,57 @@ func (r *userRepository) DeleteUser(ctx context.Context, id uint) error {
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// CountByRole counts the users holding role and locks their rows, so two
+// concurrent demotions cannot both see the other one still in place.
+func (r *userRepository) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var ids []uint
+	err := db.Model(&entity.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ?", role).
+		Pluck("id", &ids).
+		Error
+	if err != nil {
+		r.Logger.Error("Error query count users by role", zap.Error(err))
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+func (r *userRepository) FindDeletedByID(ctx context.Context, id uint) (entity.User, error) {
+	db := infra.GetDB(ctx, r.db)
+
+	var user entity.User
+	err := db.Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&user).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, utils.ErrUserNotFound
+		}
+		r.Logger.Error("Error query get deleted user by id", zap.Error(err))
+		return user, err
+	}
+	return user, nil
+}
+
+func (r *userRepository) RestoreUser(ctx context.Context, id uint) error {
+	db := infra.GetDB(ctx, r.db)
+
+	result := db.Unscoped().
+		Model(&entity.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		r.Logger.Error("Error query restore user", zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
@@ -8,6 +8,10 @@ import (
 
 func SeedAll(db *gorm.DB) error {
 	return db.Transaction(func(tx *gorm.DB) error {
+		err := data.PermissionSeeds(tx)
+		if err != nil {
+			return err
+		}
 		users, err := data.UserSeeds(tx)
 		if err != nil {
 			return err
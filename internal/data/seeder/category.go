@@ -20,22 +20,27 @@ func CategorySeeds(db *gorm.DB) ([]entity.Category, error) {
 		{
 			Name:        "Coffee",
 			Description: "Berbagai macam kopi panas dan dingin",
+			Station:     "bar",
 		},
 		{
 			Name:        "Non Coffee",
 			Description: "Minuman non kopi seperti teh dan coklat",
+			Station:     "bar",
 		},
 		{
 			Name:        "Food",
 			Description: "Makanan berat dan ringan",
+			Station:     "kitchen",
 		},
 		{
 			Name:        "Snack",
 			Description: "Camilan pendamping minum kopi",
+			Station:     "kitchen",
 		},
 		{
 			Name:        "Dessert",
 			Description: "Makanan penutup",
+			Station:     "pastry",
 		},
 	}
 
@@ -20,6 +20,7 @@ func PaymentMethodSeeds(db *gorm.DB) ([]entity.PaymentMethod, error) {
 	pMethods = []entity.PaymentMethod{
 		{
 			Name: "Cash",
+			IsCash: true,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
@@ -0,0 +1,92 @@
+package data
+
+import (
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PermissionSeeds syncs the permission catalog and creates the built-in roles
+// that are missing. Unlike the other seeds it runs on every start so new
+// permissions appear; existing roles keep the permissions they were edited to
+// and only receive the defaults of permissions that did not exist before.
+func PermissionSeeds(db *gorm.DB) error {
+	var known []string
+	if err := db.Model(&entity.Permission{}).Pluck("name", &known).Error; err != nil {
+		return err
+	}
+	existed := make(map[string]bool, len(known))
+	for _, name := range known {
+		existed[name] = true
+	}
+
+	catalog := append([]entity.Permission(nil), entity.PermissionCatalog...)
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description"}),
+	}).Create(&catalog).Error
+	if err != nil {
+		return err
+	}
+
+	roles := []entity.Role{
+		{Name: string(entity.RoleSuperAdmin), Description: "Full access to everything", IsSystem: true},
+		{Name: string(entity.RoleAdmin), Description: "Outlet manager", IsSystem: true},
+		{Name: string(entity.RoleStaff), Description: "Front of house and kitchen staff", IsSystem: true},
+	}
+
+	for _, role := range roles {
+		var existing entity.Role
+		err := db.Where("name = ?", role.Name).First(&existing).Error
+		if err == nil {
+			if err := grantNewPermissions(db, &existing, existed); err != nil {
+				return err
+			}
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var permissions []entity.Permission
+		names := entity.DefaultRolePermissions[entity.UserRole(role.Name)]
+		if len(names) > 0 {
+			if err := db.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+				return err
+			}
+		}
+		role.Permissions = permissions
+
+		if err := db.Create(&role).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grantNewPermissions adds to an existing built-in role the default
+// permissions that were only just added to the catalog.
+func grantNewPermissions(db *gorm.DB, role *entity.Role, existed map[string]bool) error {
+	if len(existed) == 0 {
+		return nil
+	}
+
+	var names []string
+	for _, name := range entity.DefaultRolePermissions[entity.UserRole(role.Name)] {
+		if !existed[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	var permissions []entity.Permission
+	if err := db.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return err
+	}
+	return db.Model(role).Association("Permissions").Append(permissions)
+}
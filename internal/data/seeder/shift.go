@@ -50,8 +50,7 @@ func ShiftSeeds(db *gorm.DB, profiles []entity.Profile) error {
 		Clauses(clause.OnConflict{
 			Columns: []clause.Column{
 				{Name: "profile_id"},
-				{Name: "week_number"},
-				{Name: "year"},
+				{Name: "shift_start"},
 			},
 			DoNothing: true,
 		}).
@@ -0,0 +1,27 @@
+package request
+
+type ClockRequest struct {
+	Notes string `json:"notes" validate:"omitempty,max=255"`
+}
+
+// CorrectAttendanceRequest takes RFC3339 timestamps. The note is required so
+// every manager correction carries its reason.
+type CorrectAttendanceRequest struct {
+	ClockIn  string `json:"clock_in" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ClockOut string `json:"clock_out" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ShiftID  *uint  `json:"shift_id"`
+	Note     string `json:"note" validate:"required,max=500"`
+}
+
+type GetAttendanceRequest struct {
+	PaginationRequest
+	ProfileID uint   `json:"profile_id" form:"profile_id"`
+	StartDate string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type TimesheetRequest struct {
+	StartDate string `json:"start_date" form:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" form:"end_date" validate:"required,datetime=2006-01-02"`
+	Format    string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
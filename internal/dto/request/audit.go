@@ -0,0 +1,11 @@
+package request
+
+type GetAuditLogsRequest struct {
+	PaginationRequest
+	ActorID    uint   `json:"actor_id" form:"actor_id"`
+	Action     string `json:"action" form:"action" validate:"omitempty,oneof=create update delete"`
+	EntityType string `json:"entity_type" form:"entity_type" validate:"omitempty,max=50"`
+	EntityID   uint   `json:"entity_id" form:"entity_id"`
+	StartDate  string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
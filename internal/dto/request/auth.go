@@ -1,21 +1,62 @@
 package request
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email" validate:"email"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=100"`
+	IPAddress  string `json:"-"`
+	UserAgent  string `json:"-"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	IPAddress    string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
 type ResetPasswordRequest struct {
-	Email string `json:"email" validate:"email"`
+	Email     string `json:"email" validate:"email"`
+	IPAddress string `json:"-"`
 }
 
 type ResetPassword struct {
 	Email       string `json:"email" validate:"email"`
-	NewPassword string `json:"new_password"`
-	ResetToken  string `json:"reset_token"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+	ResetToken  string `json:"reset_token" validate:"required"`
 }
 
 type ValidateOTP struct {
-	Email string `json:"email"`
-	OTP   string `json:"otp"`
-}
\ No newline at end of file
+	Email string `json:"email" validate:"email"`
+	OTP   string `json:"otp" validate:"required,len=6,numeric"`
+}
+
+type SetManagerPINRequest struct {
+	Password string `json:"password" validate:"required"`
+	PIN      string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+type VerifyLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Method         string `json:"method" validate:"required,oneof=totp recovery_code email"`
+	Code           string `json:"code" validate:"required,max=20"`
+}
+
+type LoginCodeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	IPAddress      string `json:"-"`
+}
+
+type SetupTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type EnableTwoFactorRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// TwoFactorConfirmRequest guards changes to an enabled second factor with
+// the password and an authenticator or recovery code.
+type TwoFactorConfirmRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
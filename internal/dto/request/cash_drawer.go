@@ -0,0 +1,22 @@
+package request
+
+type OpenCashDrawerRequest struct {
+	OpeningFloat float64 `json:"opening_float" validate:"gte=0"`
+	Notes        string  `json:"notes" validate:"omitempty,max=500"`
+}
+
+type CashMovementRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+	Reason string  `json:"reason" validate:"required,max=255"`
+}
+
+type CloseCashDrawerRequest struct {
+	CountedCash *float64 `json:"counted_cash" validate:"required,gte=0"`
+	Notes       string   `json:"notes" validate:"omitempty,max=500"`
+}
+
+type GetCashDrawersRequest struct {
+	PaginationRequest
+	CashierID uint   `json:"cashier_id" form:"cashier_id"`
+	Status    string `json:"status" form:"status" validate:"omitempty,oneof=open closed"`
+}
@@ -10,10 +10,12 @@ type CreateCategoryRequest struct {
 	Name        string `json:"name" validate:"required,min=3,max=100"`
 	IconURL     string `json:"icon_url" validate:"omitempty,url"`
 	Description string `json:"description" validate:"omitempty,max=500"`
+	Station     string `json:"station" validate:"omitempty,max=50"`
 }
 
 type UpdateCategoryRequest struct {
 	Name        string `json:"name" validate:"omitempty,min=3,max=100"`
 	IconURL     string `json:"icon_url" validate:"omitempty,url"`
 	Description string `json:"description" validate:"omitempty,max=500"`
+	Station     string `json:"station" validate:"omitempty,max=50"`
 }
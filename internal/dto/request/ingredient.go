@@ -0,0 +1,37 @@
+package request
+
+type CreateIngredientRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=100"`
+	Unit        string  `json:"unit" validate:"required,oneof=g kg ml l pcs"`
+	Stock       float64 `json:"stock" validate:"min=0"`
+	MinStock    float64 `json:"min_stock" validate:"min=0"`
+	CostPerUnit float64 `json:"cost_per_unit" validate:"min=0"`
+}
+
+type UpdateIngredientRequest struct {
+	Name        string   `json:"name" validate:"omitempty,min=1,max=100"`
+	Unit        string   `json:"unit" validate:"omitempty,oneof=g kg ml l pcs"`
+	MinStock    *float64 `json:"min_stock" validate:"omitempty,min=0"`
+	CostPerUnit *float64 `json:"cost_per_unit" validate:"omitempty,min=0"`
+}
+
+type GetIngredientsRequest struct {
+	PaginationRequest
+	Search   string `json:"search" form:"search"`
+	LowStock bool   `json:"low_stock" form:"low_stock"`
+}
+
+type AdjustIngredientStockRequest struct {
+	Action         string  `json:"action" validate:"required,oneof=restock adjustment"`
+	QuantityChange float64 `json:"quantity_change" validate:"required"`
+	Notes          string  `json:"notes" validate:"omitempty,max=500"`
+}
+
+type RecipeItemRequest struct {
+	IngredientID uint    `json:"ingredient_id" validate:"required"`
+	Quantity     float64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type SetRecipeRequest struct {
+	Items []RecipeItemRequest `json:"items" validate:"dive"`
+}
@@ -1,12 +1,27 @@
 package request
 
 type CreateInventoryLogRequest struct {
-	ProductID      uint   `json:"product_id"`
-	Action         string `json:"action"`
+	ProductID      uint   `json:"product_id" validate:"required"`
+	Action         string `json:"action" validate:"required,oneof=restock adjustment"`
 	QuantityChange int    `json:"quantity_change"`
 	Notes          string `json:"notes"`
 }
 
 type InventoryLogsFilter struct {
 	PaginationRequest
-}
\ No newline at end of file
+	ProductID     uint   `json:"product_id" form:"product_id"`
+	Type          string `json:"type" form:"type" validate:"omitempty,oneof=in out adjustment initial"`
+	ReferenceType string `json:"reference_type" form:"reference_type"`
+	CreatedBy     uint   `json:"created_by" form:"created_by"`
+	StartDate     string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate       string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	SortBy        string `json:"sort_by" form:"sort_by" validate:"omitempty,oneof=created_at quantity_change current_stock_after"`
+	SortOrder     string `json:"sort_order" form:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Format        string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type StockHistoryRequest struct {
+	StartDate string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Interval  string `json:"interval" form:"interval" validate:"omitempty,oneof=entry day"`
+}
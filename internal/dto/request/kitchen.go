@@ -0,0 +1,5 @@
+package request
+
+type KitchenQueueRequest struct {
+	Station string `json:"station" form:"station" validate:"omitempty,max=50"`
+}
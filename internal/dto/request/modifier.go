@@ -0,0 +1,31 @@
+package request
+
+type CreateModifierGroupRequest struct {
+	Name       string                        `json:"name" validate:"required,min=1,max=100"`
+	IsRequired bool                          `json:"is_required"`
+	MinSelect  int                           `json:"min_select" validate:"min=0"`
+	MaxSelect  *int                          `json:"max_select" validate:"omitempty,min=0"`
+	SortOrder  int                           `json:"sort_order"`
+	Options    []CreateModifierOptionRequest `json:"options" validate:"dive"`
+}
+
+type UpdateModifierGroupRequest struct {
+	Name       string `json:"name" validate:"omitempty,min=1,max=100"`
+	IsRequired *bool  `json:"is_required"`
+	MinSelect  *int   `json:"min_select" validate:"omitempty,min=0"`
+	MaxSelect  *int   `json:"max_select" validate:"omitempty,min=0"`
+	SortOrder  *int   `json:"sort_order"`
+}
+
+type CreateModifierOptionRequest struct {
+	Name       string  `json:"name" validate:"required,min=1,max=100"`
+	PriceDelta float64 `json:"price_delta"`
+	SortOrder  int     `json:"sort_order"`
+}
+
+type UpdateModifierOptionRequest struct {
+	Name       string   `json:"name" validate:"omitempty,min=1,max=100"`
+	PriceDelta *float64 `json:"price_delta"`
+	IsActive   *bool    `json:"is_active"`
+	SortOrder  *int     `json:"sort_order"`
+}
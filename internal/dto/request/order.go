@@ -0,0 +1,52 @@
+package request
+
+type OrderItemRequest struct {
+	ProductID         uint   `json:"product_id" validate:"required"`
+	Quantity          int    `json:"quantity" validate:"required,min=1"`
+	ModifierOptionIDs []uint `json:"modifier_option_ids"`
+	Notes             string `json:"notes" validate:"omitempty,max=500"`
+}
+
+type CreateOrderRequest struct {
+	TableID    uint               `json:"table_id" validate:"required"`
+	CustomerID *uint              `json:"customer_id"`
+	Notes      string             `json:"notes" validate:"omitempty,max=500"`
+	Items      []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type GetOrdersRequest struct {
+	PaginationRequest
+	Status    string `json:"status" form:"status"`
+	TableID   uint   `json:"table_id" form:"table_id"`
+	Date      string `json:"date" form:"date"`
+	StartDate string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Format    string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status     string           `json:"status" validate:"required,oneof=pending in_process cooking completed cancelled"`
+	StatusDesc string           `json:"status_desc" validate:"omitempty,max=100"`
+	Override   *ManagerOverride `json:"override"`
+}
+
+// ManagerOverride lets a manager approve an action the requester may not
+// perform on their own.
+type ManagerOverride struct {
+	ManagerID uint   `json:"manager_id" validate:"required"`
+	PIN       string `json:"pin" validate:"required,numeric,min=4,max=6"`
+}
+
+type ApplyDiscountRequest struct {
+	Type     string           `json:"type" validate:"required,oneof=amount percent"`
+	Value    float64          `json:"value" validate:"required,gt=0"`
+	Reason   string           `json:"reason" validate:"required,max=255"`
+	Override *ManagerOverride `json:"override"`
+}
+
+type RefundOrderRequest struct {
+	Amount          float64          `json:"amount" validate:"required,gt=0"`
+	PaymentMethodID uint             `json:"payment_method_id"`
+	Reason          string           `json:"reason" validate:"required,max=255"`
+	Override        *ManagerOverride `json:"override"`
+}
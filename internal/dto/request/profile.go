@@ -1,11 +1,32 @@
 package request
 
+// ProfileRequest is what users may change on their own profile. Salary is
+// set by an admin through SalaryChangeRequest.
 type ProfileRequest struct {
-	FullName          string  `gorm:"not null" json:"full_name"`
-	Phone             string  `json:"phone"`
-	DateOfBirth       string  `json:"date_of_birth"`
-	Salary            float64 `json:"salary"`
-	ProfileImageURL   string  `json:"profile_image_url"`
-	Address           string  `json:"address"`
-	AdditionalDetails string  `gorm:"type:text" json:"additional_details,omitempty"`
-}
\ No newline at end of file
+	FullName          string `gorm:"not null" json:"full_name"`
+	Phone             string `json:"phone"`
+	DateOfBirth       string `json:"date_of_birth"`
+	ProfileImageURL   string `json:"profile_image_url"`
+	Address           string `json:"address"`
+	AdditionalDetails string `gorm:"type:text" json:"additional_details,omitempty"`
+}
+
+// StaffProfileRequest is an admin's edit of someone else's profile. Empty
+// fields are left as they are; DateOfBirth is written like "2 January 2006".
+type StaffProfileRequest struct {
+	FullName          string `json:"full_name" validate:"omitempty,max=100"`
+	Phone             string `json:"phone" validate:"omitempty,max=20"`
+	DateOfBirth       string `json:"date_of_birth"`
+	ProfileImageURL   string `json:"profile_image_url"`
+	Address           string `json:"address"`
+	AdditionalDetails string `json:"additional_details"`
+}
+
+// SalaryChangeRequest sets a user's salary from EffectiveDate on, written
+// like "2 January 2006". It defaults to today and may lie in the past but
+// not in the future.
+type SalaryChangeRequest struct {
+	Salary        *float64 `json:"salary" validate:"required,gte=0"`
+	EffectiveDate string   `json:"effective_date"`
+	Note          string   `json:"note" validate:"max=255"`
+}
@@ -0,0 +1,38 @@
+package request
+
+type PurchaseOrderItemRequest struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  int     `json:"quantity" validate:"required,min=1"`
+	UnitCost  float64 `json:"unit_cost" validate:"min=0"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID uint                       `json:"supplier_id" validate:"required"`
+	ExpectedAt string                     `json:"expected_at" validate:"omitempty,datetime=2006-01-02"`
+	Notes      string                     `json:"notes" validate:"omitempty,max=500"`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type UpdatePurchaseOrderRequest struct {
+	SupplierID uint                       `json:"supplier_id"`
+	ExpectedAt string                     `json:"expected_at" validate:"omitempty,datetime=2006-01-02"`
+	Notes      *string                    `json:"notes" validate:"omitempty,max=500"`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"omitempty,dive"`
+}
+
+type ReceiveItemRequest struct {
+	ItemID   uint     `json:"item_id" validate:"required"`
+	Quantity int      `json:"quantity" validate:"required,min=1"`
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,min=0"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceiveItemRequest `json:"items" validate:"required,min=1,dive"`
+	Notes string               `json:"notes" validate:"omitempty,max=500"`
+}
+
+type GetPurchaseOrdersRequest struct {
+	PaginationRequest
+	Status     string `json:"status" form:"status"`
+	SupplierID uint   `json:"supplier_id" form:"supplier_id"`
+}
@@ -0,0 +1,8 @@
+package request
+
+// ReceiptRequest picks how a receipt is rendered. PaperWidth only applies to
+// escpos and defaults to the configured printer width.
+type ReceiptRequest struct {
+	Format     string `json:"format" form:"format" validate:"omitempty,oneof=json html pdf escpos"`
+	PaperWidth int    `json:"paper_width" form:"paper_width" validate:"omitempty,oneof=58 80"`
+}
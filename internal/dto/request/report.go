@@ -0,0 +1,21 @@
+package request
+
+// ReportRequest is the date range shared by the sales reports. Dates are
+// inclusive and read in Timezone, which defaults to the business timezone.
+// Format csv or xlsx downloads the report as a table instead of JSON.
+type ReportRequest struct {
+	StartDate string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Timezone  string `json:"timezone" form:"timezone"`
+	Format    string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type RevenueReportRequest struct {
+	ReportRequest
+	Period string `json:"period" form:"period" validate:"omitempty,oneof=day week month"`
+}
+
+type ProductSalesReportRequest struct {
+	ReportRequest
+	Limit int `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`
+}
@@ -27,7 +27,10 @@ type UpdateReservationRequest struct {
 type GetReservationsRequest struct {
 	PaginationRequest
 	Date       string `json:"date" form:"date"`
+	StartDate  string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate    string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
 	Status     string `json:"status" form:"status"`
 	CustomerID uint   `json:"customer_id" form:"customer_id"`
 	TableID    uint   `json:"table_id" form:"table_id"`
+	Format     string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
 }
@@ -0,0 +1,24 @@
+package request
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=3,max=20,lowercase,alphanum"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// UpdateRoleRequest replaces the role's permissions. A nil Description keeps
+// the current one.
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+type PermissionOverrideRequest struct {
+	Permission string `json:"permission" validate:"required"`
+	Granted    *bool  `json:"granted" validate:"required"`
+}
+
+// UserPermissionsRequest replaces every override of a user.
+type UserPermissionsRequest struct {
+	Overrides []PermissionOverrideRequest `json:"overrides" validate:"required,dive"`
+}
@@ -0,0 +1,41 @@
+package request
+
+type CreateShiftRequest struct {
+	ProfileID uint   `json:"profile_id" validate:"required"`
+	Date      string `json:"date" validate:"required,datetime=2006-01-02"`
+	StartTime string `json:"start_time" validate:"omitempty"`
+	EndTime   string `json:"end_time" validate:"omitempty"`
+	Notes     string `json:"notes" validate:"omitempty,max=255"`
+}
+
+type BulkAssignShiftsRequest struct {
+	ProfileIDs    []uint   `json:"profile_ids" validate:"required,min=1"`
+	Dates         []string `json:"dates" validate:"required,min=1,dive,datetime=2006-01-02"`
+	StartTime     string   `json:"start_time" validate:"omitempty"`
+	EndTime       string   `json:"end_time" validate:"omitempty"`
+	Notes         string   `json:"notes" validate:"omitempty,max=255"`
+	SkipConflicts bool     `json:"skip_conflicts"`
+}
+
+type CopyWeekRequest struct {
+	FromYear      int    `json:"from_year" validate:"required,min=2000"`
+	FromWeek      int    `json:"from_week" validate:"required,min=1,max=53"`
+	ToYear        int    `json:"to_year" validate:"required,min=2000"`
+	ToWeek        int    `json:"to_week" validate:"required,min=1,max=53"`
+	ProfileIDs    []uint `json:"profile_ids"`
+	SkipConflicts bool   `json:"skip_conflicts"`
+}
+
+type UpdateShiftRequest struct {
+	Date      string  `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	StartTime string  `json:"start_time" validate:"omitempty"`
+	EndTime   string  `json:"end_time" validate:"omitempty"`
+	Notes     *string `json:"notes" validate:"omitempty,max=255"`
+}
+
+type GetShiftsRequest struct {
+	PaginationRequest
+	ProfileID uint `json:"profile_id" form:"profile_id"`
+	Year      int  `json:"year" form:"year"`
+	Week      int  `json:"week" form:"week"`
+}
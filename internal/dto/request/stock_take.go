@@ -0,0 +1,24 @@
+package request
+
+type CreateStockTakeRequest struct {
+	Notes string `json:"notes" validate:"omitempty,max=500"`
+}
+
+type StockTakeCountRequest struct {
+	ProductID       uint   `json:"product_id" validate:"required"`
+	CountedQuantity int    `json:"counted_quantity" validate:"min=0"`
+	Notes           string `json:"notes" validate:"omitempty,max=255"`
+}
+
+type RecordStockTakeCountsRequest struct {
+	Items []StockTakeCountRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type GetStockTakesRequest struct {
+	PaginationRequest
+	Status string `json:"status" form:"status"`
+}
+
+type StockTakeVarianceRequest struct {
+	Format string `json:"format" form:"format" validate:"omitempty,oneof=csv xlsx"`
+}
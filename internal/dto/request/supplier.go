@@ -0,0 +1,26 @@
+package request
+
+type CreateSupplierRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	ContactName string `json:"contact_name" validate:"omitempty,max=100"`
+	Phone       string `json:"phone" validate:"omitempty,max=20"`
+	Email       string `json:"email" validate:"omitempty,email"`
+	Address     string `json:"address" validate:"omitempty,max=500"`
+	Notes       string `json:"notes" validate:"omitempty,max=500"`
+}
+
+type UpdateSupplierRequest struct {
+	Name        string  `json:"name" validate:"omitempty,min=1,max=100"`
+	ContactName *string `json:"contact_name" validate:"omitempty,max=100"`
+	Phone       *string `json:"phone" validate:"omitempty,max=20"`
+	Email       *string `json:"email" validate:"omitempty,email"`
+	Address     *string `json:"address" validate:"omitempty,max=500"`
+	Notes       *string `json:"notes" validate:"omitempty,max=500"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type GetSuppliersRequest struct {
+	PaginationRequest
+	Search   string `json:"search" form:"search"`
+	IsActive *bool  `json:"is_active" form:"is_active"`
+}
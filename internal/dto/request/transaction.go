@@ -0,0 +1,12 @@
+package request
+
+type GetTransactionsRequest struct {
+	PaginationRequest
+	OrderID         uint   `json:"order_id" form:"order_id"`
+	PaymentMethodID uint   `json:"payment_method_id" form:"payment_method_id"`
+	Type            string `json:"type" form:"type" validate:"omitempty,oneof=payment refund adjustment"`
+	Status          string `json:"status" form:"status" validate:"omitempty,oneof=pending completed failed cancelled"`
+	StartDate       string `json:"start_date" form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate         string `json:"end_date" form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Format          string `json:"format" form:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
@@ -15,4 +15,19 @@ type UserRequest struct {
 type UpdateUserRequest struct {
 	ID   uint   `json:"id"`
 	Role string `json:"role"`
-}
\ No newline at end of file
+}
+
+type InvitationTokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// AcceptInvitationRequest sets the password of an invited user and fills in
+// their profile. DateOfBirth is written like "2 January 2006".
+type AcceptInvitationRequest struct {
+	Token       string `json:"token" validate:"required"`
+	Password    string `json:"password" validate:"required,min=8"`
+	FullName    string `json:"full_name" validate:"required,max=100"`
+	Phone       string `json:"phone" validate:"omitempty,max=20"`
+	DateOfBirth string `json:"date_of_birth"`
+	Address     string `json:"address"`
+}
@@ -0,0 +1,94 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type AttendanceResponse struct {
+	ID                uint                           `json:"id"`
+	ProfileID         uint                           `json:"profile_id"`
+	FullName          string                         `json:"full_name,omitempty"`
+	ShiftID           *uint                          `json:"shift_id,omitempty"`
+	ShiftStart        *time.Time                     `json:"shift_start,omitempty"`
+	ShiftEnd          *time.Time                     `json:"shift_end,omitempty"`
+	ClockIn           time.Time                      `json:"clock_in"`
+	ClockOut          *time.Time                     `json:"clock_out,omitempty"`
+	WorkedMinutes     int                            `json:"worked_minutes"`
+	LateMinutes       int                            `json:"late_minutes"`
+	EarlyLeaveMinutes int                            `json:"early_leave_minutes"`
+	OvertimeMinutes   int                            `json:"overtime_minutes"`
+	Notes             string                         `json:"notes,omitempty"`
+	Corrections       []AttendanceCorrectionResponse `json:"corrections,omitempty"`
+}
+
+type AttendanceCorrectionResponse struct {
+	ID               uint       `json:"id"`
+	CorrectedBy      uint       `json:"corrected_by"`
+	PreviousClockIn  time.Time  `json:"previous_clock_in"`
+	PreviousClockOut *time.Time `json:"previous_clock_out,omitempty"`
+	NewClockIn       time.Time  `json:"new_clock_in"`
+	NewClockOut      *time.Time `json:"new_clock_out,omitempty"`
+	Note             string     `json:"note"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type TimesheetEntry struct {
+	ProfileID       uint    `json:"profile_id"`
+	FullName        string  `json:"full_name"`
+	DaysWorked      int     `json:"days_worked"`
+	WorkedHours     float64 `json:"worked_hours"`
+	OvertimeHours   float64 `json:"overtime_hours"`
+	LateCount       int     `json:"late_count"`
+	LateMinutes     int     `json:"late_minutes"`
+	EarlyLeaveCount int     `json:"early_leave_count"`
+	OpenPunches     int     `json:"open_punches"`
+	MonthlySalary   float64 `json:"monthly_salary"`
+	HourlyRate      float64 `json:"hourly_rate"`
+	LaborCost       float64 `json:"labor_cost"`
+}
+
+type TimesheetResponse struct {
+	StartDate      string           `json:"start_date"`
+	EndDate        string           `json:"end_date"`
+	Entries        []TimesheetEntry `json:"entries"`
+	TotalHours     float64          `json:"total_hours"`
+	TotalLaborCost float64          `json:"total_labor_cost"`
+}
+
+// Converters
+func AttendanceToResponse(a *entity.Attendance) AttendanceResponse {
+	resp := AttendanceResponse{
+		ID:                a.ID,
+		ProfileID:         a.ProfileID,
+		FullName:          a.Profile.FullName,
+		ShiftID:           a.ShiftID,
+		ClockIn:           a.ClockIn,
+		ClockOut:          a.ClockOut,
+		WorkedMinutes:     a.WorkedMinutes,
+		LateMinutes:       a.LateMinutes,
+		EarlyLeaveMinutes: a.EarlyLeaveMinutes,
+		OvertimeMinutes:   a.OvertimeMinutes,
+		Notes:             a.Notes,
+	}
+
+	if a.Shift != nil {
+		resp.ShiftStart = &a.Shift.ShiftStart
+		resp.ShiftEnd = &a.Shift.ShiftEnd
+	}
+
+	for _, c := range a.Corrections {
+		resp.Corrections = append(resp.Corrections, AttendanceCorrectionResponse{
+			ID:               c.ID,
+			CorrectedBy:      c.CorrectedBy,
+			PreviousClockIn:  c.PreviousClockIn,
+			PreviousClockOut: c.PreviousClockOut,
+			NewClockIn:       c.NewClockIn,
+			NewClockOut:      c.NewClockOut,
+			Note:             c.Note,
+			CreatedAt:        c.CreatedAt,
+		})
+	}
+
+	return resp
+}
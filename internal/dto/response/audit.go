@@ -0,0 +1,48 @@
+package response
+
+import (
+	"encoding/json"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID         uint               `json:"id"`
+	ActorID    *uint              `json:"actor_id"`
+	ActorEmail string             `json:"actor_email,omitempty"`
+	Action     entity.AuditAction `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   uint               `json:"entity_id"`
+	Before     json.RawMessage    `json:"before"`
+	After      json.RawMessage    `json:"after"`
+	IPAddress  string             `json:"ip_address,omitempty"`
+	RequestID  string             `json:"request_id,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// Converters
+func AuditLogToResponse(l *entity.AuditLog) AuditLogResponse {
+	r := AuditLogResponse{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Before:     auditJSON(l.Before),
+		After:      auditJSON(l.After),
+		IPAddress:  l.IPAddress,
+		RequestID:  l.RequestID,
+		CreatedAt:  l.CreatedAt,
+	}
+	if l.Actor != nil {
+		r.ActorEmail = l.Actor.Email
+	}
+	return r
+}
+
+func auditJSON(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
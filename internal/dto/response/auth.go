@@ -1,18 +1,78 @@
 package response
 
 import (
+	"project-POS-APP-golang-integer/internal/data/entity"
 	"time"
-
-	"github.com/google/uuid"
 )
 
+// AuthResponse carries the session tokens, or only TwoFactor when the login
+// still needs a second step.
 type AuthResponse struct {
-	Token uuid.UUID `json:"token"`
+	Token            string                      `json:"token,omitempty"`
+	ExpiresAt        time.Time                   `json:"expires_at,omitzero"`
+	RefreshToken     string                      `json:"refresh_token,omitempty"`
+	RefreshExpiresAt time.Time                   `json:"refresh_expires_at,omitzero"`
+	TwoFactor        *TwoFactorChallengeResponse `json:"two_factor,omitempty"`
+}
+
+// TwoFactorChallengeResponse is what the client needs to finish a login: the
+// challenge token to send back with a code and the ways a code can be given.
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string                   `json:"challenge_token"`
+	Methods        []entity.TwoFactorMethod `json:"methods"`
+	EmailCodeSent  bool                     `json:"email_code_sent"`
+	ExpiresAt      time.Time                `json:"expires_at"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	Required          bool       `json:"required"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
+
+// TwoFactorSetupResponse is shown once while enrolling; OTPAuthURI is meant
+// to be rendered as a QR code.
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// RecoveryCodesResponse lists new recovery codes. They are shown only once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// AuthPrincipal is who an access token was issued to.
+type AuthPrincipal struct {
+	UserID    uint
+	Role      string
+	SessionID uint
+}
+
+type SessionResponse struct {
+	ID               uint      `json:"id"`
+	DeviceName       string    `json:"device_name,omitempty"`
+	IPAddress        string    `json:"ip_address,omitempty"`
+	UserAgent        string    `json:"user_agent,omitempty"`
+	Current          bool      `json:"current"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastUsedAt       time.Time `json:"last_used_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
 }
 
-type OTPResponse struct {
-	OTPCode   string `json:"otp_code"`
-	ExpiresAt time.Time `json:"expires_at"`
+// PasswordResetEmail is what the reset email offers: a code to enter in the
+// app and a link that resets without one. It is never returned by the API.
+type PasswordResetEmail struct {
+	OTPCode       string
+	ExpiresAt     time.Time
+	ResetLink     string
+	LinkExpiresAt time.Time
 }
 
 type ResetTokenResponse struct {
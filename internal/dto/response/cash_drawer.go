@@ -0,0 +1,92 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type CashMovementResponse struct {
+	ID        uint                    `json:"id"`
+	Type      entity.CashMovementType `json:"type"`
+	Amount    float64                 `json:"amount"`
+	Reason    string                  `json:"reason"`
+	CreatedBy uint                    `json:"created_by"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type CashDrawerResponse struct {
+	ID           uint                    `json:"id"`
+	CashierID    uint                    `json:"cashier_id"`
+	Status       entity.CashDrawerStatus `json:"status"`
+	OpeningFloat float64                 `json:"opening_float"`
+	OpenedAt     time.Time               `json:"opened_at"`
+	ClosedAt     *time.Time              `json:"closed_at,omitempty"`
+	ClosedBy     *uint                   `json:"closed_by,omitempty"`
+	CashSales    float64                 `json:"cash_sales"`
+	CashRefunds  float64                 `json:"cash_refunds"`
+	PayIns       float64                 `json:"pay_ins"`
+	PayOuts      float64                 `json:"pay_outs"`
+	ExpectedCash float64                 `json:"expected_cash"`
+	CountedCash  *float64                `json:"counted_cash,omitempty"`
+	Variance     *float64                `json:"variance,omitempty"`
+	Notes        string                  `json:"notes,omitempty"`
+	Movements    []CashMovementResponse  `json:"movements,omitempty"`
+}
+
+type ZReportLine struct {
+	PaymentMethodID   uint    `json:"payment_method_id"`
+	PaymentMethodName string  `json:"payment_method_name"`
+	IsCash            bool    `json:"is_cash"`
+	PaymentCount      int64   `json:"payment_count"`
+	Payments          float64 `json:"payments"`
+	RefundCount       int64   `json:"refund_count"`
+	Refunds           float64 `json:"refunds"`
+	Adjustments       float64 `json:"adjustments"`
+	Net               float64 `json:"net"`
+}
+
+type ZReportResponse struct {
+	SessionID    uint               `json:"session_id"`
+	CashierID    uint               `json:"cashier_id"`
+	OpenedAt     time.Time          `json:"opened_at"`
+	ClosedAt     *time.Time         `json:"closed_at,omitempty"`
+	Lines        []ZReportLine      `json:"lines"`
+	GrossSales   float64            `json:"gross_sales"`
+	TotalRefunds float64            `json:"total_refunds"`
+	NetSales     float64            `json:"net_sales"`
+	Drawer       CashDrawerResponse `json:"drawer"`
+}
+
+// Converters
+func CashDrawerToResponse(s *entity.CashDrawerSession) CashDrawerResponse {
+	resp := CashDrawerResponse{
+		ID:           s.ID,
+		CashierID:    s.CashierID,
+		Status:       s.Status,
+		OpeningFloat: s.OpeningFloat,
+		OpenedAt:     s.OpenedAt,
+		ClosedAt:     s.ClosedAt,
+		ClosedBy:     s.ClosedBy,
+		CashSales:    s.CashSales,
+		CashRefunds:  s.CashRefunds,
+		PayIns:       s.PayIns,
+		PayOuts:      s.PayOuts,
+		ExpectedCash: s.ExpectedCash,
+		CountedCash:  s.CountedCash,
+		Variance:     s.Variance,
+		Notes:        s.Notes,
+	}
+
+	for _, m := range s.Movements {
+		resp.Movements = append(resp.Movements, CashMovementResponse{
+			ID:        m.ID,
+			Type:      m.Type,
+			Amount:    m.Amount,
+			Reason:    m.Reason,
+			CreatedBy: m.CreatedBy,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	return resp
+}
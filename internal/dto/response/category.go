@@ -7,6 +7,7 @@ type CategoryResponse struct {
 	Name        string    `json:"name"`
 	IconURL     string    `json:"icon_url,omitempty"`
 	Description string    `json:"description,omitempty"`
+	Station     string    `json:"station"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -0,0 +1,77 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type IngredientResponse struct {
+	ID          uint                  `json:"id"`
+	Name        string                `json:"name"`
+	Unit        entity.IngredientUnit `json:"unit"`
+	Stock       float64               `json:"stock"`
+	MinStock    float64               `json:"min_stock"`
+	CostPerUnit float64               `json:"cost_per_unit"`
+	IsLowStock  bool                  `json:"is_low_stock"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type RecipeItemResponse struct {
+	IngredientID   uint                  `json:"ingredient_id"`
+	IngredientName string                `json:"ingredient_name"`
+	Unit           entity.IngredientUnit `json:"unit"`
+	Quantity       float64               `json:"quantity"`
+}
+
+type IngredientLogResponse struct {
+	ID                uint                    `json:"id"`
+	IngredientID      uint                    `json:"ingredient_id"`
+	Type              entity.InventoryLogType `json:"type"`
+	QuantityChange    float64                 `json:"quantity_change"`
+	CurrentStockAfter float64                 `json:"current_stock_after"`
+	ReferenceID       *uint                   `json:"reference_id,omitempty"`
+	ReferenceType     string                  `json:"reference_type,omitempty"`
+	Notes             string                  `json:"notes,omitempty"`
+	CreatedBy         uint                    `json:"created_by"`
+	CreatedAt         time.Time               `json:"created_at"`
+}
+
+// Converters
+func IngredientToResponse(ingredient *entity.Ingredient) IngredientResponse {
+	return IngredientResponse{
+		ID:          ingredient.ID,
+		Name:        ingredient.Name,
+		Unit:        ingredient.Unit,
+		Stock:       ingredient.Stock,
+		MinStock:    ingredient.MinStock,
+		CostPerUnit: ingredient.CostPerUnit,
+		IsLowStock:  ingredient.IsLowStock(),
+		CreatedAt:   ingredient.CreatedAt,
+		UpdatedAt:   ingredient.UpdatedAt,
+	}
+}
+
+func RecipeItemToResponse(item *entity.RecipeItem) RecipeItemResponse {
+	return RecipeItemResponse{
+		IngredientID:   item.IngredientID,
+		IngredientName: item.Ingredient.Name,
+		Unit:           item.Ingredient.Unit,
+		Quantity:       item.Quantity,
+	}
+}
+
+func IngredientLogToResponse(log *entity.IngredientLog) IngredientLogResponse {
+	return IngredientLogResponse{
+		ID:                log.ID,
+		IngredientID:      log.IngredientID,
+		Type:              log.Type,
+		QuantityChange:    log.QuantityChange,
+		CurrentStockAfter: log.CurrentStockAfter,
+		ReferenceID:       log.ReferenceID,
+		ReferenceType:     log.ReferenceType,
+		Notes:             log.Notes,
+		CreatedBy:         log.CreatedBy,
+		CreatedAt:         log.CreatedAt,
+	}
+}
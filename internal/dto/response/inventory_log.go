@@ -16,4 +16,21 @@ type InventoryLogResponse struct {
 	Notes             string                  `json:"notes,omitempty"`
 	CreatedBy         uint                    `json:"created_by"`
 	CreatedAt         time.Time               `json:"created_at"`
-}
\ No newline at end of file
+}
+type StockHistoryPoint struct {
+	Time           time.Time               `json:"time"`
+	Stock          int                     `json:"stock"`
+	QuantityChange int                     `json:"quantity_change"`
+	Type           entity.InventoryLogType `json:"type,omitempty"`
+	ReferenceID    *uint                   `json:"reference_id,omitempty"`
+	ReferenceType  string                  `json:"reference_type,omitempty"`
+}
+
+type ProductStockHistoryResponse struct {
+	ProductID    uint                `json:"product_id"`
+	ProductName  string              `json:"product_name"`
+	CurrentStock int                 `json:"current_stock"`
+	OpeningStock int                 `json:"opening_stock"`
+	Interval     string              `json:"interval"`
+	Points       []StockHistoryPoint `json:"points"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+type KitchenItemResponse struct {
+	ItemID         uint      `json:"item_id"`
+	OrderID        uint      `json:"order_id"`
+	OrderNumber    string    `json:"order_number"`
+	OrderStatus    string    `json:"order_status"`
+	TableNumber    string    `json:"table_number,omitempty"`
+	ProductName    string    `json:"product_name"`
+	Quantity       int       `json:"quantity"`
+	Modifiers      []string  `json:"modifiers,omitempty"`
+	Notes          string    `json:"notes,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+	ElapsedMinutes int       `json:"elapsed_minutes"`
+}
+
+type KitchenStationResponse struct {
+	Station   string                `json:"station"`
+	ItemCount int                   `json:"item_count"`
+	Items     []KitchenItemResponse `json:"items"`
+}
+
+type KitchenBumpResponse struct {
+	ItemID      uint       `json:"item_id"`
+	OrderID     uint       `json:"order_id"`
+	BumpedAt    *time.Time `json:"bumped_at"`
+	OrderStatus string     `json:"order_status"`
+}
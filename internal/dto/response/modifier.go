@@ -0,0 +1,60 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type ModifierOptionResponse struct {
+	ID         uint    `json:"id"`
+	GroupID    uint    `json:"group_id"`
+	Name       string  `json:"name"`
+	PriceDelta float64 `json:"price_delta"`
+	IsActive   bool    `json:"is_active"`
+	SortOrder  int     `json:"sort_order"`
+}
+
+type ModifierGroupResponse struct {
+	ID         uint                     `json:"id"`
+	ProductID  uint                     `json:"product_id"`
+	Name       string                   `json:"name"`
+	IsRequired bool                     `json:"is_required"`
+	MinSelect  int                      `json:"min_select"`
+	MaxSelect  int                      `json:"max_select"`
+	SortOrder  int                      `json:"sort_order"`
+	Options    []ModifierOptionResponse `json:"options"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+}
+
+// Converters
+func ModifierOptionToResponse(option *entity.ModifierOption) ModifierOptionResponse {
+	return ModifierOptionResponse{
+		ID:         option.ID,
+		GroupID:    option.GroupID,
+		Name:       option.Name,
+		PriceDelta: option.PriceDelta,
+		IsActive:   option.IsActive,
+		SortOrder:  option.SortOrder,
+	}
+}
+
+func ModifierGroupToResponse(group *entity.ModifierGroup) ModifierGroupResponse {
+	options := make([]ModifierOptionResponse, 0, len(group.Options))
+	for _, o := range group.Options {
+		options = append(options, ModifierOptionToResponse(&o))
+	}
+
+	return ModifierGroupResponse{
+		ID:         group.ID,
+		ProductID:  group.ProductID,
+		Name:       group.Name,
+		IsRequired: group.IsRequired,
+		MinSelect:  group.MinSelect,
+		MaxSelect:  group.MaxSelect,
+		SortOrder:  group.SortOrder,
+		Options:    options,
+		CreatedAt:  group.CreatedAt,
+		UpdatedAt:  group.UpdatedAt,
+	}
+}
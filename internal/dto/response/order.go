@@ -0,0 +1,120 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type OrderItemModifierResponse struct {
+	ModifierOptionID uint    `json:"modifier_option_id"`
+	GroupName        string  `json:"group_name"`
+	OptionName       string  `json:"option_name"`
+	PriceDelta       float64 `json:"price_delta"`
+}
+
+type OrderItemResponse struct {
+	ID          uint                        `json:"id"`
+	ProductID   uint                        `json:"product_id"`
+	ProductName string                      `json:"product_name,omitempty"`
+	Quantity    int                         `json:"quantity"`
+	UnitPrice   float64                     `json:"unit_price"`
+	TotalPrice  float64                     `json:"total_price"`
+	Notes       string                      `json:"notes,omitempty"`
+	BumpedAt    *time.Time                  `json:"bumped_at,omitempty"`
+	Modifiers   []OrderItemModifierResponse `json:"modifiers"`
+}
+
+type OrderResponse struct {
+	ID             uint                `json:"id"`
+	OrderNumber    string              `json:"order_number"`
+	TableID        uint                `json:"table_id"`
+	TableNumber    string              `json:"table_number,omitempty"`
+	CustomerID     *uint               `json:"customer_id,omitempty"`
+	Status         entity.OrderStatus  `json:"status"`
+	Subtotal       float64             `json:"subtotal"`
+	TaxPercentage  float64             `json:"tax_percentage"`
+	TaxAmount      float64             `json:"tax_amount"`
+	Total          float64             `json:"total"`
+	Notes          string              `json:"notes,omitempty"`
+	Discount       *OrderDiscount      `json:"discount,omitempty"`
+	Items          []OrderItemResponse `json:"items"`
+	CreatedBy      uint                `json:"created_by"`
+	VoidedBy       *uint               `json:"voided_by,omitempty"`
+	VoidApprovedBy *uint               `json:"void_approved_by,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+type OrderDiscount struct {
+	Type        entity.DiscountType `json:"type"`
+	Value       float64             `json:"value"`
+	Amount      float64             `json:"amount"`
+	Reason      string              `json:"reason"`
+	RequestedBy *uint               `json:"requested_by"`
+	ApprovedBy  *uint               `json:"approved_by"`
+}
+
+// Converters
+func OrderItemToResponse(item *entity.OrderItem) OrderItemResponse {
+	modifiers := make([]OrderItemModifierResponse, 0, len(item.Modifiers))
+	for _, m := range item.Modifiers {
+		modifiers = append(modifiers, OrderItemModifierResponse{
+			ModifierOptionID: m.ModifierOptionID,
+			GroupName:        m.GroupName,
+			OptionName:       m.OptionName,
+			PriceDelta:       m.PriceDelta,
+		})
+	}
+
+	return OrderItemResponse{
+		ID:          item.ID,
+		ProductID:   item.ProductID,
+		ProductName: item.Product.Name,
+		Quantity:    item.Quantity,
+		UnitPrice:   item.UnitPrice,
+		TotalPrice:  item.TotalPrice,
+		Notes:       item.Notes,
+		BumpedAt:    item.BumpedAt,
+		Modifiers:   modifiers,
+	}
+}
+
+func OrderToResponse(order *entity.Order) OrderResponse {
+	items := make([]OrderItemResponse, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		items = append(items, OrderItemToResponse(&item))
+	}
+
+	var discount *OrderDiscount
+	if order.DiscountType != "" {
+		discount = &OrderDiscount{
+			Type:        order.DiscountType,
+			Value:       order.DiscountValue,
+			Amount:      order.DiscountAmount,
+			Reason:      order.DiscountReason,
+			RequestedBy: order.DiscountedBy,
+			ApprovedBy:  order.DiscountApprovedBy,
+		}
+	}
+
+	return OrderResponse{
+		ID:             order.ID,
+		OrderNumber:    order.OrderNumber,
+		TableID:        order.TableID,
+		TableNumber:    order.Table.TableNumber,
+		CustomerID:     order.CustomerID,
+		Status:         order.Status,
+		Subtotal:       order.Subtotal,
+		TaxPercentage:  order.TaxPercentage,
+		TaxAmount:      order.TaxAmount,
+		Total:          order.Total,
+		Notes:          order.Notes,
+		Discount:       discount,
+		Items:          items,
+		CreatedBy:      order.CreatedBy,
+		VoidedBy:       order.VoidedBy,
+		VoidApprovedBy: order.VoidApprovedBy,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+	}
+}
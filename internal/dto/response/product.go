@@ -11,6 +11,7 @@ type ProductResponse struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	Price       float64           `json:"price"`
+	CostPrice   float64           `json:"cost_price"`
 	Stock       int               `json:"stock"`
 	MinStock    int               `json:"min_stock"`
 	Status      string            `json:"status"`
@@ -38,6 +39,7 @@ func ToProductResponse(product *entity.Product) *ProductResponse {
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
+		CostPrice:   product.CostPrice,
 		Stock:       product.Stock,
 		MinStock:    product.MinStock,
 		Status:      string(product.Status),
@@ -1,6 +1,9 @@
 package response
 
-import "project-POS-APP-golang-integer/internal/data/entity"
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
 
 type ProfileResponse struct {
 	Email             string          `json:"email" validate:"email"`
@@ -12,4 +15,29 @@ type ProfileResponse struct {
 	ProfileImageURL   string          `json:"profile_image_url"`
 	Address           string          `json:"address"`
 	AdditionalDetails string          `gorm:"type:text" json:"additional_details,omitempty"`
-}
\ No newline at end of file
+}
+
+// StaffProfileResponse is a profile as an admin sees it. Salary is left out
+// for admins who may not see salaries.
+type StaffProfileResponse struct {
+	UserID            uint            `json:"user_id"`
+	Email             string          `json:"email"`
+	Role              entity.UserRole `json:"role"`
+	FullName          string          `json:"full_name"`
+	Phone             string          `json:"phone"`
+	DateOfBirth       string          `json:"date_of_birth"`
+	Salary            *float64        `json:"salary,omitempty"`
+	ProfileImageURL   string          `json:"profile_image_url"`
+	Address           string          `json:"address"`
+	AdditionalDetails string          `json:"additional_details,omitempty"`
+}
+
+type SalaryChangeResponse struct {
+	ID             uint      `json:"id"`
+	Salary         float64   `json:"salary"`
+	PreviousSalary float64   `json:"previous_salary"`
+	EffectiveDate  string    `json:"effective_date"`
+	Note           string    `json:"note,omitempty"`
+	ChangedBy      *uint     `json:"changed_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type PurchaseOrderItemResponse struct {
+	ID                uint    `json:"id"`
+	ProductID         uint    `json:"product_id"`
+	ProductName       string  `json:"product_name,omitempty"`
+	QuantityOrdered   int     `json:"quantity_ordered"`
+	QuantityReceived  int     `json:"quantity_received"`
+	RemainingQuantity int     `json:"remaining_quantity"`
+	UnitCost          float64 `json:"unit_cost"`
+	Subtotal          float64 `json:"subtotal"`
+}
+
+type PurchaseOrderResponse struct {
+	ID           uint                        `json:"id"`
+	PONumber     string                      `json:"po_number"`
+	SupplierID   uint                        `json:"supplier_id"`
+	SupplierName string                      `json:"supplier_name,omitempty"`
+	Status       entity.PurchaseOrderStatus  `json:"status"`
+	ExpectedAt   *time.Time                  `json:"expected_at,omitempty"`
+	OrderedAt    *time.Time                  `json:"ordered_at,omitempty"`
+	ReceivedAt   *time.Time                  `json:"received_at,omitempty"`
+	TotalAmount  float64                     `json:"total_amount"`
+	Notes        string                      `json:"notes,omitempty"`
+	Items        []PurchaseOrderItemResponse `json:"items"`
+	CreatedBy    uint                        `json:"created_by"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+// Converters
+func PurchaseOrderToResponse(po *entity.PurchaseOrder) PurchaseOrderResponse {
+	items := make([]PurchaseOrderItemResponse, 0, len(po.Items))
+	for _, item := range po.Items {
+		items = append(items, PurchaseOrderItemResponse{
+			ID:                item.ID,
+			ProductID:         item.ProductID,
+			ProductName:       item.Product.Name,
+			QuantityOrdered:   item.QuantityOrdered,
+			QuantityReceived:  item.QuantityReceived,
+			RemainingQuantity: item.RemainingQuantity(),
+			UnitCost:          item.UnitCost,
+			Subtotal:          item.UnitCost * float64(item.QuantityOrdered),
+		})
+	}
+
+	return PurchaseOrderResponse{
+		ID:           po.ID,
+		PONumber:     po.PONumber,
+		SupplierID:   po.SupplierID,
+		SupplierName: po.Supplier.Name,
+		Status:       po.Status,
+		ExpectedAt:   po.ExpectedAt,
+		OrderedAt:    po.OrderedAt,
+		ReceivedAt:   po.ReceivedAt,
+		TotalAmount:  po.TotalAmount,
+		Notes:        po.Notes,
+		Items:        items,
+		CreatedBy:    po.CreatedBy,
+		CreatedAt:    po.CreatedAt,
+		UpdatedAt:    po.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package response
+
+import "time"
+
+// ReceiptResponse is everything printed on a customer receipt, already
+// resolved from the order, its transactions and the outlet configuration.
+type ReceiptResponse struct {
+	OutletName    string           `json:"outlet_name"`
+	Address       string           `json:"address,omitempty"`
+	Phone         string           `json:"phone,omitempty"`
+	Header        []string         `json:"header,omitempty"`
+	Footer        []string         `json:"footer,omitempty"`
+	OrderNumber   string           `json:"order_number"`
+	TableNumber   string           `json:"table_number,omitempty"`
+	CustomerName  string           `json:"customer_name,omitempty"`
+	IssuedAt      time.Time        `json:"issued_at"`
+	Items         []ReceiptItem    `json:"items"`
+	Subtotal      float64          `json:"subtotal"`
+	Discount      float64          `json:"discount,omitempty"`
+	TaxPercentage float64          `json:"tax_percentage"`
+	TaxAmount     float64          `json:"tax_amount"`
+	Total         float64          `json:"total"`
+	Payments      []ReceiptPayment `json:"payments"`
+	Paid          float64          `json:"paid"`
+	Refunded      float64          `json:"refunded"`
+	Change        float64          `json:"change"`
+}
+
+type ReceiptItem struct {
+	Name      string   `json:"name"`
+	Quantity  int      `json:"quantity"`
+	UnitPrice float64  `json:"unit_price"`
+	Total     float64  `json:"total"`
+	Modifiers []string `json:"modifiers,omitempty"`
+	Notes     string   `json:"notes,omitempty"`
+}
+
+type ReceiptPayment struct {
+	Method string  `json:"method"`
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
+}
+
+type ReceiptEmailResponse struct {
+	OrderNumber string `json:"order_number"`
+	Email       string `json:"email"`
+}
@@ -0,0 +1,71 @@
+package response
+
+type ReportRangeResponse struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Timezone  string `json:"timezone"`
+}
+
+type SalesSummaryResponse struct {
+	ReportRangeResponse
+	OrderCount    int64   `json:"order_count"`
+	Subtotal      float64 `json:"subtotal"`
+	TaxAmount     float64 `json:"tax_amount"`
+	Revenue       float64 `json:"revenue"`
+	AverageTicket float64 `json:"average_ticket"`
+}
+
+type RevenuePoint struct {
+	PeriodStart   string  `json:"period_start"`
+	OrderCount    int64   `json:"order_count"`
+	Revenue       float64 `json:"revenue"`
+	AverageTicket float64 `json:"average_ticket"`
+}
+
+type RevenueReportResponse struct {
+	ReportRangeResponse
+	Period string         `json:"period"`
+	Points []RevenuePoint `json:"points"`
+}
+
+type CategorySalesResponse struct {
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Quantity     int64   `json:"quantity"`
+	Revenue      float64 `json:"revenue"`
+	RevenueShare float64 `json:"revenue_share"`
+}
+
+type ProductSalesResponse struct {
+	ProductID    uint    `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	CategoryName string  `json:"category_name,omitempty"`
+	Quantity     int64   `json:"quantity"`
+	Revenue      float64 `json:"revenue"`
+}
+
+type PaymentMethodSalesResponse struct {
+	PaymentMethodID   uint    `json:"payment_method_id"`
+	PaymentMethodName string  `json:"payment_method_name"`
+	TransactionCount  int64   `json:"transaction_count"`
+	Payments          float64 `json:"payments"`
+	Refunds           float64 `json:"refunds"`
+	Net               float64 `json:"net"`
+	Share             float64 `json:"share"`
+}
+
+type PeakHourResponse struct {
+	Hour          int     `json:"hour"`
+	OrderCount    int64   `json:"order_count"`
+	Revenue       float64 `json:"revenue"`
+	AverageTicket float64 `json:"average_ticket"`
+}
+
+type DashboardResponse struct {
+	Summary        SalesSummaryResponse         `json:"summary"`
+	DailyRevenue   []RevenuePoint               `json:"daily_revenue"`
+	TopProducts    []ProductSalesResponse       `json:"top_products"`
+	Categories     []CategorySalesResponse      `json:"categories"`
+	PaymentMethods []PaymentMethodSalesResponse `json:"payment_methods"`
+	PeakHours      []PeakHourResponse           `json:"peak_hours"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsSystem    bool      `json:"is_system"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PermissionOverrideResponse struct {
+	Permission string `json:"permission"`
+	Granted    bool   `json:"granted"`
+}
+
+// UserPermissionsResponse is what a user may do: Permissions is the result
+// of their role with Overrides applied.
+type UserPermissionsResponse struct {
+	UserID      uint                         `json:"user_id"`
+	Role        string                       `json:"role"`
+	Permissions []string                     `json:"permissions"`
+	Overrides   []PermissionOverrideResponse `json:"overrides"`
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type ShiftResponse struct {
+	ID            uint      `json:"id"`
+	ProfileID     uint      `json:"profile_id"`
+	FullName      string    `json:"full_name,omitempty"`
+	WeekNumber    int       `json:"week_number"`
+	Year          int       `json:"year"`
+	ShiftStart    time.Time `json:"shift_start"`
+	ShiftEnd      time.Time `json:"shift_end"`
+	DurationHours float64   `json:"duration_hours"`
+	Notes         string    `json:"notes,omitempty"`
+}
+
+type ShiftConflictResponse struct {
+	ProfileID  uint      `json:"profile_id"`
+	ShiftStart time.Time `json:"shift_start"`
+	ShiftEnd   time.Time `json:"shift_end"`
+	Reason     string    `json:"reason"`
+}
+
+type BulkShiftResponse struct {
+	Created []ShiftResponse         `json:"created"`
+	Skipped []ShiftConflictResponse `json:"skipped"`
+}
+
+// Converters
+func ShiftToResponse(shift *entity.Shift) ShiftResponse {
+	return ShiftResponse{
+		ID:            shift.ID,
+		ProfileID:     shift.ProfileID,
+		FullName:      shift.Profile.FullName,
+		WeekNumber:    shift.WeekNumber,
+		Year:          shift.Year,
+		ShiftStart:    shift.ShiftStart,
+		ShiftEnd:      shift.ShiftEnd,
+		DurationHours: shift.ShiftEnd.Sub(shift.ShiftStart).Hours(),
+		Notes:         shift.Notes,
+	}
+}
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type StockTakeItemResponse struct {
+	ProductID        uint    `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	ExpectedQuantity int     `json:"expected_quantity"`
+	CountedQuantity  int     `json:"counted_quantity"`
+	Variance         int     `json:"variance"`
+	UnitCost         float64 `json:"unit_cost"`
+	VarianceValue    float64 `json:"variance_value"`
+	Notes            string  `json:"notes,omitempty"`
+}
+
+type StockTakeResponse struct {
+	ID                 uint                    `json:"id"`
+	Reference          string                  `json:"reference"`
+	Status             entity.StockTakeStatus  `json:"status"`
+	Notes              string                  `json:"notes,omitempty"`
+	CreatedBy          uint                    `json:"created_by"`
+	CommittedBy        *uint                   `json:"committed_by,omitempty"`
+	CommittedAt        *time.Time              `json:"committed_at,omitempty"`
+	ItemsCounted       int                     `json:"items_counted"`
+	ItemsWithVariance  int                     `json:"items_with_variance"`
+	TotalVarianceValue float64                 `json:"total_variance_value"`
+	Items              []StockTakeItemResponse `json:"items,omitempty"`
+	CreatedAt          time.Time               `json:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+// Converters
+
+// StockTakeToResponse builds the variance review. Open sessions compare the
+// counts against the live product stock, committed ones use the frozen values.
+func StockTakeToResponse(stockTake *entity.StockTake) StockTakeResponse {
+	resp := StockTakeResponse{
+		ID:          stockTake.ID,
+		Reference:   stockTake.Reference,
+		Status:      stockTake.Status,
+		Notes:       stockTake.Notes,
+		CreatedBy:   stockTake.CreatedBy,
+		CommittedBy: stockTake.CommittedBy,
+		CommittedAt: stockTake.CommittedAt,
+		CreatedAt:   stockTake.CreatedAt,
+		UpdatedAt:   stockTake.UpdatedAt,
+		Items:       make([]StockTakeItemResponse, 0, len(stockTake.Items)),
+	}
+
+	for _, item := range stockTake.Items {
+		expected, variance := item.ExpectedQuantity, item.Variance
+		if stockTake.Status == entity.StockTakeStatusOpen {
+			expected = item.Product.Stock
+			variance = item.CountedQuantity - expected
+		}
+
+		line := StockTakeItemResponse{
+			ProductID:        item.ProductID,
+			ProductName:      item.Product.Name,
+			ExpectedQuantity: expected,
+			CountedQuantity:  item.CountedQuantity,
+			Variance:         variance,
+			UnitCost:         item.Product.CostPrice,
+			VarianceValue:    float64(variance) * item.Product.CostPrice,
+			Notes:            item.Notes,
+		}
+
+		resp.ItemsCounted++
+		if variance != 0 {
+			resp.ItemsWithVariance++
+		}
+		resp.TotalVarianceValue += line.VarianceValue
+		resp.Items = append(resp.Items, line)
+	}
+
+	return resp
+}
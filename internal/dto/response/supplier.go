@@ -0,0 +1,35 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type SupplierResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContactName string    `json:"contact_name,omitempty"`
+	Phone       string    `json:"phone,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	Address     string    `json:"address,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Converters
+func SupplierToResponse(supplier *entity.Supplier) SupplierResponse {
+	return SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Phone:       supplier.Phone,
+		Email:       supplier.Email,
+		Address:     supplier.Address,
+		Notes:       supplier.Notes,
+		IsActive:    supplier.IsActive,
+		CreatedAt:   supplier.CreatedAt,
+		UpdatedAt:   supplier.UpdatedAt,
+	}
+}
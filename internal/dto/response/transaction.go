@@ -0,0 +1,41 @@
+package response
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
+)
+
+type TransactionResponse struct {
+	ID                uint                     `json:"id"`
+	TransactionNumber string                   `json:"transaction_number"`
+	OrderID           uint                     `json:"order_id"`
+	OrderNumber       string                   `json:"order_number,omitempty"`
+	TransactionType   entity.TransactionType   `json:"transaction_type"`
+	PaymentMethodID   uint                     `json:"payment_method_id"`
+	PaymentMethod     string                   `json:"payment_method,omitempty"`
+	Amount            float64                  `json:"amount"`
+	Status            entity.TransactionStatus `json:"status"`
+	Notes             string                   `json:"notes,omitempty"`
+	CreatedBy         uint                     `json:"created_by"`
+	ApprovedBy        *uint                    `json:"approved_by,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+}
+
+// Converters
+func TransactionToResponse(t *entity.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:                t.ID,
+		TransactionNumber: t.TransactionNumber,
+		OrderID:           t.OrderID,
+		OrderNumber:       t.Order.OrderNumber,
+		TransactionType:   t.TransactionType,
+		PaymentMethodID:   t.PaymentMethodID,
+		PaymentMethod:     t.PaymentMethod.Name,
+		Amount:            t.Amount,
+		Status:            t.Status,
+		Notes:             t.Notes,
+		CreatedBy:         t.CreatedBy,
+		ApprovedBy:        t.ApprovedBy,
+		CreatedAt:         t.CreatedAt,
+	}
+}
@@ -0,0 +1,8 @@
+package response
+
+// UploadResponse links an uploaded image and its thumbnail. The thumbnail
+// sits next to the image with "_thumb" before the extension.
+type UploadResponse struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"project-POS-APP-golang-integer/internal/data/entity"
+	"time"
 )
 
 type UserResponse struct {
@@ -9,8 +10,27 @@ type UserResponse struct {
 	Role  entity.UserRole `json:"role"`
 }
 
+// CreateUserResponse reports the new user. InvitationSent is false when the
+// invitation email could not be sent; it can be resent.
 type CreateUserResponse struct {
-	Email string          `json:"email"`
-	Role  entity.UserRole `json:"role"`
-	Password string `json:"password"`
-}
\ No newline at end of file
+	ID                  uint            `json:"id"`
+	Email               string          `json:"email"`
+	Role                entity.UserRole `json:"role"`
+	InvitationExpiresAt time.Time       `json:"invitation_expires_at"`
+	InvitationSent      bool            `json:"invitation_sent"`
+}
+
+type InvitationResponse struct {
+	Email     string          `json:"email"`
+	Role      entity.UserRole `json:"role"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// InvitationEmail is what the invitation email offers. It is never returned
+// by the API.
+type InvitationEmail struct {
+	Email     string
+	Role      entity.UserRole
+	Link      string
+	ExpiresAt time.Time
+}
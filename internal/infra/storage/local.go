@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local keeps files in a directory on disk.
+type Local struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocal(dir, publicURL string) *Local {
+	return &Local{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Put writes the file through a temporary file so readers never see it
+// half written.
+func (l *Local) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete removes the file. A file that is already gone is not an error.
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Dir is the directory the files are kept in.
+func (l *Local) Dir() string {
+	return l.dir
+}
+
+func (l *Local) URL(key string) string {
+	return l.publicURL + "/" + key
+}
+
+func (l *Local) Key(url string) (string, bool) {
+	return publicKey(l.publicURL, url)
+}
+
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service        = "s3"
+	s3Algorithm      = "AWS4-HMAC-SHA256"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DateFormat     = "20060102"
+	s3RequestTimeout = 30 * time.Second
+)
+
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is where the bucket's files are read from, for example a
+	// CDN. It defaults to the bucket on the endpoint.
+	PublicURL string
+	// Client defaults to an http.Client with a timeout.
+	Client *http.Client
+}
+
+// S3 keeps files in a bucket of any S3-compatible service. Requests are
+// signed with AWS Signature Version 4 and address the bucket path-style.
+type S3 struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3(opts S3Options) *S3 {
+	endpoint := strings.TrimSuffix(opts.Endpoint, "/")
+	publicURL := strings.TrimSuffix(opts.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = endpoint + "/" + opts.Bucket
+	}
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: s3RequestTimeout}
+	}
+
+	return &S3{
+		endpoint:  endpoint,
+		region:    opts.Region,
+		bucket:    opts.Bucket,
+		accessKey: opts.AccessKey,
+		secretKey: opts.SecretKey,
+		publicURL: publicURL,
+		client:    client,
+		now:       time.Now,
+	}
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.do(ctx, http.MethodPut, key, data, contentType)
+}
+
+// Delete removes the object. S3 treats a missing object as deleted.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, nil, "")
+}
+
+func (s *S3) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *S3) Key(url string) (string, bool) {
+	return publicKey(s.publicURL, url)
+}
+
+func (s *S3) do(ctx context.Context, method, key string, body []byte, contentType string) error {
+	if !validKey(key) {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+
+	url := s.endpoint + "/" + s3Escape(s.bucket) + "/" + s3EscapePath(key)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, s.now().UTC())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("storage: %s %s: %s: %s", method, key, res.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 Authorization header. Every header set
+// on the request so far is signed along with the host.
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath escapes each segment of key the way Signature Version 4
+// expects, keeping the slashes.
+func s3EscapePath(key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = s3Escape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+// Package storage keeps uploaded files on the local disk or in an
+// S3-compatible bucket.
+package storage
+
+import (
+	"fmt"
+	"project-POS-APP-golang-integer/internal/usecase"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	// LocalRoute is where the local driver's files are served from.
+	LocalRoute = "/uploads"
+
+	defaultLocalDir = "uploads"
+	defaultS3Region = "us-east-1"
+)
+
+// New returns the storage the configuration asks for. baseURL is the app's
+// own address, used for links to locally stored files when no public URL
+// is configured.
+func New(cfg utils.StorageConfig, baseURL string) (usecase.FileStorage, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", DriverLocal:
+		dir := cfg.LocalDir
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		publicURL := cfg.PublicURL
+		if publicURL == "" {
+			publicURL = strings.TrimSuffix(baseURL, "/") + LocalRoute
+		}
+		return NewLocal(dir, publicURL), nil
+
+	case DriverS3:
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			return nil, fmt.Errorf("storage: S3_ENDPOINT and S3_BUCKET are required for the s3 driver")
+		}
+		region := cfg.S3Region
+		if region == "" {
+			region = defaultS3Region
+		}
+		return NewS3(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.PublicURL,
+		}), nil
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+}
+
+// publicKey returns the key a URL under base points to.
+func publicKey(base, url string) (string, bool) {
+	prefix := strings.TrimSuffix(base, "/") + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(url, prefix)
+	return key, validKey(key)
+}
+
+// validKey keeps keys inside the storage: relative, without "." or ".."
+// segments.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
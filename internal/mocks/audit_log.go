@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditLogRepoMock struct {
+	mock.Mock
+}
+
+func (m *AuditLogRepoMock) Create(ctx context.Context, log *entity.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *AuditLogRepoMock) FindAll(ctx context.Context, params repository.AuditLogParams) ([]entity.AuditLog, int64, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]entity.AuditLog), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type InventoryLogRepoMock struct {
+	mock.Mock
+}
+
+func (m *InventoryLogRepoMock) GetInventoryLogs(ctx context.Context, f repository.InventoryLogParams) ([]entity.InventoryLog, int64, error) {
+	args := m.Called(ctx, f)
+	return args.Get(0).([]entity.InventoryLog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *InventoryLogRepoMock) StreamInventoryLogs(ctx context.Context, f repository.InventoryLogParams, fn func([]entity.InventoryLog) error) error {
+	args := m.Called(ctx, f, fn)
+	return args.Error(0)
+}
+
+func (m *InventoryLogRepoMock) CreateInventoryLog(ctx context.Context, inventory *entity.InventoryLog) (*entity.InventoryLog, error) {
+	args := m.Called(ctx, inventory)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryLog), args.Error(1)
+}
+
+func (m *InventoryLogRepoMock) GetProductHistory(ctx context.Context, productID uint, start, end *time.Time) ([]entity.InventoryLog, error) {
+	args := m.Called(ctx, productID, start, end)
+	return args.Get(0).([]entity.InventoryLog), args.Error(1)
+}
+
+func (m *InventoryLogRepoMock) GetLastLogBefore(ctx context.Context, productID uint, before time.Time) (*entity.InventoryLog, error) {
+	args := m.Called(ctx, productID, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryLog), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type InvitationRepoMock struct {
+	mock.Mock
+}
+
+func (m *InvitationRepoMock) Create(ctx context.Context, invitation *entity.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *InvitationRepoMock) FindValidByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invitation), args.Error(1)
+}
+
+func (m *InvitationRepoMock) FindLatestByUser(ctx context.Context, userID uint) (*entity.Invitation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invitation), args.Error(1)
+}
+
+func (m *InvitationRepoMock) MarkAccepted(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *InvitationRepoMock) RevokeByUser(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
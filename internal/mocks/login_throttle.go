@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginThrottleRepoMock struct {
+	mock.Mock
+}
+
+func (m *LoginThrottleRepoMock) throttle(args mock.Arguments) (*entity.LoginThrottle, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LoginThrottle), args.Error(1)
+}
+
+func (m *LoginThrottleRepoMock) Find(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	return m.throttle(m.Called(ctx, key))
+}
+
+func (m *LoginThrottleRepoMock) FindForUpdate(ctx context.Context, key string) (*entity.LoginThrottle, error) {
+	return m.throttle(m.Called(ctx, key))
+}
+
+func (m *LoginThrottleRepoMock) Save(ctx context.Context, throttle *entity.LoginThrottle) error {
+	args := m.Called(ctx, throttle)
+	return args.Error(0)
+}
+
+func (m *LoginThrottleRepoMock) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProductRepoMock struct {
+	mock.Mock
+}
+
+func (m *ProductRepoMock) product(args mock.Arguments) (*entity.Product, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Product), args.Error(1)
+}
+
+func (m *ProductRepoMock) Create(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *ProductRepoMock) FindByID(id uint) (*entity.Product, error) {
+	return m.product(m.Called(id))
+}
+
+func (m *ProductRepoMock) Update(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *ProductRepoMock) SoftDelete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ProductRepoMock) CheckHasOrderItems(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ProductRepoMock) FindByNameAndCategory(name string, categoryID uint) (*entity.Product, error) {
+	return m.product(m.Called(name, categoryID))
+}
+
+func (m *ProductRepoMock) FindAllWithFilter(req request.GetProductsRequest) ([]entity.Product, int64, error) {
+	args := m.Called(req)
+	return args.Get(0).([]entity.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *ProductRepoMock) GetSoldCount(productID uint) (int64, error) {
+	args := m.Called(productID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ProductRepoMock) AdjustStock(ctx context.Context, id uint, delta int) (*entity.Product, error) {
+	return m.product(m.Called(ctx, id, delta))
+}
+
+func (m *ProductRepoMock) FindByIDForUpdate(ctx context.Context, id uint) (*entity.Product, error) {
+	return m.product(m.Called(ctx, id))
+}
+
+func (m *ProductRepoMock) UpdateCostPrice(ctx context.Context, id uint, cost float64) error {
+	args := m.Called(ctx, id, cost)
+	return args.Error(0)
+}
+
+func (m *ProductRepoMock) UpdateImageURL(ctx context.Context, id uint, url string) error {
+	args := m.Called(ctx, id, url)
+	return args.Error(0)
+}
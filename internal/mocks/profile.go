@@ -26,3 +26,16 @@ func (m *ProfileRepoMock) UpdateProfile(ctx context.Context, profile *entity.Pro
 	args := m.Called(ctx, profile)
 	return args.Error(0)
 }
+
+func (m *ProfileRepoMock) UpdateSalary(ctx context.Context, userID uint, salary float64) error {
+	args := m.Called(ctx, userID, salary)
+	return args.Error(0)
+}
+
+func (m *ProfileRepoMock) GetByProfileID(ctx context.Context, id uint) (*entity.Profile, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Profile), args.Error(1)
+}
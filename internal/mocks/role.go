@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRepoMock struct {
+	mock.Mock
+}
+
+func (m *RoleRepoMock) List(ctx context.Context) ([]entity.Role, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.Role), args.Error(1)
+}
+
+func (m *RoleRepoMock) FindByID(ctx context.Context, id uint) (*entity.Role, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entity.Role), args.Error(1)
+}
+
+func (m *RoleRepoMock) FindByName(ctx context.Context, name string) (*entity.Role, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*entity.Role), args.Error(1)
+}
+
+func (m *RoleRepoMock) Create(ctx context.Context, role *entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *RoleRepoMock) Update(ctx context.Context, role *entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *RoleRepoMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *RoleRepoMock) ReplacePermissions(ctx context.Context, role *entity.Role, permissions []entity.Permission) error {
+	args := m.Called(ctx, role, permissions)
+	return args.Error(0)
+}
+
+func (m *RoleRepoMock) CountUsers(ctx context.Context, name string) (int64, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(int64), args.Error(1)
+}
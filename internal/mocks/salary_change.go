@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SalaryChangeRepoMock struct {
+	mock.Mock
+}
+
+func (m *SalaryChangeRepoMock) Create(ctx context.Context, change *entity.SalaryChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *SalaryChangeRepoMock) FindByUser(ctx context.Context, userID uint) ([]entity.SalaryChange, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.SalaryChange), args.Error(1)
+}
+
+func (m *SalaryChangeRepoMock) FindEffective(ctx context.Context, userID uint, at time.Time) (*entity.SalaryChange, error) {
+	args := m.Called(ctx, userID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalaryChange), args.Error(1)
+}
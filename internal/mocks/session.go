@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"project-POS-APP-golang-integer/internal/data/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionRepoMock struct {
+	mock.Mock
+}
+
+func (m *SessionRepoMock) session(args mock.Arguments) (*entity.Session, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Session), args.Error(1)
+}
+
+func (m *SessionRepoMock) Create(ctx context.Context, session *entity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) Update(ctx context.Context, session *entity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) Revoke(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) FindActiveByToken(ctx context.Context, token string) (*entity.Session, error) {
+	return m.session(m.Called(ctx, token))
+}
+
+func (m *SessionRepoMock) FindByRefreshHash(ctx context.Context, hash string) (*entity.Session, error) {
+	return m.session(m.Called(ctx, hash))
+}
+
+func (m *SessionRepoMock) FindByID(ctx context.Context, id uint) (*entity.Session, error) {
+	return m.session(m.Called(ctx, id))
+}
+
+func (m *SessionRepoMock) FindActiveByUser(ctx context.Context, userID uint) ([]entity.Session, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]entity.Session), args.Error(1)
+}
+
+func (m *SessionRepoMock) Touch(ctx context.Context, id uint, expiresAt time.Time) error {
+	args := m.Called(ctx, id, expiresAt)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) RevokeByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) RevokeAllByUser(ctx context.Context, userID uint, exceptID uint) (int64, error) {
+	args := m.Called(ctx, userID, exceptID)
+	return args.Get(0).(int64), args.Error(1)
+}
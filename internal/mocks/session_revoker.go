@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionRevokerMock struct {
+	mock.Mock
+}
+
+func (m *SessionRevokerMock) RevokeUserSessions(ctx context.Context, userID uint) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
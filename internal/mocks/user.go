@@ -41,4 +41,19 @@ func (m *UserRepoMock) UpdateUser(ctx context.Context, id uint, user *entity.Use
 func (m *UserRepoMock) DeleteUser(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+func (m *UserRepoMock) CountByRole(ctx context.Context, role entity.UserRole) (int64, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *UserRepoMock) FindDeletedByID(ctx context.Context, id uint) (entity.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.User), args.Error(1)
+}
+
+func (m *UserRepoMock) RestoreUser(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,454 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// earlyClockInWindow is how long before a shift a clock-in still links to it.
+	earlyClockInWindow = 2 * time.Hour
+
+	defaultMonthlyWorkHours = 173
+)
+
+type AttendanceService interface {
+	ClockIn(ctx context.Context, req request.ClockRequest) (*response.AttendanceResponse, error)
+	ClockOut(ctx context.Context, req request.ClockRequest) (*response.AttendanceResponse, error)
+	GetMyAttendance(ctx context.Context, req request.GetAttendanceRequest) ([]response.AttendanceResponse, response.PaginationMeta, error)
+	GetAttendance(ctx context.Context, req request.GetAttendanceRequest) ([]response.AttendanceResponse, response.PaginationMeta, error)
+	GetAttendanceByID(ctx context.Context, id uint) (*response.AttendanceResponse, error)
+	CorrectAttendance(ctx context.Context, id uint, req request.CorrectAttendanceRequest) (*response.AttendanceResponse, error)
+	GetTimesheet(ctx context.Context, req request.TimesheetRequest) (*response.TimesheetResponse, error)
+}
+
+type attendanceService struct {
+	tx     TxManager
+	repo   *repository.Repository
+	log    *zap.Logger
+	config utils.Configuration
+	loc    *time.Location
+}
+
+func NewAttendanceService(tx TxManager, repo *repository.Repository, log *zap.Logger, config utils.Configuration) AttendanceService {
+	return &attendanceService{
+		tx:     tx,
+		repo:   repo,
+		log:    log.With(zap.String("service", "attendance")),
+		config: config,
+		loc:    utils.LoadLocation(config.BusinessRules.Timezone),
+	}
+}
+
+func (s *attendanceService) ClockIn(ctx context.Context, req request.ClockRequest) (*response.AttendanceResponse, error) {
+	profile, err := s.currentProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attendance *entity.Attendance
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		open, err := s.repo.AttendanceRepo.FindOpenByProfile(ctx, profile.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if open != nil {
+			return utils.ErrAlreadyClockedIn
+		}
+
+		now := time.Now().UTC()
+		attendance = &entity.Attendance{
+			ProfileID: profile.ID,
+			ClockIn:   now,
+			Notes:     req.Notes,
+		}
+
+		// Link to the shift running now or starting soon
+		shifts, err := s.repo.ShiftRepo.FindOverlapping(ctx, profile.ID, now, now.Add(earlyClockInWindow), 0)
+		if err != nil {
+			return err
+		}
+		if len(shifts) > 0 {
+			sort.Slice(shifts, func(i, j int) bool { return shifts[i].ShiftStart.Before(shifts[j].ShiftStart) })
+			attendance.ShiftID = &shifts[0].ID
+			attendance.Shift = &shifts[0]
+		}
+
+		computeAttendance(attendance, s.lateGrace())
+
+		attendance, err = s.repo.AttendanceRepo.Create(ctx, attendance)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			// A concurrent clock-in won the race for the open punch
+			return utils.ErrAlreadyClockedIn
+		}
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityAttendance, attendance.ID, nil, attendance)
+	})
+	if err != nil {
+		s.log.Error("Failed to clock in", zap.Uint("profile_id", profile.ID), zap.Error(err))
+		return nil, err
+	}
+	attendance.Profile = *profile
+
+	s.log.Info("Clocked in",
+		zap.Uint("profile_id", profile.ID),
+		zap.Uint("attendance_id", attendance.ID),
+		zap.Int("late_minutes", attendance.LateMinutes))
+
+	resp := response.AttendanceToResponse(attendance)
+	return &resp, nil
+}
+
+func (s *attendanceService) ClockOut(ctx context.Context, req request.ClockRequest) (*response.AttendanceResponse, error) {
+	profile, err := s.currentProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var attendance *entity.Attendance
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		attendance, err = s.repo.AttendanceRepo.FindOpenByProfile(ctx, profile.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrNotClockedIn
+			}
+			return err
+		}
+
+		before := *attendance
+		now := time.Now().UTC()
+		attendance.ClockOut = &now
+		if req.Notes != "" {
+			attendance.Notes = req.Notes
+		}
+		computeAttendance(attendance, s.lateGrace())
+
+		if err := s.repo.AttendanceRepo.Update(ctx, attendance); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityAttendance, attendance.ID, before, attendance)
+	})
+	if err != nil {
+		s.log.Error("Failed to clock out", zap.Uint("profile_id", profile.ID), zap.Error(err))
+		return nil, err
+	}
+	attendance.Profile = *profile
+
+	s.log.Info("Clocked out",
+		zap.Uint("profile_id", profile.ID),
+		zap.Uint("attendance_id", attendance.ID),
+		zap.Int("worked_minutes", attendance.WorkedMinutes),
+		zap.Int("overtime_minutes", attendance.OvertimeMinutes))
+
+	resp := response.AttendanceToResponse(attendance)
+	return &resp, nil
+}
+
+func (s *attendanceService) GetMyAttendance(ctx context.Context, req request.GetAttendanceRequest) ([]response.AttendanceResponse, response.PaginationMeta, error) {
+	profile, err := s.currentProfile(ctx)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	req.ProfileID = profile.ID
+	return s.GetAttendance(ctx, req)
+}
+
+func (s *attendanceService) GetAttendance(ctx context.Context, req request.GetAttendanceRequest) ([]response.AttendanceResponse, response.PaginationMeta, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	attendances, total, err := s.repo.AttendanceRepo.FindAll(ctx, repository.AttendanceParams{
+		Offset:    req.GetOffset(),
+		Limit:     req.GetPerPage(),
+		ProfileID: req.ProfileID,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		s.log.Error("Failed to get attendance", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.AttendanceResponse, 0, len(attendances))
+	for _, a := range attendances {
+		result = append(result, response.AttendanceToResponse(&a))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *attendanceService) GetAttendanceByID(ctx context.Context, id uint) (*response.AttendanceResponse, error) {
+	attendance, err := s.findAttendance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.AttendanceToResponse(attendance)
+	return &resp, nil
+}
+
+func (s *attendanceService) CorrectAttendance(ctx context.Context, id uint, req request.CorrectAttendanceRequest) (*response.AttendanceResponse, error) {
+	managerID := ctx.Value("user_id").(uint)
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		attendance, err := s.findAttendance(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *attendance
+		correction := &entity.AttendanceCorrection{
+			AttendanceID:     attendance.ID,
+			CorrectedBy:      managerID,
+			PreviousClockIn:  attendance.ClockIn,
+			PreviousClockOut: attendance.ClockOut,
+			Note:             req.Note,
+		}
+
+		if req.ClockIn != "" {
+			t, err := time.Parse(time.RFC3339, req.ClockIn)
+			if err != nil {
+				return utils.ErrInvalidDateFormat
+			}
+			attendance.ClockIn = t.UTC()
+		}
+		if req.ClockOut != "" {
+			t, err := time.Parse(time.RFC3339, req.ClockOut)
+			if err != nil {
+				return utils.ErrInvalidDateFormat
+			}
+			t = t.UTC()
+			attendance.ClockOut = &t
+		}
+		if attendance.ClockOut != nil && !attendance.ClockOut.After(attendance.ClockIn) {
+			return utils.ErrInvalidClockOut
+		}
+
+		if req.ShiftID != nil {
+			if *req.ShiftID == 0 {
+				attendance.ShiftID = nil
+				attendance.Shift = nil
+			} else {
+				shift, err := s.repo.ShiftRepo.FindByID(ctx, *req.ShiftID)
+				if err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return utils.ErrShiftNotFound
+					}
+					return err
+				}
+				if shift.ProfileID != attendance.ProfileID {
+					return utils.ErrShiftNotFound
+				}
+				attendance.ShiftID = &shift.ID
+				attendance.Shift = shift
+			}
+		}
+
+		computeAttendance(attendance, s.lateGrace())
+
+		if err := s.repo.AttendanceRepo.Update(ctx, attendance); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityAttendance, id, before, attendance); err != nil {
+			return err
+		}
+
+		correction.NewClockIn = attendance.ClockIn
+		correction.NewClockOut = attendance.ClockOut
+		return s.repo.AttendanceRepo.CreateCorrection(ctx, correction)
+	})
+	if err != nil {
+		s.log.Error("Failed to correct attendance", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Attendance corrected",
+		zap.Uint("id", id),
+		zap.Uint("corrected_by", managerID),
+		zap.String("note", req.Note))
+
+	return s.GetAttendanceByID(ctx, id)
+}
+
+func (s *attendanceService) GetTimesheet(ctx context.Context, req request.TimesheetRequest) (*response.TimesheetResponse, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := s.repo.AttendanceRepo.FindInPeriod(ctx, *start, *end)
+	if err != nil {
+		s.log.Error("Failed to get timesheet", zap.Error(err))
+		return nil, err
+	}
+
+	rules := s.config.BusinessRules
+	monthlyHours := rules.MonthlyWorkHours
+	if monthlyHours <= 0 {
+		monthlyHours = defaultMonthlyWorkHours
+	}
+	multiplier := rules.OvertimeMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	entries := buildTimesheet(attendances, monthlyHours, multiplier, s.loc)
+
+	result := &response.TimesheetResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Entries:   entries,
+	}
+	for _, e := range entries {
+		result.TotalHours += e.WorkedHours
+		result.TotalLaborCost += e.LaborCost
+	}
+	result.TotalHours = roundTo(result.TotalHours, 2)
+	result.TotalLaborCost = roundTo(result.TotalLaborCost, 2)
+
+	return result, nil
+}
+
+func (s *attendanceService) currentProfile(ctx context.Context) (*entity.Profile, error) {
+	userID := ctx.Value("user_id").(uint)
+
+	user, err := s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to get profile", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	if user.Profile == nil || user.Profile.ID == 0 {
+		return nil, utils.ErrProfileNotFound
+	}
+
+	return user.Profile, nil
+}
+
+func (s *attendanceService) findAttendance(ctx context.Context, id uint) (*entity.Attendance, error) {
+	attendance, err := s.repo.AttendanceRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrAttendanceNotFound
+		}
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (s *attendanceService) lateGrace() time.Duration {
+	return time.Duration(s.config.BusinessRules.LateGraceMinutes) * time.Minute
+}
+
+// computeAttendance fills the minute fields of a punch. Lateness is measured
+// from the shift start once it exceeds the grace period; early leave and
+// overtime only apply to a closed punch.
+func computeAttendance(a *entity.Attendance, grace time.Duration) {
+	a.WorkedMinutes, a.LateMinutes, a.EarlyLeaveMinutes, a.OvertimeMinutes = 0, 0, 0, 0
+
+	if a.ClockOut != nil {
+		a.WorkedMinutes = wholeMinutes(a.ClockOut.Sub(a.ClockIn))
+	}
+	if a.Shift == nil {
+		return
+	}
+
+	if late := a.ClockIn.Sub(a.Shift.ShiftStart); late > grace {
+		a.LateMinutes = wholeMinutes(late)
+	}
+	if a.ClockOut == nil {
+		return
+	}
+	if early := a.Shift.ShiftEnd.Sub(*a.ClockOut); early > 0 {
+		a.EarlyLeaveMinutes = wholeMinutes(early)
+	}
+	if over := a.ClockOut.Sub(a.Shift.ShiftEnd); over > 0 {
+		a.OvertimeMinutes = wholeMinutes(over)
+	}
+}
+
+// buildTimesheet totals closed punches per profile. The hourly rate is the
+// monthly salary spread over monthlyHours; overtime is paid at multiplier.
+func buildTimesheet(attendances []entity.Attendance, monthlyHours int, multiplier float64, loc *time.Location) []response.TimesheetEntry {
+	entries := make(map[uint]*response.TimesheetEntry)
+	days := make(map[uint]map[string]bool)
+	var order []uint
+
+	for _, a := range attendances {
+		entry, ok := entries[a.ProfileID]
+		if !ok {
+			rate := 0.0
+			if monthlyHours > 0 {
+				rate = a.Profile.Salary / float64(monthlyHours)
+			}
+			entry = &response.TimesheetEntry{
+				ProfileID:     a.ProfileID,
+				FullName:      a.Profile.FullName,
+				MonthlySalary: a.Profile.Salary,
+				HourlyRate:    roundTo(rate, 2),
+			}
+			entries[a.ProfileID] = entry
+			days[a.ProfileID] = make(map[string]bool)
+			order = append(order, a.ProfileID)
+		}
+
+		if a.IsOpen() {
+			entry.OpenPunches++
+			continue
+		}
+
+		days[a.ProfileID][a.ClockIn.In(loc).Format("2006-01-02")] = true
+		entry.WorkedHours += float64(a.WorkedMinutes) / 60
+		entry.OvertimeHours += float64(a.OvertimeMinutes) / 60
+		entry.LateMinutes += a.LateMinutes
+		if a.LateMinutes > 0 {
+			entry.LateCount++
+		}
+		if a.EarlyLeaveMinutes > 0 {
+			entry.EarlyLeaveCount++
+		}
+	}
+
+	result := make([]response.TimesheetEntry, 0, len(order))
+	for _, id := range order {
+		entry := entries[id]
+		entry.DaysWorked = len(days[id])
+
+		rate := 0.0
+		if monthlyHours > 0 {
+			rate = entry.MonthlySalary / float64(monthlyHours)
+		}
+		regular := entry.WorkedHours - entry.OvertimeHours
+		entry.LaborCost = roundTo(regular*rate+entry.OvertimeHours*rate*multiplier, 2)
+		entry.WorkedHours = roundTo(entry.WorkedHours, 2)
+		entry.OvertimeHours = roundTo(entry.OvertimeHours, 2)
+
+		result = append(result, *entry)
+	}
+
+	return result
+}
+
+func wholeMinutes(d time.Duration) int {
+	return int(d / time.Minute)
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func attendanceShift() *entity.Shift {
+	return &entity.Shift{
+		ID:         1,
+		ShiftStart: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		ShiftEnd:   time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestComputeAttendance_LateAndOvertime(t *testing.T) {
+	shift := attendanceShift()
+	out := shift.ShiftEnd.Add(45 * time.Minute)
+	a := &entity.Attendance{
+		Shift:    shift,
+		ClockIn:  shift.ShiftStart.Add(12 * time.Minute),
+		ClockOut: &out,
+	}
+
+	computeAttendance(a, 5*time.Minute)
+
+	require.Equal(t, 12, a.LateMinutes)
+	require.Equal(t, 0, a.EarlyLeaveMinutes)
+	require.Equal(t, 45, a.OvertimeMinutes)
+	require.Equal(t, 8*60+33, a.WorkedMinutes)
+}
+
+func TestComputeAttendance_WithinGraceAndEarlyLeave(t *testing.T) {
+	shift := attendanceShift()
+	out := shift.ShiftEnd.Add(-30 * time.Minute)
+	a := &entity.Attendance{
+		Shift:    shift,
+		ClockIn:  shift.ShiftStart.Add(3 * time.Minute),
+		ClockOut: &out,
+	}
+
+	computeAttendance(a, 5*time.Minute)
+
+	require.Equal(t, 0, a.LateMinutes)
+	require.Equal(t, 30, a.EarlyLeaveMinutes)
+	require.Equal(t, 0, a.OvertimeMinutes)
+}
+
+func TestBuildTimesheet_LaborCost(t *testing.T) {
+	profile := entity.Profile{FullName: "Rina", Salary: 1730000}
+	profile.ID = 7
+
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	out1 := day.Add(8 * time.Hour)
+	out2 := day.AddDate(0, 0, 1).Add(10 * time.Hour)
+
+	attendances := []entity.Attendance{
+		{ProfileID: 7, Profile: profile, ClockIn: day, ClockOut: &out1, WorkedMinutes: 480},
+		{ProfileID: 7, Profile: profile, ClockIn: day.AddDate(0, 0, 1), ClockOut: &out2, WorkedMinutes: 600, OvertimeMinutes: 120, LateMinutes: 10},
+		{ProfileID: 7, Profile: profile, ClockIn: day.AddDate(0, 0, 2)},
+	}
+
+	entries := buildTimesheet(attendances, 173, 1.5, time.UTC)
+
+	require.Len(t, entries, 1)
+	e := entries[0]
+	require.Equal(t, 2, e.DaysWorked)
+	require.Equal(t, 18.0, e.WorkedHours)
+	require.Equal(t, 2.0, e.OvertimeHours)
+	require.Equal(t, 1, e.LateCount)
+	require.Equal(t, 1, e.OpenPunches)
+	require.Equal(t, 10000.0, e.HourlyRate)
+	// 16 regular hours plus 2 overtime hours at 1.5x
+	require.Equal(t, 190000.0, e.LaborCost)
+}
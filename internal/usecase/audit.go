@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"reflect"
+
+	"go.uber.org/zap"
+)
+
+// Entity types recorded in the audit trail.
+const (
+	auditEntityUser            = "user"
+	auditEntityUserPermissions = "user_permissions"
+	auditEntityProfile         = "profile"
+	auditEntitySalaryChange    = "salary_change"
+	auditEntityRole            = "role"
+	auditEntityCategory        = "category"
+	auditEntityProduct         = "product"
+	auditEntityModifierGroup   = "modifier_group"
+	auditEntityModifierOption  = "modifier_option"
+	auditEntityProductRecipe   = "product_recipe"
+	auditEntityModifierRecipe  = "modifier_recipe"
+	auditEntityInventoryLog    = "inventory_log"
+	auditEntityIngredient      = "ingredient"
+	auditEntityIngredientLog   = "ingredient_log"
+	auditEntitySupplier        = "supplier"
+	auditEntityPurchaseOrder   = "purchase_order"
+	auditEntityStockTake       = "stock_take"
+	auditEntityReservation     = "reservation"
+	auditEntityOrder           = "order"
+	auditEntityOrderItem       = "order_item"
+	auditEntityTransaction     = "transaction"
+	auditEntityShift           = "shift"
+	auditEntityAttendance      = "attendance"
+	auditEntityCashDrawer      = "cash_drawer"
+	auditEntityTwoFactor       = "two_factor"
+	auditEntityLoginLockout    = "login_lockout"
+	auditEntitySession         = "session"
+	auditEntityInvitation      = "invitation"
+	auditEntityStockTakeItem   = "stock_take_item"
+)
+
+// auditIgnoredFields change on every write and would only add noise.
+var auditIgnoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"CreatedAt":  true,
+	"UpdatedAt":  true,
+}
+
+type AuditService interface {
+	GetAuditLogs(ctx context.Context, req request.GetAuditLogsRequest) ([]response.AuditLogResponse, response.PaginationMeta, error)
+}
+
+type auditService struct {
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewAuditService(repo *repository.Repository, log *zap.Logger) AuditService {
+	return &auditService{
+		repo: repo,
+		log:  log.With(zap.String("service", "audit")),
+	}
+}
+
+func (s *auditService) GetAuditLogs(ctx context.Context, req request.GetAuditLogsRequest) ([]response.AuditLogResponse, response.PaginationMeta, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	logs, total, err := s.repo.AuditLogRepo.FindAll(ctx, repository.AuditLogParams{
+		Offset:     req.GetOffset(),
+		Limit:      req.GetPerPage(),
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		StartDate:  start,
+		EndDate:    end,
+	})
+	if err != nil {
+		s.log.Error("Failed to get audit logs", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, response.AuditLogToResponse(&l))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+// recordAudit writes an audit entry for a change to one entity, attributed
+// to the user, address and request behind ctx. Inside WithinTx it is written
+// in the same transaction, so it is kept exactly when the change is.
+//
+// before and after are marshalled as JSON and only the fields that differ are
+// kept. Nested objects are left out; pass a flat snapshot where a relation
+// is part of the change.
+func recordAudit(ctx context.Context, audits repository.AuditLogRepository, action entity.AuditAction, entityType string, entityID uint, before, after any) error {
+	beforeJSON, afterJSON, err := auditDiff(before, after)
+	if err != nil {
+		return err
+	}
+	if action == entity.AuditActionUpdate && beforeJSON == "" && afterJSON == "" {
+		return nil
+	}
+
+	entry := &entity.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+	}
+	if actorID, ok := ctx.Value("user_id").(uint); ok {
+		entry.ActorID = &actorID
+	}
+	entry.IPAddress, _ = ctx.Value("client_ip").(string)
+	entry.RequestID, _ = ctx.Value("request_id").(string)
+
+	return audits.Create(ctx, entry)
+}
+
+// auditSecretChange stands in for a password or PIN, so the trail shows that
+// field changed without ever holding its hash.
+func auditSecretChange(field string) (before, after map[string]string) {
+	return map[string]string{field: "previous"}, map[string]string{field: "changed"}
+}
+
+// auditSession is the part of a session worth keeping in the trail; the
+// tokens stay out of it.
+func auditSession(session *entity.Session) map[string]any {
+	return map[string]any{
+		"user_id":     session.UserID,
+		"device_name": session.DeviceName,
+		"ip_address":  session.IPAddress,
+		"revoked":     session.RevokedAt != nil,
+	}
+}
+
+// auditDiff returns the JSON of the fields that differ between before and
+// after. Either may be nil for a create or a delete.
+func auditDiff(before, after any) (string, string, error) {
+	b, err := auditFields(before)
+	if err != nil {
+		return "", "", err
+	}
+	a, err := auditFields(after)
+	if err != nil {
+		return "", "", err
+	}
+
+	if b != nil && a != nil {
+		for k, v := range b {
+			if av, ok := a[k]; ok && reflect.DeepEqual(v, av) {
+				delete(b, k)
+				delete(a, k)
+			}
+		}
+	}
+
+	beforeJSON, err := encodeAuditFields(b)
+	if err != nil {
+		return "", "", err
+	}
+	afterJSON, err := encodeAuditFields(a)
+	if err != nil {
+		return "", "", err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+// auditFields flattens v into its top-level JSON fields without relations.
+func auditFields(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	for k, val := range fields {
+		if auditIgnoredFields[k] || isAuditRelation(val) {
+			delete(fields, k)
+		}
+	}
+	return fields, nil
+}
+
+func isAuditRelation(v any) bool {
+	switch val := v.(type) {
+	case map[string]any:
+		return true
+	case []any:
+		for _, item := range val {
+			if _, ok := item.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func encodeAuditFields(fields map[string]any) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
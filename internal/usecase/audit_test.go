@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditDiff_KeepsChangedFieldsOnly(t *testing.T) {
+	before := entity.Supplier{Name: "Old", Phone: "0811", IsActive: true}
+	after := before
+	after.Name = "New"
+	after.IsActive = false
+
+	b, a, err := auditDiff(before, after)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Old","is_active":true}`, b)
+	require.JSONEq(t, `{"name":"New","is_active":false}`, a)
+}
+
+func TestAuditDiff_CreateAndDelete(t *testing.T) {
+	supplier := entity.Supplier{Name: "Acme"}
+
+	b, a, err := auditDiff(nil, supplier)
+	require.NoError(t, err)
+	require.Empty(t, b)
+	require.Contains(t, a, `"name":"Acme"`)
+
+	b, a, err = auditDiff(supplier, nil)
+	require.NoError(t, err)
+	require.Contains(t, b, `"name":"Acme"`)
+	require.Empty(t, a)
+}
+
+func TestAuditFields_DropsRelationsAndTimestamps(t *testing.T) {
+	fields, err := auditFields(map[string]any{
+		"name":       "Latte",
+		"tags":       []string{"hot"},
+		"category":   map[string]any{"id": 1},
+		"items":      []map[string]any{{"id": 1}},
+		"created_at": "2026-01-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"name": "Latte", "tags": []any{"hot"}}, fields)
+}
+
+func TestRecordAudit_SkipsUnchangedUpdate(t *testing.T) {
+	audits := new(mocks.AuditLogRepoMock)
+	supplier := entity.Supplier{Name: "Acme"}
+
+	err := recordAudit(context.Background(), audits, entity.AuditActionUpdate, auditEntitySupplier, 1, supplier, supplier)
+	require.NoError(t, err)
+	audits.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecordAudit_AttributesRequest(t *testing.T) {
+	audits := new(mocks.AuditLogRepoMock)
+	ctx := context.WithValue(context.Background(), "user_id", uint(7))
+	ctx = context.WithValue(ctx, "client_ip", "10.0.0.1")
+	ctx = context.WithValue(ctx, "request_id", "req-1")
+
+	audits.On("Create", ctx, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return *l.ActorID == 7 && l.IPAddress == "10.0.0.1" && l.RequestID == "req-1" &&
+			l.Action == entity.AuditActionDelete && l.EntityType == auditEntitySupplier && l.EntityID == 3 &&
+			l.Before != "" && l.After == ""
+	})).Return(nil)
+
+	err := recordAudit(ctx, audits, entity.AuditActionDelete, auditEntitySupplier, 3, entity.Supplier{Name: "Acme"}, nil)
+	require.NoError(t, err)
+	audits.AssertExpectations(t)
+}
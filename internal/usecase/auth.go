@@ -2,15 +2,19 @@ package usecase
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"net/url"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/dto/response"
 	"project-POS-APP-golang-integer/pkg/utils"
 	content "project-POS-APP-golang-integer/pkg/utils/email"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -18,207 +22,844 @@ import (
 type AuthService interface {
 	Login(ctx context.Context, data request.LoginRequest) (*response.AuthResponse, error)
 	ValidateToken(ctx context.Context, token string) (*uint, error)
+	Authenticate(ctx context.Context, token string) (*response.AuthPrincipal, error)
 	Logout(ctx context.Context, token string) error
-	RequestResetPassword(ctx context.Context, email string) (*response.OTPResponse, error)
+	RequestResetPassword(ctx context.Context, req request.ResetPasswordRequest) error
 	ValidateOTP(ctx context.Context, req request.ValidateOTP) (*response.ResetTokenResponse, error)
 	ResetPassword(ctx context.Context, req request.ResetPassword) error
+	Refresh(ctx context.Context, req request.RefreshTokenRequest) (*response.AuthResponse, error)
+	ListSessions(ctx context.Context) ([]response.SessionResponse, error)
+	RevokeSession(ctx context.Context, sessionID uint) error
+	RevokeOtherSessions(ctx context.Context) (*response.RevokedSessionsResponse, error)
+	ForceLogout(ctx context.Context, userID uint) (*response.RevokedSessionsResponse, error)
+	RevokeUserSessions(ctx context.Context, userID uint) (int64, error)
+	UnlockAccount(ctx context.Context, userID uint) error
+	SetManagerPIN(ctx context.Context, req request.SetManagerPINRequest) error
+	VerifyLogin(ctx context.Context, req request.VerifyLoginRequest) (*response.AuthResponse, error)
+	SendLoginCode(ctx context.Context, req request.LoginCodeRequest) error
+	GetTwoFactorStatus(ctx context.Context) (*response.TwoFactorStatusResponse, error)
+	SetupTwoFactor(ctx context.Context, req request.SetupTwoFactorRequest) (*response.TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, req request.EnableTwoFactorRequest) (*response.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, req request.TwoFactorConfirmRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, req request.TwoFactorConfirmRequest) (*response.RecoveryCodesResponse, error)
 }
 
+const (
+	AuthModeSession = "session"
+	AuthModeJWT     = "jwt"
+
+	defaultSessionIdle    = time.Hour
+	defaultSessionRefresh = 30 * 24 * time.Hour
+	defaultJWTAccessTTL   = 15 * time.Minute
+
+	// sessionTouchInterval limits how often activity is written back, so
+	// a busy client does not update its session row on every request.
+	sessionTouchInterval = time.Minute
+
+	otpTTL        = 10 * time.Minute
+	resetLinkTTL  = 30 * time.Minute
+	resetTokenTTL = 5 * time.Minute
+)
+
 type authService struct {
 	tx TxManager
 	repo *repository.Repository
 	log *zap.Logger
 	email EmailSender
+	config utils.Configuration
+	jwtKeys utils.JWTKeySet
+	denylist TokenDenylist
 }
 
-func NewAuthService(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender) AuthService {
-	return &authService{
+func NewAuthService(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender, config utils.Configuration) AuthService {
+	s := &authService{
 		tx: tx,
 		repo: repo,
 		log: log,
 		email: email,
+		config: config,
+		jwtKeys: utils.ParseJWTKeySet(config.Session.JWTKeys, config.Session.JWTActiveKey),
+		denylist: NewTokenDenylist(repo.TokenRevocationRepo, log),
 	}
+	if s.jwtMode() {
+		if err := s.jwtKeys.CheckActiveKey(); err != nil {
+			log.Fatal("AUTH_MODE is jwt but JWT_ACTIVE_KEY is unusable", zap.String("kid", s.jwtKeys.ActiveKID), zap.Error(err))
+		}
+	}
+	return s
 }
 
 func (s *authService) Login(ctx context.Context, data request.LoginRequest) (*response.AuthResponse, error) {
+	now := time.Now()
+	accountPolicy, ipPolicy := loginPolicies(s.config.LoginProtection)
+	ipKey := loginIPKey(data.IPAddress)
+
+	// Count the attempt against the client address before touching the
+	// account; a right password gives it back
+	if _, err := s.reserveLoginAttempt(ctx, ipKey, ipPolicy, now, utils.ErrTooManyLoginAttempts); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
 	user, err := s.repo.UserRepo.FindUserByEmail(ctx, data.Email)
-	if err == gorm.ErrRecordNotFound {
-		s.log.Error("User not found: ", zap.Error(err))
-		return nil, err
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Warn("Login for unknown email", zap.String("ip", data.IPAddress))
+		return nil, utils.ErrInvalidCredentials
 	}
 
 	if err != nil {
 		s.log.Error("Error find user by email: ", zap.Error(err))
+		s.releaseLoginAttempt(ctx, ipKey, ipPolicy)
+		return nil, err
+	}
+
+	accountKey := loginAccountKey(user.ID)
+	lockedUntil, err := s.reserveLoginAttempt(ctx, accountKey, accountPolicy, now, utils.ErrAccountLocked)
+	if err != nil {
+		s.releaseLoginAttempt(ctx, ipKey, ipPolicy)
 		return nil, err
 	}
 
 	// Check password
 	if !utils.CheckPassword(data.Password, user.PasswordHash) {
-		s.log.Error("Incorrect password: ", zap.Error(err))
-		return nil, errors.New("incorrect password")
+		s.log.Warn("Incorrect password", zap.Uint("user_id", user.ID), zap.String("ip", data.IPAddress))
+		if lockedUntil != nil {
+			s.notifyLockout(ctx, user, *lockedUntil, data.IPAddress)
+			return nil, utils.ErrAccountLocked
+		}
+		return nil, utils.ErrInvalidCredentials
 	}
+	s.releaseLoginAttempt(ctx, ipKey, ipPolicy)
 
-	// Record session
-	token, err := s.repo.SessionRepo.Create(ctx, user.ID)
+	// Roles that require it, and users who enrolled, finish with a second
+	// factor; the account is only forgiven once that succeeds too
+	enrolled, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		s.releaseLoginAttempt(ctx, accountKey, accountPolicy)
+		return nil, err
+	}
+	if enrolled || s.twoFactorRequired(user.Role) {
+		s.releaseLoginAttempt(ctx, accountKey, accountPolicy)
+		return s.startLoginChallenge(ctx, user, data, enrolled)
+	}
+
+	s.clearLoginFailures(ctx, user.ID)
+	return s.openSession(ctx, user, data.DeviceName, data.IPAddress, data.UserAgent)
+}
+
+// clearLoginFailures forgives the account after a complete login, not the
+// address, so one known password cannot be used to keep guessing others.
+func (s *authService) clearLoginFailures(ctx context.Context, userID uint) {
+	if err := s.repo.LoginThrottleRepo.Delete(ctx, loginAccountKey(userID)); err != nil {
+		s.log.Warn("Error clear failed logins", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+// openSession records a new session for user and returns its tokens.
+func (s *authService) openSession(ctx context.Context, user *entity.User, deviceName, ipAddress, userAgent string) (*response.AuthResponse, error) {
+	session := entity.Session{
+		UserID:     user.ID,
+		DeviceName: deviceName,
+		IPAddress:  ipAddress,
+		UserAgent:  truncateUserAgent(userAgent),
+		CreatedAt:  time.Now(),
+	}
+	refreshToken, err := issueSessionTokens(&session, time.Now(), s.idleTimeout(), s.refreshTTL())
 	if err != nil {
 		s.log.Error("Error create token: ", zap.Error(err))
 		return nil, errors.New("token error")
 	}
-	
-	res := response.AuthResponse{
-		Token: token,
+
+	err = s.repo.SessionRepo.Create(ctx, &session)
+	if err != nil {
+		s.log.Error("Error create session: ", zap.Error(err))
+		return nil, errors.New("token error")
 	}
 
-	return &res, nil
+	res, err := s.authResponse(&session, user.Role, refreshToken)
+	if err != nil {
+		s.log.Error("Error sign access token: ", zap.Error(err))
+		return nil, errors.New("token error")
+	}
+
+	return res, nil
 }
 
 func (s *authService) ValidateToken(ctx context.Context, token string) (*uint, error) {
 	// Validate token to authorize user
-	userID, err := s.repo.SessionRepo.ValidateToken(ctx, token)
+	session, err := s.repo.SessionRepo.FindActiveByToken(ctx, token)
 	if err != nil {
 		s.log.Error("Error validate token service: ", zap.Error(err))
 		return nil, err
 	}
 
-	return userID, nil
+	// Slide the expiry forward on activity
+	if expiresAt, ok := slideSessionExpiry(session, time.Now(), s.idleTimeout()); ok {
+		if err := s.repo.SessionRepo.Touch(ctx, session.ID, expiresAt); err != nil {
+			s.log.Warn("Error extend session", zap.Uint("session_id", session.ID), zap.Error(err))
+		}
+	}
+
+	return &session.UserID, nil
+}
+
+// Authenticate resolves an access token to the user behind it. Signed tokens
+// are checked against their signature and the denylist only; opaque tokens
+// are looked up with their session and user.
+func (s *authService) Authenticate(ctx context.Context, token string) (*response.AuthPrincipal, error) {
+	if s.jwtMode() {
+		return s.authenticateJWT(ctx, token)
+	}
+
+	session, err := s.repo.SessionRepo.FindActiveByToken(ctx, token)
+	if err != nil {
+		return nil, utils.ErrInvalidToken
+	}
+	if expiresAt, ok := slideSessionExpiry(session, time.Now(), s.idleTimeout()); ok {
+		if err := s.repo.SessionRepo.Touch(ctx, session.ID, expiresAt); err != nil {
+			s.log.Warn("Error extend session", zap.Uint("session_id", session.ID), zap.Error(err))
+		}
+	}
+
+	user, err := s.repo.UserRepo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, utils.ErrUserNotFound
+	}
+
+	return &response.AuthPrincipal{UserID: user.ID, Role: string(user.Role), SessionID: session.ID}, nil
+}
+
+func (s *authService) authenticateJWT(ctx context.Context, token string) (*response.AuthPrincipal, error) {
+	claims, err := utils.ParseJWT(token, s.jwtKeys, time.Now())
+	if err != nil {
+		s.log.Debug("Rejected access token", zap.Error(err))
+		return nil, utils.ErrInvalidToken
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if s.denylist.IsRevoked(ctx, sessionDenyKey(claims.SessionID), issuedAt) ||
+		s.denylist.IsRevoked(ctx, userDenyKey(claims.Subject), issuedAt) {
+		return nil, utils.ErrInvalidToken
+	}
+
+	return &response.AuthPrincipal{UserID: claims.Subject, Role: claims.Role, SessionID: claims.SessionID}, nil
+}
+
+// Refresh rotates a session's tokens. Presenting a refresh token that was
+// already rotated means it leaked, so the whole session is revoked.
+func (s *authService) Refresh(ctx context.Context, req request.RefreshTokenRequest) (*response.AuthResponse, error) {
+	hash := utils.HashToken(req.RefreshToken)
+
+	var res *response.AuthResponse
+	reused := false
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		session, err := s.repo.SessionRepo.FindByRefreshHash(ctx, hash)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		now := time.Now()
+		if session.RevokedAt != nil || !now.Before(session.RefreshExpiresAt) {
+			return utils.ErrInvalidRefreshToken
+		}
+
+		if session.RefreshTokenHash != hash {
+			reused = true
+			s.log.Warn("Refresh token reused, revoking session",
+				zap.Uint("session_id", session.ID),
+				zap.Uint("user_id", session.UserID))
+			if err := s.repo.SessionRepo.RevokeByID(ctx, session.ID); err != nil {
+				return err
+			}
+			s.denySession(ctx, session.ID)
+			return nil
+		}
+
+		user, err := s.repo.UserRepo.GetUserByID(ctx, session.UserID)
+		if err != nil {
+			if errors.Is(err, utils.ErrUserNotFound) {
+				return utils.ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		refreshToken, err := issueSessionTokens(session, now, s.idleTimeout(), s.refreshTTL())
+		if err != nil {
+			return err
+		}
+		if req.IPAddress != "" {
+			session.IPAddress = req.IPAddress
+		}
+		if req.UserAgent != "" {
+			session.UserAgent = truncateUserAgent(req.UserAgent)
+		}
+
+		if err := s.repo.SessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+
+		res, err = s.authResponse(session, user.Role, refreshToken)
+		return err
+	})
+	if err != nil {
+		s.log.Error("Error refresh token", zap.Error(err))
+		return nil, err
+	}
+	if reused {
+		return nil, utils.ErrInvalidRefreshToken
+	}
+
+	return res, nil
+}
+
+// ListSessions returns the signed-in user's live sessions, flagging the one
+// making the request.
+func (s *authService) ListSessions(ctx context.Context) ([]response.SessionResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+	current, _ := ctx.Value("session_id").(uint)
+
+	sessions, err := s.repo.SessionRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("Error list sessions", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]response.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, response.SessionResponse{
+			ID:               session.ID,
+			DeviceName:       session.DeviceName,
+			IPAddress:        session.IPAddress,
+			UserAgent:        session.UserAgent,
+			Current:          session.ID == current,
+			CreatedAt:        session.CreatedAt,
+			LastUsedAt:       session.LastUsedAt,
+			ExpiresAt:        session.ExpiresAt,
+			RefreshExpiresAt: session.RefreshExpiresAt,
+		})
+	}
+
+	return res, nil
+}
+
+// RevokeSession signs one of the user's other devices out.
+func (s *authService) RevokeSession(ctx context.Context, sessionID uint) error {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return utils.ErrInvalidToken
+	}
+	current, _ := ctx.Value("session_id").(uint)
+
+	session, err := s.repo.SessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrSessionNotFound
+		}
+		return err
+	}
+	if session.UserID != userID || session.RevokedAt != nil {
+		return utils.ErrSessionNotFound
+	}
+	if session.ID == current {
+		return utils.ErrCannotRevokeCurrent
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SessionRepo.RevokeByID(ctx, session.ID); err != nil {
+			return err
+		}
+		revokedAt := time.Now()
+		revoked := *session
+		revoked.RevokedAt = &revokedAt
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntitySession, session.ID, auditSession(session), auditSession(&revoked))
+	})
+	if err != nil {
+		s.log.Error("Error revoke session", zap.Uint("session_id", sessionID), zap.Error(err))
+		return err
+	}
+	s.denySession(ctx, session.ID)
+
+	s.log.Info("Session revoked", zap.Uint("session_id", sessionID), zap.Uint("user_id", userID))
+	return nil
+}
+
+// RevokeOtherSessions signs the user out everywhere except the current device.
+func (s *authService) RevokeOtherSessions(ctx context.Context) (*response.RevokedSessionsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+	current, _ := ctx.Value("session_id").(uint)
+
+	if current == 0 {
+		return nil, utils.ErrInvalidToken
+	}
+
+	sessions, err := s.repo.SessionRepo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("Error revoke other sessions", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	revoked, err := s.repo.SessionRepo.RevokeAllByUser(ctx, userID, current)
+	if err != nil {
+		s.log.Error("Error revoke other sessions", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.ID != current {
+			s.denySession(ctx, session.ID)
+		}
+	}
+
+	s.log.Info("Other sessions revoked", zap.Uint("user_id", userID), zap.Int64("revoked", revoked))
+	return &response.RevokedSessionsResponse{Revoked: revoked}, nil
+}
+
+// ForceLogout revokes every session of a user, for admins.
+func (s *authService) ForceLogout(ctx context.Context, userID uint) (*response.RevokedSessionsResponse, error) {
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actorRole, _ := ctx.Value("user_role").(entity.UserRole); !canManageRole(actorRole, user.Role) {
+		return nil, utils.ErrCannotManageUser
+	}
+
+	var revoked int64
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		revoked, err = s.repo.SessionRepo.RevokeAllByUser(ctx, userID, 0)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUser, userID,
+			map[string]int64{"active_sessions": revoked}, map[string]int64{"active_sessions": 0})
+	})
+	if err != nil {
+		s.log.Error("Error force logout", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	s.denyUser(ctx, userID)
+
+	s.log.Info("User forced to log out", zap.Uint("user_id", userID), zap.Int64("revoked", revoked))
+	return &response.RevokedSessionsResponse{Revoked: revoked}, nil
+}
+
+// RevokeUserSessions ends every session of a user together with the signed
+// tokens already issued to them. It does not check who is asking; callers
+// decide whether the user's access should end.
+func (s *authService) RevokeUserSessions(ctx context.Context, userID uint) (int64, error) {
+	revoked, err := s.repo.SessionRepo.RevokeAllByUser(ctx, userID, 0)
+	if err != nil {
+		s.log.Error("Error revoke user sessions", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, err
+	}
+	s.denyUser(ctx, userID)
+	return revoked, nil
+}
+
+func (s *authService) jwtMode() bool {
+	return s.config.Session.Mode == AuthModeJWT
+}
+
+func (s *authService) jwtAccessTTL() time.Duration {
+	if s.config.Session.JWTAccessMinutes > 0 {
+		return time.Duration(s.config.Session.JWTAccessMinutes) * time.Minute
+	}
+	return defaultJWTAccessTTL
+}
+
+// denySession stops signed tokens already issued for a revoked session. With
+// opaque tokens revoking the session row is enough.
+func (s *authService) denySession(ctx context.Context, sessionID uint) {
+	if !s.jwtMode() {
+		return
+	}
+	if err := s.denylist.Revoke(ctx, sessionDenyKey(sessionID), time.Now().Add(s.jwtAccessTTL())); err != nil {
+		s.log.Error("Error deny session tokens", zap.Uint("session_id", sessionID), zap.Error(err))
+	}
+}
+
+// denyUser stops every signed token already issued to a user.
+func (s *authService) denyUser(ctx context.Context, userID uint) {
+	if !s.jwtMode() {
+		return
+	}
+	if err := s.denylist.Revoke(ctx, userDenyKey(userID), time.Now().Add(s.jwtAccessTTL())); err != nil {
+		s.log.Error("Error deny user tokens", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func (s *authService) idleTimeout() time.Duration {
+	if s.config.Session.IdleMinutes > 0 {
+		return time.Duration(s.config.Session.IdleMinutes) * time.Minute
+	}
+	return defaultSessionIdle
+}
+
+func (s *authService) refreshTTL() time.Duration {
+	if s.config.Session.RefreshDays > 0 {
+		return time.Duration(s.config.Session.RefreshDays) * 24 * time.Hour
+	}
+	return defaultSessionRefresh
+}
+
+// issueSessionTokens gives a session a new access token and refresh token and
+// returns the plain refresh token; only its hash is kept on the session, and
+// the hash it replaces is remembered to detect reuse.
+func issueSessionTokens(session *entity.Session, now time.Time, idle, refreshTTL time.Duration) (string, error) {
+	access, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	refresh, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	session.Token = access
+	session.ExpiresAt = now.Add(idle)
+	session.PreviousRefreshHash = session.RefreshTokenHash
+	session.RefreshTokenHash = utils.HashToken(refresh)
+	session.RefreshExpiresAt = now.Add(refreshTTL)
+	session.LastUsedAt = now
+	if session.ExpiresAt.After(session.RefreshExpiresAt) {
+		session.ExpiresAt = session.RefreshExpiresAt
+	}
+
+	return refresh, nil
+}
+
+// slideSessionExpiry returns the access expiry after activity at now, never
+// past the refresh expiry, and whether it moved enough to be worth saving.
+func slideSessionExpiry(session *entity.Session, now time.Time, idle time.Duration) (time.Time, bool) {
+	next := now.Add(idle)
+	if !session.RefreshExpiresAt.IsZero() && next.After(session.RefreshExpiresAt) {
+		next = session.RefreshExpiresAt
+	}
+	return next, next.Sub(session.ExpiresAt) >= sessionTouchInterval
+}
+
+func truncateUserAgent(ua string) string {
+	if len(ua) > 255 {
+		return ua[:255]
+	}
+	return ua
+}
+
+// authResponse returns the session's tokens. In jwt mode the access token is
+// a signed token for the session instead of the opaque session token.
+func (s *authService) authResponse(session *entity.Session, role entity.UserRole, refreshToken string) (*response.AuthResponse, error) {
+	res := &response.AuthResponse{
+		Token:            session.Token.String(),
+		ExpiresAt:        session.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+	}
+	if !s.jwtMode() {
+		return res, nil
+	}
+
+	token, expiresAt, err := signAccessToken(session, role, time.Now(), s.jwtAccessTTL(), s.jwtKeys)
+	if err != nil {
+		return nil, err
+	}
+	res.Token = token
+	res.ExpiresAt = expiresAt
+	return res, nil
+}
+
+func signAccessToken(session *entity.Session, role entity.UserRole, now time.Time, ttl time.Duration, keys utils.JWTKeySet) (string, time.Time, error) {
+	expiresAt := now.Add(ttl)
+	if !session.RefreshExpiresAt.IsZero() && expiresAt.After(session.RefreshExpiresAt) {
+		expiresAt = session.RefreshExpiresAt
+	}
+
+	token, err := utils.SignJWT(utils.JWTClaims{
+		Subject:   session.UserID,
+		Role:      string(role),
+		SessionID: session.ID,
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}, keys)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
 }
 
 func (s *authService) Logout(ctx context.Context, token string) error {
-	err := s.repo.SessionRepo.Revoke(ctx, token)
+	sessionID, ok := ctx.Value("session_id").(uint)
+	if !ok {
+		err := s.repo.SessionRepo.Revoke(ctx, token)
+		if err != nil {
+			s.log.Error("Error logout service: ", zap.Error(err))
+		}
+		return err
+	}
+
+	err := s.repo.SessionRepo.RevokeByID(ctx, sessionID)
 	if err != nil {
 		s.log.Error("Error logout service: ", zap.Error(err))
 		return err
 	}
+	s.denySession(ctx, sessionID)
 	return nil
 }
 
-func (s *authService) RequestResetPassword(ctx context.Context, email string) (*response.OTPResponse, error) {
+// RequestResetPassword emails a reset code and a single-use reset link. It
+// succeeds whether or not the email is registered, so the response does not
+// reveal which addresses have accounts; the lookup and the email happen in
+// the background so the response time does not either.
+func (s *authService) RequestResetPassword(ctx context.Context, req request.ResetPasswordRequest) error {
+	if err := s.throttleEmailRequest(ctx, req.Email, req.IPAddress); err != nil {
+		return err
+	}
+
+	go s.sendPasswordReset(context.Background(), req.Email)
+	return nil
+}
+
+// sendPasswordReset replaces the reset code and link of the user with email
+// and sends the new ones.
+func (s *authService) sendPasswordReset(ctx context.Context, email string) {
 	// Find user by email
 	user, err := s.repo.UserRepo.FindUserByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Info("Password reset requested for unknown email")
+		return
+	}
 	if err != nil {
 		s.log.Error("Error find user by email", zap.Error(err))
-		return nil, err
+		return
 	}
 
-	// Generate OTP
-	otpCode, _ := utils.GenerateOTP(6)
+	// Generate OTP and reset link
+	otpCode, err := utils.GenerateOTP(6)
+	if err != nil {
+		s.log.Error("Error generate reset code", zap.Error(err))
+		return
+	}
+	linkToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		s.log.Error("Error generate reset link", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
 	otp := entity.OTP{
 		UserID: user.ID,
 		OTPCode: otpCode,
-		ExpiresAt: time.Now().Add(10 * time.Minute),
+		Purpose: entity.OTPPurposePasswordReset,
+		ExpiresAt: now.Add(otpTTL),
+	}
+	reset := entity.PasswordReset{
+		UserID: user.ID,
+		ResetTokenHash: utils.HashToken(linkToken),
+		ExpiredAt: now.Add(resetLinkTTL),
+		CreatedAt: now,
 	}
-	err = s.repo.OTPRepo.Create(ctx, &otp)
+
+	// A new request replaces any code or link sent before
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.OTPRepo.InvalidateByPurpose(ctx, user.ID, entity.OTPPurposePasswordReset); err != nil {
+			return err
+		}
+		if err := s.repo.PasswordResetRepo.InvalidateByUser(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := s.repo.OTPRepo.Create(ctx, &otp); err != nil {
+			return err
+		}
+		return s.repo.PasswordResetRepo.Create(ctx, &reset)
+	})
 	if err != nil {
-		s.log.Error("Error create otp", zap.Error(err))
-		return nil, err
+		s.log.Error("Error create password reset", zap.Error(err))
+		return
 	}
 
-	res := response.OTPResponse{
+	data := response.PasswordResetEmail{
 		OTPCode: otpCode,
 		ExpiresAt: otp.ExpiresAt,
+		ResetLink: resetLink(s.resetURL(), user.Email, linkToken),
+		LinkExpiresAt: reset.ExpiredAt,
 	}
 
-	// Send email
 	err = s.email.Send(ctx, request.EmailRequest{
 		To:      user.Email,
 		Subject: "Reset Password",
-		Body:    content.SendOTP(res),
+		Body:    content.SendOTP(data),
 	})
-
 	if err != nil {
 		s.log.Error("Error send email", zap.Error(err))
 	}
-	
-	return &res, nil
 }
 
 func (s *authService) ValidateOTP(ctx context.Context, req request.ValidateOTP) (*response.ResetTokenResponse, error) {
-	// Find user by email
+	// Find user by email; an unknown email looks like a wrong code
 	user, err := s.repo.UserRepo.FindUserByEmail(ctx, req.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrInvalidOTP
+	}
 	if err != nil {
 		s.log.Error("Error find user by email", zap.Error(err))
 		return nil, err
 	}
 
 	// Validate OTP
-	otp, err := s.repo.OTPRepo.GetValidByUser(ctx, user.ID)
+	otp, err := s.repo.OTPRepo.GetValidByUser(ctx, user.ID, entity.OTPPurposePasswordReset)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrInvalidOTP
+	}
 	if err != nil {
 		s.log.Error("Error get valid OTP", zap.Error(err))
 		return nil, err
 	}
 
-	if otp.OTPCode != req.OTP {
-		return nil, err
+	if subtle.ConstantTimeCompare([]byte(otp.OTPCode), []byte(req.OTP)) != 1 {
+		// Each wrong code counts; the OTP is void once the limit is reached
+		attempts, err := s.repo.OTPRepo.RecordFailedAttempt(ctx, otp.ID, s.otpMaxAttempts())
+		if err != nil {
+			return nil, err
+		}
+		if attempts >= s.otpMaxAttempts() {
+			s.log.Warn("OTP invalidated after repeated wrong codes", zap.Uint("user_id", user.ID))
+			return nil, utils.ErrOTPAttemptsExceeded
+		}
+		return nil, utils.ErrInvalidOTP
 	}
 
-	// Mark OTP as used
-	err = s.repo.OTPRepo.MarkUsed(ctx, otp.ID)
+	// Generate reset token
+	token, err := utils.GenerateSecureToken(32)
 	if err != nil {
-		s.log.Error("Error mark OTP as used", zap.Error(err))
 		return nil, err
 	}
-
-	// Generate reset token
-	token, err := utils.GenerateRandomToken(16)
 	reset := entity.PasswordReset{
 		UserID: user.ID,
-		ResetTokenHash: utils.HashPassword(token.String()),
-		ExpiredAt: time.Now().Add(5 * time.Minute),
+		ResetTokenHash: utils.HashToken(token),
+		ExpiredAt: time.Now().Add(resetTokenTTL),
 		CreatedAt: time.Now(),
 	}
-	err = s.repo.PasswordResetRepo.Create(ctx, &reset)
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Mark OTP as used; losing a race with another request voids this one
+		if err := s.repo.OTPRepo.MarkUsed(ctx, otp.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidOTP
+			}
+			return err
+		}
+		return s.repo.PasswordResetRepo.Create(ctx, &reset)
+	})
 	if err != nil {
-		s.log.Error("Error create reset token", zap.Error(err))
+		if !errors.Is(err, utils.ErrInvalidOTP) {
+			s.log.Error("Error create reset token", zap.Error(err))
+		}
 		return nil, err
 	}
 
 	res := response.ResetTokenResponse{
-		ResetToken: token.String(),
+		ResetToken: token,
 	}
 
 	return &res, nil
 }
 
+// ResetPassword sets a new password with a reset token from ValidateOTP or
+// the emailed link. The token works once; afterwards every other outstanding
+// code and link is voided and the user's sessions are ended.
 func (s *authService) ResetPassword(ctx context.Context, req request.ResetPassword) error {
-	// Get user by email
-	user, err := s.repo.UserRepo.FindUserByEmail(ctx, req.Email)
+	// Get valid token
+	reset, err := s.repo.PasswordResetRepo.FindValidByTokenHash(ctx, utils.HashToken(req.ResetToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrInvalidToken
+	}
 	if err != nil {
-		s.log.Error("Error get user by id", zap.Error(err))
+		s.log.Error("Error get token", zap.Error(err))
 		return err
 	}
 
-	// Get valid token
-	reset, err := s.repo.PasswordResetRepo.GetValidByUser(ctx, user.ID)
+	// The token must belong to the email it is presented with
+	user, err := s.repo.UserRepo.FindUserByEmail(ctx, req.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrInvalidToken
+	}
 	if err != nil {
-		s.log.Error("Error get token", zap.Error(err))
+		s.log.Error("Error get user by email", zap.Error(err))
 		return err
 	}
-
-	if !utils.CheckPassword(req.ResetToken, reset.ResetTokenHash) {
+	if user.ID != reset.UserID {
 		return utils.ErrInvalidToken
 	}
 
 	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Mark token as used first so a concurrent reset with it fails
+		if e := s.repo.PasswordResetRepo.MarkUsed(ctx, reset.ID); e != nil {
+			if errors.Is(e, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidToken
+			}
+			return e
+		}
 		// Hash password
 		user.PasswordHash = utils.HashPassword(req.NewPassword)
 		// Update user password
-		e := s.repo.UserRepo.UpdateUser(ctx, user.ID, user)
-		if e != nil {
+		if e := s.repo.UserRepo.UpdateUser(ctx, user.ID, user); e != nil {
 			return e
 		}
-		// Mark token as used
-		e = s.repo.PasswordResetRepo.MarkUsed(ctx, reset.ID)
-		if e != nil {
+		if e := s.repo.PasswordResetRepo.InvalidateByUser(ctx, user.ID); e != nil {
 			return e
 		}
-		return nil
+		if e := s.repo.OTPRepo.InvalidateByUser(ctx, user.ID); e != nil {
+			return e
+		}
+		if _, e := s.repo.SessionRepo.RevokeAllByUser(ctx, user.ID, 0); e != nil {
+			return e
+		}
+		before, after := auditSecretChange("password")
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUser, user.ID, before, after)
 	})
 
 	if err != nil {
-		s.log.Error("Error reset password", zap.Error(err))
+		if !errors.Is(err, utils.ErrInvalidToken) {
+			s.log.Error("Error reset password", zap.Error(err))
+		}
 		return err
 	}
 
+	s.denyUser(ctx, user.ID)
+
+	// Proving ownership of the email also lifts a login lockout
+	if err := s.repo.LoginThrottleRepo.Delete(ctx, loginAccountKey(user.ID)); err != nil {
+		s.log.Warn("Error clear failed logins", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *authService) resetURL() string {
+	if s.config.PasswordResetURL != "" {
+		return s.config.PasswordResetURL
+	}
+	return strings.TrimSuffix(s.config.BaseURL, "/") + "/reset-password"
+}
+
+// resetLink builds the emailed link to the reset page carrying the email and
+// the single-use token.
+func resetLink(base, email, token string) string {
+	query := url.Values{}
+	query.Set("email", email)
+	query.Set("token", token)
+
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + query.Encode()
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestIssueSessionTokens_RotatesAndKeepsPreviousHash(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	session := &entity.Session{}
+
+	first, err := issueSessionTokens(session, now, time.Hour, 30*24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, utils.HashToken(first), session.RefreshTokenHash)
+	require.Empty(t, session.PreviousRefreshHash)
+	require.Equal(t, now.Add(time.Hour), session.ExpiresAt)
+	firstAccess := session.Token
+
+	second, err := issueSessionTokens(session, now.Add(time.Hour), time.Hour, 30*24*time.Hour)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+	require.NotEqual(t, firstAccess, session.Token)
+	require.Equal(t, utils.HashToken(first), session.PreviousRefreshHash)
+	require.Equal(t, utils.HashToken(second), session.RefreshTokenHash)
+}
+
+func TestSlideSessionExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	session := &entity.Session{
+		ExpiresAt:        now.Add(59*time.Minute + 30*time.Second),
+		RefreshExpiresAt: now.Add(24 * time.Hour),
+	}
+
+	// Activity within the touch interval is not written back
+	_, ok := slideSessionExpiry(session, now, time.Hour)
+	require.False(t, ok)
+
+	next, ok := slideSessionExpiry(session, now.Add(10*time.Minute), time.Hour)
+	require.True(t, ok)
+	require.Equal(t, now.Add(70*time.Minute), next)
+
+	// Never past the refresh expiry
+	next, _ = slideSessionExpiry(session, now.Add(23*time.Hour+30*time.Minute), time.Hour)
+	require.Equal(t, session.RefreshExpiresAt, next)
+}
+
+func TestSignAccessToken_RoundTripAndKeyRotation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	session := &entity.Session{ID: 7, UserID: 3, RefreshExpiresAt: now.Add(24 * time.Hour)}
+	oldKeys := utils.ParseJWTKeySet("k1:first-secret", "k1")
+
+	token, expiresAt, err := signAccessToken(session, entity.RoleAdmin, now, 15*time.Minute, oldKeys)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(15*time.Minute), expiresAt)
+
+	// After rotation the old key still verifies tokens it signed
+	rotated := utils.ParseJWTKeySet("k2:second-secret, k1:first-secret", "k2")
+	claims, err := utils.ParseJWT(token, rotated, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, uint(3), claims.Subject)
+	require.Equal(t, uint(7), claims.SessionID)
+	require.Equal(t, string(entity.RoleAdmin), claims.Role)
+
+	// Once the old key is retired its tokens are rejected
+	_, err = utils.ParseJWT(token, utils.ParseJWTKeySet("k2:second-secret", "k2"), now.Add(time.Minute))
+	require.ErrorIs(t, err, utils.ErrJWTUnknownKey)
+
+	_, err = utils.ParseJWT(token, rotated, now.Add(16*time.Minute))
+	require.ErrorIs(t, err, utils.ErrJWTExpired)
+}
+
+func TestSignAccessToken_CappedAtRefreshExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	session := &entity.Session{ID: 1, UserID: 1, RefreshExpiresAt: now.Add(5 * time.Minute)}
+
+	_, expiresAt, err := signAccessToken(session, entity.RoleStaff, now, 15*time.Minute, utils.ParseJWTKeySet("k1:secret", "k1"))
+	require.NoError(t, err)
+	require.Equal(t, now.Add(5*time.Minute), expiresAt)
+}
+
+func TestParseJWT_RejectsTamperedToken(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	keys := utils.ParseJWTKeySet("k1:secret", "k1")
+	token, _, err := signAccessToken(&entity.Session{ID: 1, UserID: 1}, entity.RoleStaff, now, time.Minute, keys)
+	require.NoError(t, err)
+
+	_, err = utils.ParseJWT(token, utils.ParseJWTKeySet("k1:other", "k1"), now)
+	require.ErrorIs(t, err, utils.ErrJWTSignature)
+
+	_, err = utils.ParseJWT("not-a-token", keys, now)
+	require.ErrorIs(t, err, utils.ErrJWTMalformed)
+}
+
+func TestDenies(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := entity.TokenRevocation{Key: "session:1", RevokedBefore: now, ExpiresAt: now.Add(15 * time.Minute)}
+
+	require.True(t, denies(r, now.Add(-time.Minute), now))
+	require.True(t, denies(r, now, now))
+	require.False(t, denies(r, now.Add(time.Second), now))
+	// Expired revocations no longer deny anything
+	require.False(t, denies(r, now.Add(-time.Minute), now.Add(15*time.Minute)))
+	require.False(t, denies(entity.TokenRevocation{}, now, now))
+}
+
+func TestResetLink(t *testing.T) {
+	link := resetLink("https://pos.example.com/reset-password", "jane+pos@example.com", "abc123")
+	require.Equal(t, "https://pos.example.com/reset-password?email=jane%2Bpos%40example.com&token=abc123", link)
+
+	// A base that already has a query keeps it
+	link = resetLink("https://pos.example.com/app?page=reset", "a@b.co", "t")
+	require.Equal(t, "https://pos.example.com/app?page=reset&email=a%40b.co&token=t", link)
+}
+
+func newSessionTestService() (AuthService, *mocks.SessionRepoMock, *mocks.UserRepoMock) {
+	tx := new(infra.MockTxManager)
+	sessionRepo := new(mocks.SessionRepoMock)
+	userRepo := new(mocks.UserRepoMock)
+	repo := &repository.Repository{SessionRepo: sessionRepo, UserRepo: userRepo}
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	return NewAuthService(tx, repo, zap.NewNop(), nil, utils.Configuration{}), sessionRepo, userRepo
+}
+
+func TestAuthService_Refresh_RotatesTokens(t *testing.T) {
+	service, sessionRepo, userRepo := newSessionTestService()
+	session := &entity.Session{
+		ID:               4,
+		UserID:           1,
+		RefreshTokenHash: utils.HashToken("old-refresh"),
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	sessionRepo.On("FindByRefreshHash", mock.Anything, utils.HashToken("old-refresh")).Return(session, nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(1)).Return(entity.User{ID: 1, Role: entity.RoleStaff}, nil)
+	sessionRepo.On("Update", mock.Anything, session).Return(nil)
+
+	res, err := service.Refresh(context.Background(), request.RefreshTokenRequest{RefreshToken: "old-refresh"})
+	require.NoError(t, err)
+	require.NotEqual(t, "old-refresh", res.RefreshToken)
+	require.Equal(t, utils.HashToken(res.RefreshToken), session.RefreshTokenHash)
+	require.Equal(t, utils.HashToken("old-refresh"), session.PreviousRefreshHash)
+	sessionRepo.AssertNotCalled(t, "RevokeByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Refresh_ReusedTokenRevokesSession(t *testing.T) {
+	service, sessionRepo, _ := newSessionTestService()
+	session := &entity.Session{
+		ID:                  4,
+		UserID:              1,
+		RefreshTokenHash:    utils.HashToken("new-refresh"),
+		PreviousRefreshHash: utils.HashToken("old-refresh"),
+		RefreshExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	sessionRepo.On("FindByRefreshHash", mock.Anything, utils.HashToken("old-refresh")).Return(session, nil)
+	sessionRepo.On("RevokeByID", mock.Anything, uint(4)).Return(nil)
+
+	_, err := service.Refresh(context.Background(), request.RefreshTokenRequest{RefreshToken: "old-refresh"})
+	require.ErrorIs(t, err, utils.ErrInvalidRefreshToken)
+	sessionRepo.AssertCalled(t, "RevokeByID", mock.Anything, uint(4))
+	sessionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Refresh_DeletedUser(t *testing.T) {
+	service, sessionRepo, userRepo := newSessionTestService()
+	session := &entity.Session{
+		ID:               4,
+		UserID:           1,
+		RefreshTokenHash: utils.HashToken("old-refresh"),
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	sessionRepo.On("FindByRefreshHash", mock.Anything, utils.HashToken("old-refresh")).Return(session, nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(1)).Return(entity.User{}, utils.ErrUserNotFound)
+
+	_, err := service.Refresh(context.Background(), request.RefreshTokenRequest{RefreshToken: "old-refresh"})
+	require.ErrorIs(t, err, utils.ErrInvalidRefreshToken)
+}
+
+func TestAuthService_RevokeSession_OfAnotherUser(t *testing.T) {
+	service, sessionRepo, _ := newSessionTestService()
+	ctx := context.WithValue(actorContext(1, entity.RoleStaff), "session_id", uint(3))
+
+	sessionRepo.On("FindByID", mock.Anything, uint(8)).Return(&entity.Session{ID: 8, UserID: 2}, nil)
+
+	err := service.RevokeSession(ctx, 8)
+	require.ErrorIs(t, err, utils.ErrSessionNotFound)
+	sessionRepo.AssertNotCalled(t, "RevokeByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_RevokeOtherSessions_KeepsCurrent(t *testing.T) {
+	service, sessionRepo, _ := newSessionTestService()
+	ctx := context.WithValue(actorContext(1, entity.RoleStaff), "session_id", uint(3))
+
+	sessionRepo.On("FindActiveByUser", mock.Anything, uint(1)).Return([]entity.Session{{ID: 3}, {ID: 5}, {ID: 6}}, nil)
+	sessionRepo.On("RevokeAllByUser", mock.Anything, uint(1), uint(3)).Return(int64(2), nil)
+
+	res, err := service.RevokeOtherSessions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Revoked)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestAuthService_SendPasswordReset_UnknownEmail(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	email := new(mocks.EmailSenderMock)
+	repo := &repository.Repository{UserRepo: userRepo}
+	service := NewAuthService(tx, repo, zap.NewNop(), email, utils.Configuration{}).(*authService)
+
+	userRepo.On("FindUserByEmail", mock.Anything, "ghost@example.com").Return((*entity.User)(nil), gorm.ErrRecordNotFound)
+
+	// No code or link is stored for a missing user and nothing is mailed
+	service.sendPasswordReset(context.Background(), "ghost@example.com")
+	tx.AssertNotCalled(t, "WithinTx", mock.Anything)
+	email.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ValidateOTP_UnknownEmail(t *testing.T) {
+	userRepo := new(mocks.UserRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo}
+	service := NewAuthService(new(infra.MockTxManager), repo, zap.NewNop(), nil, utils.Configuration{})
+
+	userRepo.On("FindUserByEmail", mock.Anything, "ghost@example.com").Return((*entity.User)(nil), gorm.ErrRecordNotFound)
+
+	_, err := service.ValidateOTP(context.Background(), request.ValidateOTP{Email: "ghost@example.com", OTP: "123456"})
+	require.ErrorIs(t, err, utils.ErrInvalidOTP)
+}
@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type CashDrawerService interface {
+	OpenDrawer(ctx context.Context, req request.OpenCashDrawerRequest) (*response.CashDrawerResponse, error)
+	GetCurrentDrawer(ctx context.Context) (*response.CashDrawerResponse, error)
+	GetDrawers(ctx context.Context, req request.GetCashDrawersRequest) ([]response.CashDrawerResponse, response.PaginationMeta, error)
+	GetDrawerByID(ctx context.Context, id uint) (*response.CashDrawerResponse, error)
+	RecordMovement(ctx context.Context, id uint, movementType entity.CashMovementType, req request.CashMovementRequest) (*response.CashDrawerResponse, error)
+	CloseDrawer(ctx context.Context, id uint, req request.CloseCashDrawerRequest) (*response.ZReportResponse, error)
+	GetZReport(ctx context.Context, id uint) (*response.ZReportResponse, error)
+}
+
+type cashDrawerService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewCashDrawerService(tx TxManager, repo *repository.Repository, log *zap.Logger) CashDrawerService {
+	return &cashDrawerService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "cash_drawer")),
+	}
+}
+
+func (s *cashDrawerService) OpenDrawer(ctx context.Context, req request.OpenCashDrawerRequest) (*response.CashDrawerResponse, error) {
+	cashierID := ctx.Value("user_id").(uint)
+
+	var session *entity.CashDrawerSession
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		open, err := s.repo.CashDrawerRepo.FindOpenByCashier(ctx, cashierID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if open != nil {
+			return utils.ErrCashDrawerAlreadyOpen
+		}
+
+		session = &entity.CashDrawerSession{
+			CashierID:    cashierID,
+			Status:       entity.CashDrawerStatusOpen,
+			OpeningFloat: req.OpeningFloat,
+			OpenedAt:     time.Now(),
+			ExpectedCash: req.OpeningFloat,
+			Notes:        req.Notes,
+		}
+		session, err = s.repo.CashDrawerRepo.Create(ctx, session)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityCashDrawer, session.ID, nil, session)
+	})
+	if err != nil {
+		s.log.Error("Failed to open cash drawer", zap.Uint("cashier_id", cashierID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Cash drawer opened",
+		zap.Uint("id", session.ID),
+		zap.Uint("cashier_id", cashierID),
+		zap.Float64("opening_float", session.OpeningFloat))
+
+	resp := response.CashDrawerToResponse(session)
+	return &resp, nil
+}
+
+func (s *cashDrawerService) GetCurrentDrawer(ctx context.Context) (*response.CashDrawerResponse, error) {
+	cashierID := ctx.Value("user_id").(uint)
+
+	session, err := s.repo.CashDrawerRepo.FindOpenByCashier(ctx, cashierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrCashDrawerNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := s.refreshTotals(ctx, session); err != nil {
+		return nil, err
+	}
+
+	resp := response.CashDrawerToResponse(session)
+	return &resp, nil
+}
+
+func (s *cashDrawerService) GetDrawers(ctx context.Context, req request.GetCashDrawersRequest) ([]response.CashDrawerResponse, response.PaginationMeta, error) {
+	sessions, total, err := s.repo.CashDrawerRepo.FindAll(ctx, repository.CashDrawerParams{
+		Offset:    req.GetOffset(),
+		Limit:     req.GetPerPage(),
+		CashierID: req.CashierID,
+		Status:    req.Status,
+	})
+	if err != nil {
+		s.log.Error("Failed to get cash drawers", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.CashDrawerResponse, 0, len(sessions))
+	for _, sess := range sessions {
+		result = append(result, response.CashDrawerToResponse(&sess))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *cashDrawerService) GetDrawerByID(ctx context.Context, id uint) (*response.CashDrawerResponse, error) {
+	session, err := s.findDrawer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(ctx, session); err != nil {
+		return nil, err
+	}
+
+	if session.Status == entity.CashDrawerStatusOpen {
+		if _, err := s.refreshTotals(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := response.CashDrawerToResponse(session)
+	return &resp, nil
+}
+
+func (s *cashDrawerService) RecordMovement(ctx context.Context, id uint, movementType entity.CashMovementType, req request.CashMovementRequest) (*response.CashDrawerResponse, error) {
+	userID := ctx.Value("user_id").(uint)
+
+	var session *entity.CashDrawerSession
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		session, err = s.findDrawerForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.checkOwner(ctx, session); err != nil {
+			return err
+		}
+		if session.Status != entity.CashDrawerStatusOpen {
+			return utils.ErrCashDrawerNotOpen
+		}
+
+		before := *session
+
+		if movementType == entity.CashMovementPayOut {
+			if _, err := s.refreshTotals(ctx, session); err != nil {
+				return err
+			}
+			if req.Amount > session.ExpectedCash {
+				return utils.ErrInsufficientDrawerCash
+			}
+		}
+
+		movement := entity.CashMovement{
+			SessionID: session.ID,
+			Type:      movementType,
+			Amount:    req.Amount,
+			Reason:    req.Reason,
+			CreatedBy: userID,
+		}
+		if err := s.repo.CashDrawerRepo.CreateMovement(ctx, &movement); err != nil {
+			return err
+		}
+		session.Movements = append(session.Movements, movement)
+
+		if _, err := s.refreshTotals(ctx, session); err != nil {
+			return err
+		}
+		if err := s.repo.CashDrawerRepo.Update(ctx, session); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityCashDrawer, id, before, session)
+	})
+	if err != nil {
+		s.log.Error("Failed to record cash movement", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Cash movement recorded",
+		zap.Uint("id", id),
+		zap.String("type", string(movementType)),
+		zap.Float64("amount", req.Amount))
+
+	resp := response.CashDrawerToResponse(session)
+	return &resp, nil
+}
+
+func (s *cashDrawerService) CloseDrawer(ctx context.Context, id uint, req request.CloseCashDrawerRequest) (*response.ZReportResponse, error) {
+	userID := ctx.Value("user_id").(uint)
+
+	var session *entity.CashDrawerSession
+	var rows []repository.PaymentSummaryRow
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		session, err = s.findDrawerForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.checkOwner(ctx, session); err != nil {
+			return err
+		}
+		if session.Status != entity.CashDrawerStatusOpen {
+			return utils.ErrCashDrawerNotOpen
+		}
+
+		before := *session
+		now := time.Now()
+		session.ClosedAt = &now
+		rows, err = s.refreshTotals(ctx, session)
+		if err != nil {
+			return err
+		}
+
+		counted := *req.CountedCash
+		variance := roundTo(counted-session.ExpectedCash, 2)
+		session.Status = entity.CashDrawerStatusClosed
+		session.ClosedBy = &userID
+		session.CountedCash = &counted
+		session.Variance = &variance
+		if req.Notes != "" {
+			session.Notes = req.Notes
+		}
+
+		if err := s.repo.CashDrawerRepo.Update(ctx, session); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityCashDrawer, id, before, session)
+	})
+	if err != nil {
+		s.log.Error("Failed to close cash drawer", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Cash drawer closed",
+		zap.Uint("id", id),
+		zap.Uint("closed_by", userID),
+		zap.Float64("expected", session.ExpectedCash),
+		zap.Float64("variance", *session.Variance))
+
+	return zReport(session, rows), nil
+}
+
+func (s *cashDrawerService) GetZReport(ctx context.Context, id uint) (*response.ZReportResponse, error) {
+	session, err := s.findDrawer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwner(ctx, session); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.summarize(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == entity.CashDrawerStatusOpen {
+		applyDrawerTotals(session, rows)
+	}
+
+	return zReport(session, rows), nil
+}
+
+// refreshTotals recomputes the live cash figures for a session and returns
+// the transaction summary it used.
+func (s *cashDrawerService) refreshTotals(ctx context.Context, session *entity.CashDrawerSession) ([]repository.PaymentSummaryRow, error) {
+	rows, err := s.summarize(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	applyDrawerTotals(session, rows)
+	return rows, nil
+}
+
+func (s *cashDrawerService) summarize(ctx context.Context, session *entity.CashDrawerSession) ([]repository.PaymentSummaryRow, error) {
+	to := time.Now()
+	if session.ClosedAt != nil {
+		to = *session.ClosedAt
+	}
+
+	rows, err := s.repo.CashDrawerRepo.SummarizeTransactions(ctx, session.CashierID, session.OpenedAt, to)
+	if err != nil {
+		s.log.Error("Failed to summarize drawer transactions", zap.Uint("id", session.ID), zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// checkOwner lets cashiers work only their own drawer; managers may act on any.
+func (s *cashDrawerService) checkOwner(ctx context.Context, session *entity.CashDrawerSession) error {
+	if HasPermission(ctx, entity.PermCashDrawerManage) {
+		return nil
+	}
+
+	userID, _ := ctx.Value("user_id").(uint)
+	if session.CashierID != userID {
+		return utils.ErrCashDrawerNotOwner
+	}
+	return nil
+}
+
+func (s *cashDrawerService) findDrawer(ctx context.Context, id uint) (*entity.CashDrawerSession, error) {
+	session, err := s.repo.CashDrawerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrCashDrawerNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *cashDrawerService) findDrawerForUpdate(ctx context.Context, id uint) (*entity.CashDrawerSession, error) {
+	session, err := s.repo.CashDrawerRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrCashDrawerNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// applyDrawerTotals sets the cash figures of a session from its movements and
+// the cash rows of the transaction summary. Adjustments count towards sales.
+func applyDrawerTotals(session *entity.CashDrawerSession, rows []repository.PaymentSummaryRow) {
+	session.CashSales, session.CashRefunds, session.PayIns, session.PayOuts = 0, 0, 0, 0
+
+	for _, row := range rows {
+		if !row.IsCash {
+			continue
+		}
+		switch row.TransactionType {
+		case entity.TransactionTypeRefund:
+			session.CashRefunds += row.Total
+		default:
+			session.CashSales += row.Total
+		}
+	}
+
+	for _, m := range session.Movements {
+		switch m.Type {
+		case entity.CashMovementPayIn:
+			session.PayIns += m.Amount
+		case entity.CashMovementPayOut:
+			session.PayOuts += m.Amount
+		}
+	}
+
+	session.ExpectedCash = roundTo(session.OpeningFloat+session.CashSales-session.CashRefunds+session.PayIns-session.PayOuts, 2)
+}
+
+// zReport folds the transaction summary into one line per payment method.
+func zReport(session *entity.CashDrawerSession, rows []repository.PaymentSummaryRow) *response.ZReportResponse {
+	report := &response.ZReportResponse{
+		SessionID: session.ID,
+		CashierID: session.CashierID,
+		OpenedAt:  session.OpenedAt,
+		ClosedAt:  session.ClosedAt,
+		Lines:     []response.ZReportLine{},
+		Drawer:    response.CashDrawerToResponse(session),
+	}
+
+	index := make(map[uint]int)
+	for _, row := range rows {
+		i, ok := index[row.PaymentMethodID]
+		if !ok {
+			report.Lines = append(report.Lines, response.ZReportLine{
+				PaymentMethodID:   row.PaymentMethodID,
+				PaymentMethodName: row.PaymentMethodName,
+				IsCash:            row.IsCash,
+			})
+			i = len(report.Lines) - 1
+			index[row.PaymentMethodID] = i
+		}
+
+		line := &report.Lines[i]
+		switch row.TransactionType {
+		case entity.TransactionTypePayment:
+			line.PaymentCount += row.Count
+			line.Payments += row.Total
+			report.GrossSales += row.Total
+		case entity.TransactionTypeRefund:
+			line.RefundCount += row.Count
+			line.Refunds += row.Total
+			report.TotalRefunds += row.Total
+		default:
+			line.Adjustments += row.Total
+			report.GrossSales += row.Total
+		}
+		line.Net = roundTo(line.Payments+line.Adjustments-line.Refunds, 2)
+	}
+
+	report.GrossSales = roundTo(report.GrossSales, 2)
+	report.TotalRefunds = roundTo(report.TotalRefunds, 2)
+	report.NetSales = roundTo(report.GrossSales-report.TotalRefunds, 2)
+
+	return report
+}
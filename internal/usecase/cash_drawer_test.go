@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cashDrawerRows() []repository.PaymentSummaryRow {
+	return []repository.PaymentSummaryRow{
+		{PaymentMethodID: 1, PaymentMethodName: "Cash", IsCash: true, TransactionType: entity.TransactionTypePayment, Count: 3, Total: 150000},
+		{PaymentMethodID: 1, PaymentMethodName: "Cash", IsCash: true, TransactionType: entity.TransactionTypeRefund, Count: 1, Total: 20000},
+		{PaymentMethodID: 2, PaymentMethodName: "Debit Card", TransactionType: entity.TransactionTypePayment, Count: 2, Total: 90000},
+	}
+}
+
+func TestApplyDrawerTotals_ExpectedCash(t *testing.T) {
+	session := &entity.CashDrawerSession{
+		OpeningFloat: 100000,
+		Movements: []entity.CashMovement{
+			{Type: entity.CashMovementPayIn, Amount: 50000},
+			{Type: entity.CashMovementPayOut, Amount: 15000},
+		},
+	}
+
+	applyDrawerTotals(session, cashDrawerRows())
+
+	require.Equal(t, 150000.0, session.CashSales)
+	require.Equal(t, 20000.0, session.CashRefunds)
+	require.Equal(t, 50000.0, session.PayIns)
+	require.Equal(t, 15000.0, session.PayOuts)
+	// card payments never reach the drawer
+	require.Equal(t, 265000.0, session.ExpectedCash)
+}
+
+func TestZReport_GroupsByPaymentMethod(t *testing.T) {
+	report := zReport(&entity.CashDrawerSession{ID: 4}, cashDrawerRows())
+
+	require.Len(t, report.Lines, 2)
+	require.Equal(t, "Cash", report.Lines[0].PaymentMethodName)
+	require.Equal(t, int64(3), report.Lines[0].PaymentCount)
+	require.Equal(t, int64(1), report.Lines[0].RefundCount)
+	require.Equal(t, 130000.0, report.Lines[0].Net)
+	require.Equal(t, 90000.0, report.Lines[1].Net)
+	require.Equal(t, 240000.0, report.GrossSales)
+	require.Equal(t, 20000.0, report.TotalRefunds)
+	require.Equal(t, 220000.0, report.NetSales)
+}
@@ -1,7 +1,7 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
@@ -14,25 +14,31 @@ import (
 type CategoryService interface {
 	GetAllCategories(req request.GetCategoriesRequest) (*response.CategoryListResponse, error)
 	GetCategoryByID(id uint) (*response.CategoryResponse, error)
-	CreateCategory(req request.CreateCategoryRequest) (*response.CategoryResponse, error)
-	UpdateCategory(id uint, req request.UpdateCategoryRequest) (*response.CategoryResponse, error)
-	DeleteCategory(id uint) error
+	CreateCategory(ctx context.Context, req request.CreateCategoryRequest) (*response.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, id uint, req request.UpdateCategoryRequest) (*response.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, id uint) error
 }
 
 type categoryService struct {
 	tx           TxManager
 	categoryRepo repository.CategoryRepository
+	auditRepo    repository.AuditLogRepository
+	storage      FileStorage
 	log          *zap.Logger
 }
 
 func NewCategoryService(
 	tx TxManager,
 	categoryRepo repository.CategoryRepository,
+	auditRepo repository.AuditLogRepository,
+	storage FileStorage,
 	log *zap.Logger,
 ) CategoryService {
 	return &categoryService{
 		tx:           tx,
 		categoryRepo: categoryRepo,
+		auditRepo:    auditRepo,
+		storage:      storage,
 		log:          log.With(zap.String("service", "category")),
 	}
 }
@@ -57,6 +63,7 @@ func (cs *categoryService) GetAllCategories(req request.GetCategoriesRequest) (*
 			Name:        category.Name,
 			IconURL:     category.IconURL,
 			Description: category.Description,
+			Station:     category.Station,
 			CreatedAt:   category.CreatedAt,
 			UpdatedAt:   category.UpdatedAt,
 		})
@@ -104,14 +111,19 @@ func (cs *categoryService) GetCategoryByID(id uint) (*response.CategoryResponse,
 		Name:        category.Name,
 		IconURL:     category.IconURL,
 		Description: category.Description,
+		Station:     category.Station,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}, nil
 }
 
-func (cs *categoryService) CreateCategory(req request.CreateCategoryRequest) (*response.CategoryResponse, error) {
+func (cs *categoryService) CreateCategory(ctx context.Context, req request.CreateCategoryRequest) (*response.CategoryResponse, error) {
 	cs.log.Info("Creating new category", zap.String("name", req.Name))
 
+	if err := checkImageURL(cs.storage, req.IconURL, ""); err != nil {
+		return nil, err
+	}
+
 	// Check if category name already exists
 	existingCategory, err := cs.categoryRepo.FindByName(req.Name)
 	if err != nil {
@@ -129,9 +141,15 @@ func (cs *categoryService) CreateCategory(req request.CreateCategoryRequest) (*r
 		Name:        req.Name,
 		IconURL:     req.IconURL,
 		Description: req.Description,
+		Station:     normalizeStation(req.Station),
 	}
 
-	err = cs.categoryRepo.Create(category)
+	err = cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := cs.categoryRepo.Create(ctx, category); err != nil {
+			return err
+		}
+		return recordAudit(ctx, cs.auditRepo, entity.AuditActionCreate, auditEntityCategory, category.ID, nil, category)
+	})
 	if err != nil {
 		cs.log.Error("Failed to create category in repository", zap.Error(err))
 		return nil, err
@@ -146,12 +164,13 @@ func (cs *categoryService) CreateCategory(req request.CreateCategoryRequest) (*r
 		Name:        category.Name,
 		IconURL:     category.IconURL,
 		Description: category.Description,
+		Station:     category.Station,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}, nil
 }
 
-func (cs *categoryService) UpdateCategory(id uint, req request.UpdateCategoryRequest) (*response.CategoryResponse, error) {
+func (cs *categoryService) UpdateCategory(ctx context.Context, id uint, req request.UpdateCategoryRequest) (*response.CategoryResponse, error) {
 	cs.log.Info("Updating category", zap.Uint("id", id))
 
 	// Get existing category
@@ -165,6 +184,11 @@ func (cs *categoryService) UpdateCategory(id uint, req request.UpdateCategoryReq
 		cs.log.Warn("Category not found for update", zap.Uint("id", id))
 		return nil, utils.ErrCategoryNotFound
 	}
+	before := *category
+
+	if err := checkImageURL(cs.storage, req.IconURL, category.IconURL); err != nil {
+		return nil, err
+	}
 
 	// Check if new name already exists (if name is being changed)
 	if req.Name != "" && req.Name != category.Name {
@@ -179,9 +203,9 @@ func (cs *categoryService) UpdateCategory(id uint, req request.UpdateCategoryReq
 		}
 		category.Name = req.Name
 	}
-	if req.Name == "" && req.IconURL == "" && req.Description == "" {
+	if req.Name == "" && req.IconURL == "" && req.Description == "" && req.Station == "" {
 		cs.log.Warn("No changes provided for update", zap.Uint("id", id))
-		return nil, errors.New("no changes provided")
+		return nil, utils.ErrNoChangesProvided
 	}
 
 	// Update other fields if provided
@@ -191,8 +215,16 @@ func (cs *categoryService) UpdateCategory(id uint, req request.UpdateCategoryReq
 	if req.Description != "" {
 		category.Description = req.Description
 	}
+	if req.Station != "" {
+		category.Station = normalizeStation(req.Station)
+	}
 
-	err = cs.categoryRepo.Update(category)
+	err = cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := cs.categoryRepo.Update(ctx, category); err != nil {
+			return err
+		}
+		return recordAudit(ctx, cs.auditRepo, entity.AuditActionUpdate, auditEntityCategory, id, before, category)
+	})
 	if err != nil {
 		cs.log.Error("Failed to update category in repository", zap.Error(err))
 		return nil, err
@@ -205,12 +237,13 @@ func (cs *categoryService) UpdateCategory(id uint, req request.UpdateCategoryReq
 		Name:        category.Name,
 		IconURL:     category.IconURL,
 		Description: category.Description,
+		Station:     category.Station,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}, nil
 }
 
-func (cs *categoryService) DeleteCategory(id uint) error {
+func (cs *categoryService) DeleteCategory(ctx context.Context, id uint) error {
 	cs.log.Info("Deleting category", zap.Uint("id", id))
 
 	// Check if category exists
@@ -238,7 +271,12 @@ func (cs *categoryService) DeleteCategory(id uint) error {
 	}
 
 	// Perform soft delete
-	err = cs.categoryRepo.SoftDelete(id)
+	err = cs.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := cs.categoryRepo.SoftDelete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, cs.auditRepo, entity.AuditActionDelete, auditEntityCategory, id, category, nil)
+	})
 	if err != nil {
 		cs.log.Error("Failed to delete category from repository", zap.Error(err))
 		return err
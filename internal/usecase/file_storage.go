@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/pkg/utils"
+)
+
+// FileStorage keeps uploaded files under keys like "products/12/<name>.jpg".
+type FileStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL is the public address of the file stored under key.
+	URL(key string) string
+	// Key reverses URL. It reports false for addresses of files kept
+	// elsewhere, such as links entered by hand.
+	Key(url string) (string, bool)
+}
+
+// checkImageURL refuses a link into the file storage unless it is the one
+// already saved. Stored images are only set by uploading, so a stored file
+// always belongs to the entity it is set on.
+func checkImageURL(storage FileStorage, url, current string) error {
+	if storage == nil || url == "" || url == current {
+		return nil
+	}
+	if _, ok := storage.Key(url); ok {
+		return utils.ErrStoredImageURL
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Ingredient logs for stock added by hand use this reference type; they
+// point at no document.
+const manualReferenceType = "manual"
+
+type IngredientService interface {
+	CreateIngredient(ctx context.Context, req request.CreateIngredientRequest) (*response.IngredientResponse, error)
+	GetIngredients(ctx context.Context, req request.GetIngredientsRequest) ([]response.IngredientResponse, response.PaginationMeta, error)
+	GetIngredientByID(ctx context.Context, id uint) (*response.IngredientResponse, error)
+	UpdateIngredient(ctx context.Context, id uint, req request.UpdateIngredientRequest) (*response.IngredientResponse, error)
+	DeleteIngredient(ctx context.Context, id uint) error
+	AdjustStock(ctx context.Context, id uint, req request.AdjustIngredientStockRequest) (*response.IngredientLogResponse, error)
+	GetIngredientLogs(ctx context.Context, id uint, req request.PaginationRequest) ([]response.IngredientLogResponse, response.PaginationMeta, error)
+	GetProductRecipe(ctx context.Context, productID uint) ([]response.RecipeItemResponse, error)
+	SetProductRecipe(ctx context.Context, productID uint, req request.SetRecipeRequest) ([]response.RecipeItemResponse, error)
+	GetModifierRecipe(ctx context.Context, optionID uint) ([]response.RecipeItemResponse, error)
+	SetModifierRecipe(ctx context.Context, optionID uint, req request.SetRecipeRequest) ([]response.RecipeItemResponse, error)
+}
+
+type ingredientService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewIngredientService(tx TxManager, repo *repository.Repository, log *zap.Logger) IngredientService {
+	return &ingredientService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "ingredient")),
+	}
+}
+
+func (s *ingredientService) CreateIngredient(ctx context.Context, req request.CreateIngredientRequest) (*response.IngredientResponse, error) {
+	s.log.Info("Creating ingredient", zap.String("name", req.Name))
+
+	if existing, err := s.repo.IngredientRepo.FindByName(ctx, req.Name); err == nil && existing != nil {
+		return nil, utils.ErrIngredientExists
+	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	ingredient := &entity.Ingredient{
+		Name:        req.Name,
+		Unit:        entity.IngredientUnit(req.Unit),
+		Stock:       req.Stock,
+		MinStock:    req.MinStock,
+		CostPerUnit: req.CostPerUnit,
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.IngredientRepo.Create(ctx, ingredient); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityIngredient, ingredient.ID, nil, ingredient); err != nil {
+			return err
+		}
+
+		if ingredient.Stock == 0 {
+			return nil
+		}
+
+		userID, _ := ctx.Value("user_id").(uint)
+		_, err := s.repo.IngredientRepo.CreateLog(ctx, &entity.IngredientLog{
+			IngredientID:      ingredient.ID,
+			Type:              entity.InventoryLogTypeInitial,
+			QuantityChange:    ingredient.Stock,
+			CurrentStockAfter: ingredient.Stock,
+			ReferenceType:     "initial",
+			CreatedBy:         userID,
+		})
+		return err
+	})
+	if err != nil {
+		s.log.Error("Failed to create ingredient", zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.IngredientToResponse(ingredient)
+	return &resp, nil
+}
+
+func (s *ingredientService) GetIngredients(ctx context.Context, req request.GetIngredientsRequest) ([]response.IngredientResponse, response.PaginationMeta, error) {
+	ingredients, total, err := s.repo.IngredientRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get ingredients", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.IngredientResponse, 0, len(ingredients))
+	for _, i := range ingredients {
+		result = append(result, response.IngredientToResponse(&i))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *ingredientService) GetIngredientByID(ctx context.Context, id uint) (*response.IngredientResponse, error) {
+	ingredient, err := s.findIngredient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.IngredientToResponse(ingredient)
+	return &resp, nil
+}
+
+func (s *ingredientService) UpdateIngredient(ctx context.Context, id uint, req request.UpdateIngredientRequest) (*response.IngredientResponse, error) {
+	ingredient, err := s.findIngredient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *ingredient
+
+	if req.Name != "" && req.Name != ingredient.Name {
+		existing, err := s.repo.IngredientRepo.FindByName(ctx, req.Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if existing != nil && existing.ID != ingredient.ID {
+			return nil, utils.ErrIngredientExists
+		}
+		ingredient.Name = req.Name
+	}
+	if req.Unit != "" {
+		ingredient.Unit = entity.IngredientUnit(req.Unit)
+	}
+	if req.MinStock != nil {
+		ingredient.MinStock = *req.MinStock
+	}
+	if req.CostPerUnit != nil {
+		ingredient.CostPerUnit = *req.CostPerUnit
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.IngredientRepo.Update(ctx, ingredient); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityIngredient, id, before, ingredient)
+	})
+	if err != nil {
+		s.log.Error("Failed to update ingredient", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.IngredientToResponse(ingredient)
+	return &resp, nil
+}
+
+func (s *ingredientService) DeleteIngredient(ctx context.Context, id uint) error {
+	ingredient, err := s.findIngredient(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.IngredientRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityIngredient, id, ingredient, nil)
+	})
+	if err != nil {
+		s.log.Error("Failed to delete ingredient", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Ingredient deleted", zap.Uint("id", id))
+	return nil
+}
+
+func (s *ingredientService) AdjustStock(ctx context.Context, id uint, req request.AdjustIngredientStockRequest) (*response.IngredientLogResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	if req.Action == "restock" && req.QuantityChange <= 0 {
+		return nil, utils.ErrInvalidRestock
+	}
+
+	if _, err := s.findIngredient(ctx, id); err != nil {
+		return nil, err
+	}
+
+	log := &entity.IngredientLog{
+		IngredientID:   id,
+		QuantityChange: req.QuantityChange,
+		Notes:          req.Notes,
+		CreatedBy:      userID,
+	}
+
+	switch req.Action {
+	case "restock":
+		log.Type = entity.InventoryLogTypeIn
+		log.ReferenceType = manualReferenceType
+	case "adjustment":
+		log.Type = entity.InventoryLogTypeAdjustment
+		log.ReferenceType = "adjustment"
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ingredient, err := s.repo.IngredientRepo.AdjustStock(ctx, id, req.QuantityChange)
+		if err != nil {
+			return err
+		}
+		log.CurrentStockAfter = ingredient.Stock
+
+		if _, err := s.repo.IngredientRepo.CreateLog(ctx, log); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityIngredientLog, log.ID, nil, log)
+	})
+	if err != nil {
+		s.log.Error("Failed to adjust ingredient stock", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.IngredientLogToResponse(log)
+	return &resp, nil
+}
+
+func (s *ingredientService) GetIngredientLogs(ctx context.Context, id uint, req request.PaginationRequest) ([]response.IngredientLogResponse, response.PaginationMeta, error) {
+	if _, err := s.findIngredient(ctx, id); err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	logs, total, err := s.repo.IngredientRepo.FindLogs(ctx, id, req)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.IngredientLogResponse, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, response.IngredientLogToResponse(&l))
+	}
+
+	return result, paginationMeta(req, total), nil
+}
+
+func (s *ingredientService) GetProductRecipe(ctx context.Context, productID uint) ([]response.RecipeItemResponse, error) {
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	items, err := s.repo.RecipeRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipeToResponse(items), nil
+}
+
+func (s *ingredientService) SetProductRecipe(ctx context.Context, productID uint, req request.SetRecipeRequest) ([]response.RecipeItemResponse, error) {
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		previous, err := s.repo.RecipeRepo.FindByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		items, err := s.buildRecipe(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.RecipeRepo.ReplaceForProduct(ctx, productID, items); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProductRecipe, productID,
+			recipeSnapshot(previous), recipeSnapshot(items))
+	})
+	if err != nil {
+		s.log.Error("Failed to set product recipe", zap.Uint("product_id", productID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Product recipe updated", zap.Uint("product_id", productID), zap.Int("items", len(req.Items)))
+	return s.GetProductRecipe(ctx, productID)
+}
+
+func (s *ingredientService) GetModifierRecipe(ctx context.Context, optionID uint) ([]response.RecipeItemResponse, error) {
+	if _, err := s.repo.ModifierRepo.FindOptionByID(ctx, optionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrModifierOptionNotFound
+		}
+		return nil, err
+	}
+
+	items, err := s.repo.RecipeRepo.FindByModifierOptionID(ctx, optionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recipeToResponse(items), nil
+}
+
+func (s *ingredientService) SetModifierRecipe(ctx context.Context, optionID uint, req request.SetRecipeRequest) ([]response.RecipeItemResponse, error) {
+	if _, err := s.repo.ModifierRepo.FindOptionByID(ctx, optionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrModifierOptionNotFound
+		}
+		return nil, err
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		previous, err := s.repo.RecipeRepo.FindByModifierOptionID(ctx, optionID)
+		if err != nil {
+			return err
+		}
+		items, err := s.buildRecipe(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.RecipeRepo.ReplaceForModifierOption(ctx, optionID, items); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityModifierRecipe, optionID,
+			recipeSnapshot(previous), recipeSnapshot(items))
+	})
+	if err != nil {
+		s.log.Error("Failed to set modifier recipe", zap.Uint("option_id", optionID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Modifier recipe updated", zap.Uint("option_id", optionID), zap.Int("items", len(req.Items)))
+	return s.GetModifierRecipe(ctx, optionID)
+}
+
+func (s *ingredientService) buildRecipe(ctx context.Context, req request.SetRecipeRequest) ([]entity.RecipeItem, error) {
+	seen := make(map[uint]bool)
+	items := make([]entity.RecipeItem, 0, len(req.Items))
+
+	for _, r := range req.Items {
+		if seen[r.IngredientID] {
+			return nil, utils.ErrDuplicateRecipeItem
+		}
+		seen[r.IngredientID] = true
+
+		if _, err := s.findIngredient(ctx, r.IngredientID); err != nil {
+			return nil, err
+		}
+
+		items = append(items, entity.RecipeItem{
+			IngredientID: r.IngredientID,
+			Quantity:     r.Quantity,
+		})
+	}
+
+	return items, nil
+}
+
+func (s *ingredientService) findIngredient(ctx context.Context, id uint) (*entity.Ingredient, error) {
+	ingredient, err := s.repo.IngredientRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrIngredientNotFound
+		}
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// recipeSnapshot keys recipe quantities by ingredient so the audit diff shows
+// which ingredients changed.
+func recipeSnapshot(items []entity.RecipeItem) map[string]any {
+	snapshot := make(map[string]any, len(items))
+	for _, item := range items {
+		snapshot[fmt.Sprintf("ingredient_%d", item.IngredientID)] = item.Quantity
+	}
+	return snapshot
+}
+
+func recipeToResponse(items []entity.RecipeItem) []response.RecipeItemResponse {
+	result := make([]response.RecipeItemResponse, 0, len(items))
+	for _, item := range items {
+		result = append(result, response.RecipeItemToResponse(&item))
+	}
+	return result
+}
+
+func paginationMeta(req request.PaginationRequest, total int64) response.PaginationMeta {
+	totalPages := 0
+	if req.GetPerPage() > 0 && total > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(req.GetPerPage())))
+	}
+
+	return response.PaginationMeta{
+		Page:       req.GetPage(),
+		PerPage:    req.GetPerPage(),
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
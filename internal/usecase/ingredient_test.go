@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngredientService_AdjustStock_RestockMustAdd(t *testing.T) {
+	s := &ingredientService{}
+	ctx := actorContext(1, entity.RoleAdmin)
+
+	for _, qty := range []float64{0, -2} {
+		_, err := s.AdjustStock(ctx, 1, request.AdjustIngredientStockRequest{Action: "restock", QuantityChange: qty})
+		require.ErrorIs(t, err, utils.ErrInvalidRestock)
+	}
+}
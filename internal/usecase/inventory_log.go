@@ -2,18 +2,24 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type InventoryLogService interface {
 	GetInventoryLogs(ctx context.Context, req request.InventoryLogsFilter) (*response.PaginatedResponse[response.InventoryLogResponse], error)
 	CreateInventoryLog(ctx context.Context, req request.CreateInventoryLogRequest) (*response.InventoryLogResponse, error)
+	GetProductStockHistory(ctx context.Context, productID uint, req request.StockHistoryRequest) (*response.ProductStockHistoryResponse, error)
+	ExportInventoryLogs(ctx context.Context, req request.InventoryLogsFilter, w utils.ExportWriter) error
 }
 
 type inventoryLogService struct {
@@ -31,10 +37,23 @@ func NewInventoryLogService(tx TxManager, repo *repository.Repository, log *zap.
 }
 
 func (s *inventoryLogService) GetInventoryLogs(ctx context.Context, req request.InventoryLogsFilter) (*response.PaginatedResponse[response.InventoryLogResponse], error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
 	// Construct params
 	params := repository.InventoryLogParams{
 		Offset: req.GetOffset(),
 		Limit: req.GetPerPage(),
+		ProductID: req.ProductID,
+		Type: req.Type,
+		ReferenceType: req.ReferenceType,
+		CreatedBy: req.CreatedBy,
+		StartDate: start,
+		EndDate: end,
+		SortBy: req.SortBy,
+		SortOrder: req.SortOrder,
 	}
 
 	logs, total, err := s.repo.InventoryLogRepo.GetInventoryLogs(ctx, params)
@@ -79,18 +98,18 @@ func (s *inventoryLogService) CreateInventoryLog(ctx context.Context, req reques
 	}
 
 	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
-		// Get current stock
-		// product, err := s.repo.ProductRepo.GetProductByID(ctx, req.ProductID)
-		// if err != nil {
-		// 	return err
-		// }
-
-		// // Construct inventory log
-		// inventory.CurrentStockAfter = product.Stock + inventory.QuantityChange
+		// Apply the change to the product and record the resulting stock
+		product, err := s.repo.Product.AdjustStock(ctx, req.ProductID, req.QuantityChange)
+		if err != nil {
+			return err
+		}
+		inventory.CurrentStockAfter = product.Stock
 
+		// Goods from a purchase order are booked by receiving the order,
+		// so a manual restock never points at one
 		if req.Action == "restock" {
 			inventory.Type = entity.InventoryLogTypeIn
-			inventory.ReferenceType = "purchase"
+			inventory.ReferenceType = purchaseReferenceType
 		}
 
 		if req.Action == "adjustment" {
@@ -103,7 +122,7 @@ func (s *inventoryLogService) CreateInventoryLog(ctx context.Context, req reques
 			return err
 		}
 		inventory.ID = log.ID
-		return nil
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityInventoryLog, inventory.ID, nil, inventory)
 	})
 
 	if err != nil {
@@ -118,6 +137,7 @@ func (s *inventoryLogService) CreateInventoryLog(ctx context.Context, req reques
 		Type: inventory.Type,
 		QuantityChange: inventory.QuantityChange,
 		CurrentStockAfter: inventory.CurrentStockAfter,
+		ReferenceID: inventory.ReferenceID,
 		ReferenceType: inventory.ReferenceType,
 		Notes: inventory.Notes,
 		CreatedBy: inventory.CreatedBy,
@@ -125,4 +145,162 @@ func (s *inventoryLogService) CreateInventoryLog(ctx context.Context, req reques
 	}
 		
 	return &res, nil
-}
\ No newline at end of file
+}
+
+func (s *inventoryLogService) GetProductStockHistory(ctx context.Context, productID uint, req request.StockHistoryRequest) (*response.ProductStockHistoryResponse, error) {
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.InventoryLogRepo.GetProductHistory(ctx, productID, start, end)
+	if err != nil {
+		s.log.Error("Error get product stock history", zap.Uint("product_id", productID), zap.Error(err))
+		return nil, err
+	}
+
+	// Stock carried into the range is whatever the last earlier log left
+	openingStock := 0
+	if start != nil {
+		last, err := s.repo.InventoryLogRepo.GetLastLogBefore(ctx, productID, *start)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if last != nil {
+			openingStock = last.CurrentStockAfter
+		}
+	}
+
+	interval := req.Interval
+	if interval == "" {
+		interval = "entry"
+	}
+
+	return &response.ProductStockHistoryResponse{
+		ProductID:    product.ID,
+		ProductName:  product.Name,
+		CurrentStock: product.Stock,
+		OpeningStock: openingStock,
+		Interval:     interval,
+		Points:       buildStockHistory(logs, interval),
+	}, nil
+}
+
+// buildStockHistory turns inventory logs (oldest first) into a stock series.
+// With the "day" interval each point is the closing stock of that day and
+// the sum of the day's movements.
+func buildStockHistory(logs []entity.InventoryLog, interval string) []response.StockHistoryPoint {
+	points := make([]response.StockHistoryPoint, 0, len(logs))
+
+	for _, l := range logs {
+		if interval != "day" {
+			points = append(points, response.StockHistoryPoint{
+				Time:           l.CreatedAt,
+				Stock:          l.CurrentStockAfter,
+				QuantityChange: l.QuantityChange,
+				Type:           l.Type,
+				ReferenceID:    l.ReferenceID,
+				ReferenceType:  l.ReferenceType,
+			})
+			continue
+		}
+
+		y, m, d := l.CreatedAt.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, l.CreatedAt.Location())
+
+		if n := len(points); n > 0 && points[n-1].Time.Equal(day) {
+			points[n-1].Stock = l.CurrentStockAfter
+			points[n-1].QuantityChange += l.QuantityChange
+			continue
+		}
+
+		points = append(points, response.StockHistoryPoint{
+			Time:           day,
+			Stock:          l.CurrentStockAfter,
+			QuantityChange: l.QuantityChange,
+		})
+	}
+
+	return points
+}
+
+// ExportInventoryLogs writes every log matching the filters, oldest first.
+func (s *inventoryLogService) ExportInventoryLogs(ctx context.Context, req request.InventoryLogsFilter, w utils.ExportWriter) error {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return err
+	}
+
+	params := repository.InventoryLogParams{
+		ProductID:     req.ProductID,
+		Type:          req.Type,
+		ReferenceType: req.ReferenceType,
+		CreatedBy:     req.CreatedBy,
+		StartDate:     start,
+		EndDate:       end,
+	}
+
+	if err := w.WriteRow("id", "created_at", "product_id", "product_name", "type", "quantity_change", "current_stock_after", "reference_type", "reference_id", "created_by", "notes"); err != nil {
+		return err
+	}
+
+	err = s.repo.InventoryLogRepo.StreamInventoryLogs(ctx, params, func(logs []entity.InventoryLog) error {
+		for _, l := range logs {
+			referenceID := ""
+			if l.ReferenceID != nil {
+				referenceID = strconv.FormatUint(uint64(*l.ReferenceID), 10)
+			}
+			err := w.WriteRow(l.ID, l.CreatedAt, l.ProductID, l.Product.Name, string(l.Type), l.QuantityChange,
+				l.CurrentStockAfter, l.ReferenceType, referenceID, l.CreatedBy, l.Notes)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Error export inventory logs service", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// parseDateRange parses inclusive YYYY-MM-DD bounds into [start, end) times.
+func parseDateRange(startDate, endDate string) (*time.Time, *time.Time, error) {
+	return parseDateRangeIn(startDate, endDate, time.Local)
+}
+
+// parseDateRangeIn is parseDateRange with the dates taken in loc.
+func parseDateRangeIn(startDate, endDate string, loc *time.Location) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+
+	if startDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", startDate, loc)
+		if err != nil {
+			return nil, nil, utils.ErrInvalidDateFormat
+		}
+		start = &t
+	}
+	if endDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", endDate, loc)
+		if err != nil {
+			return nil, nil, utils.ErrInvalidDateFormat
+		}
+		t = t.AddDate(0, 0, 1)
+		end = &t
+	}
+	if start != nil && end != nil && !start.Before(*end) {
+		return nil, nil, utils.ErrInvalidDateRange
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuildStockHistory_Daily(t *testing.T) {
+	require := require.New(t)
+
+	day1 := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 1, 11, 15, 0, 0, 0, time.UTC)
+
+	logs := []entity.InventoryLog{
+		{CreatedAt: day1, QuantityChange: 20, CurrentStockAfter: 20, Type: entity.InventoryLogTypeIn},
+		{CreatedAt: day1.Add(3 * time.Hour), QuantityChange: -5, CurrentStockAfter: 15, Type: entity.InventoryLogTypeOut},
+		{CreatedAt: day2, QuantityChange: -2, CurrentStockAfter: 13, Type: entity.InventoryLogTypeAdjustment},
+	}
+
+	entries := buildStockHistory(logs, "entry")
+	require.Len(entries, 3)
+	require.Equal(15, entries[1].Stock)
+
+	daily := buildStockHistory(logs, "day")
+	require.Len(daily, 2)
+	require.Equal(15, daily[0].Stock)
+	require.Equal(15, daily[0].QuantityChange)
+	require.Equal(13, daily[1].Stock)
+	require.Equal(-2, daily[1].QuantityChange)
+}
+
+func TestParseDateRange(t *testing.T) {
+	require := require.New(t)
+
+	start, end, err := parseDateRange("2025-01-10", "2025-01-10")
+	require.NoError(err)
+	require.Equal(24*time.Hour, end.Sub(*start))
+
+	_, _, err = parseDateRange("2025-01-11", "2025-01-10")
+	require.ErrorIs(err, utils.ErrInvalidDateRange)
+
+	_, _, err = parseDateRange("10-01-2025", "")
+	require.ErrorIs(err, utils.ErrInvalidDateFormat)
+}
+
+func TestInventoryLogService_CreateInventoryLog_MovesProductStock(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	productRepo := new(mocks.ProductRepoMock)
+	logRepo := new(mocks.InventoryLogRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{Product: productRepo, InventoryLogRepo: logRepo, AuditLogRepo: auditRepo}
+	service := NewInventoryLogService(tx, &repo, zap.NewNop())
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	productRepo.On("AdjustStock", mock.Anything, uint(4), 12).Return(&entity.Product{Stock: 30}, nil)
+	logRepo.On("CreateInventoryLog", mock.Anything, mock.MatchedBy(func(l *entity.InventoryLog) bool {
+		return l.ProductID == 4 && l.Type == entity.InventoryLogTypeIn && l.CurrentStockAfter == 30 &&
+			l.ReferenceType == purchaseReferenceType && l.ReferenceID == nil
+	})).Return(&entity.InventoryLog{ID: 9}, nil)
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := service.CreateInventoryLog(actorContext(1, entity.RoleAdmin), request.CreateInventoryLogRequest{
+		ProductID:      4,
+		Action:         "restock",
+		QuantityChange: 12,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint(9), res.ID)
+	require.Equal(t, 30, res.CurrentStockAfter)
+
+	productRepo.AssertExpectations(t)
+	logRepo.AssertExpectations(t)
+}
+
+func TestCreateInventoryLogRequest_RejectsUnknownAction(t *testing.T) {
+	require.Error(t, utils.Validate(request.CreateInventoryLogRequest{ProductID: 1, Action: "sale", QuantityChange: -3}))
+	require.Error(t, utils.Validate(request.CreateInventoryLogRequest{ProductID: 1, QuantityChange: 3}))
+	require.NoError(t, utils.Validate(request.CreateInventoryLogRequest{ProductID: 1, Action: "restock", QuantityChange: 3}))
+}
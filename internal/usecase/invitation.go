@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	content "project-POS-APP-golang-integer/pkg/utils/email"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const defaultInvitationTTL = 72 * time.Hour
+
+func (s *userService) invitationTTL() time.Duration {
+	if s.config.Invitation.ExpiryHours > 0 {
+		return time.Duration(s.config.Invitation.ExpiryHours) * time.Hour
+	}
+	return defaultInvitationTTL
+}
+
+func (s *userService) invitationURL() string {
+	if s.config.Invitation.URL != "" {
+		return s.config.Invitation.URL
+	}
+	return strings.TrimSuffix(s.config.BaseURL, "/") + "/accept-invitation"
+}
+
+// invitationLink builds the emailed link to the accept page carrying the
+// single-use token.
+func invitationLink(base, token string) string {
+	query := url.Values{}
+	query.Set("token", token)
+
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + query.Encode()
+}
+
+// newInvitation returns a fresh token and the invitation that stores its
+// hash, sent by the user behind ctx. UserID is left for the caller.
+func (s *userService) newInvitation(ctx context.Context) (string, *entity.Invitation, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+
+	invitation := &entity.Invitation{
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(s.invitationTTL()),
+	}
+	if actorID, ok := ctx.Value("user_id").(uint); ok {
+		invitation.InvitedBy = &actorID
+	}
+	return token, invitation, nil
+}
+
+func (s *userService) sendInvitation(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	loc := utils.LoadLocation(s.config.BusinessRules.Timezone)
+	err := s.email.Send(ctx, request.EmailRequest{
+		To:      user.Email,
+		Subject: "You Have Been Invited",
+		Body: content.Invitation(response.InvitationEmail{
+			Email:     user.Email,
+			Role:      user.Role,
+			Link:      invitationLink(s.invitationURL(), token),
+			ExpiresAt: expiresAt.In(loc),
+		}),
+	})
+	if err != nil {
+		s.log.Error("Error send invitation email", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+	return err
+}
+
+// findManageableUser loads a user the user behind ctx may manage.
+func (s *userService) findManageableUser(ctx context.Context, id uint) (*entity.User, error) {
+	user, err := s.repo.UserRepo.GetUserByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, utils.ErrUserNotFound) {
+			s.log.Error("Error get user by id", zap.Error(err))
+		}
+		return nil, err
+	}
+	if err := checkManageable(ctx, user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ResendInvitation emails a new invitation to a user who has not joined yet.
+// Links sent before stop working.
+func (s *userService) ResendInvitation(ctx context.Context, id uint) (*response.InvitationResponse, error) {
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.repo.InvitationRepo.FindLatestByUser(ctx, user.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrNoPendingInvitation
+	}
+	if err != nil {
+		s.log.Error("Error find invitation", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+	if latest.AcceptedAt != nil {
+		return nil, utils.ErrNoPendingInvitation
+	}
+
+	token, invitation, err := s.newInvitation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitation.UserID = user.ID
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.InvitationRepo.RevokeByUser(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := s.repo.InvitationRepo.Create(ctx, invitation); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityInvitation, invitation.ID, nil, invitation)
+	})
+	if err != nil {
+		s.log.Error("Error resend invitation", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.sendInvitation(ctx, user, token, invitation.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Invitation resent", zap.Uint("user_id", user.ID))
+	return &response.InvitationResponse{
+		Email:     user.Email,
+		Role:      user.Role,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// RevokeInvitation voids the outstanding invitation of a user. The account
+// stays without a usable password until an invitation is resent or the user
+// is deleted.
+func (s *userService) RevokeInvitation(ctx context.Context, id uint) error {
+	user, err := s.findManageableUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pending, err := s.repo.InvitationRepo.FindLatestByUser(ctx, user.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrNoPendingInvitation
+		}
+		if err != nil {
+			return err
+		}
+
+		revoked, err := s.repo.InvitationRepo.RevokeByUser(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if revoked == 0 {
+			return utils.ErrNoPendingInvitation
+		}
+
+		after := *pending
+		revokedAt := time.Now()
+		after.RevokedAt = &revokedAt
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityInvitation, pending.ID, pending, &after)
+	})
+	if err != nil {
+		if !errors.Is(err, utils.ErrNoPendingInvitation) {
+			s.log.Error("Error revoke invitation", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	s.log.Info("Invitation revoked", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// findInvitation returns the valid invitation for token with its user.
+func (s *userService) findInvitation(ctx context.Context, token string) (*entity.Invitation, *entity.User, error) {
+	invitation, err := s.repo.InvitationRepo.FindValidByTokenHash(ctx, utils.HashToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, utils.ErrInvalidInvitation
+	}
+	if err != nil {
+		s.log.Error("Error find invitation", zap.Error(err))
+		return nil, nil, err
+	}
+
+	// A deleted user's invitation is void
+	user, err := s.repo.UserRepo.GetUserByID(ctx, invitation.UserID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		return nil, nil, utils.ErrInvalidInvitation
+	}
+	if err != nil {
+		s.log.Error("Error get user by id", zap.Error(err))
+		return nil, nil, err
+	}
+	return invitation, &user, nil
+}
+
+// GetInvitation tells the accept page who the invitation is for.
+func (s *userService) GetInvitation(ctx context.Context, token string) (*response.InvitationResponse, error) {
+	invitation, user, err := s.findInvitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.InvitationResponse{
+		Email:     user.Email,
+		Role:      user.Role,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation sets the invited user's password and fills in their
+// profile. The invitation works once.
+func (s *userService) AcceptInvitation(ctx context.Context, req request.AcceptInvitationRequest) error {
+	invitation, user, err := s.findInvitation(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	var birthday time.Time
+	if req.DateOfBirth != "" {
+		birthday, err = time.Parse("2 January 2006", req.DateOfBirth)
+		if err != nil {
+			return utils.ErrInvalidDateFormat
+		}
+	}
+
+	// The changes are the invited user's own
+	ctx = context.WithValue(ctx, "user_id", user.ID)
+	profile := entity.Profile{
+		UserID:      user.ID,
+		FullName:    req.FullName,
+		Phone:       req.Phone,
+		DateOfBirth: birthday,
+		Address:     req.Address,
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Accept first so a concurrent accept with the same token fails
+		if err := s.repo.InvitationRepo.MarkAccepted(ctx, invitation.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidInvitation
+			}
+			return err
+		}
+		if _, err := s.repo.InvitationRepo.RevokeByUser(ctx, user.ID); err != nil {
+			return err
+		}
+
+		if err := s.repo.UserRepo.UpdateUser(ctx, user.ID, &entity.User{ID: user.ID, PasswordHash: utils.HashPassword(req.Password)}); err != nil {
+			return err
+		}
+
+		before, err := s.repo.ProfileRepo.GetProfileByID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.ProfileRepo.UpdateProfile(ctx, &profile); err != nil {
+			return err
+		}
+		after, err := s.repo.ProfileRepo.GetProfileByID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProfile, user.ID, before.Profile, after.Profile)
+	})
+	if err != nil {
+		if !errors.Is(err, utils.ErrInvalidInvitation) {
+			s.log.Error("Error accept invitation", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	s.log.Info("Invitation accepted", zap.Uint("user_id", user.ID))
+	return nil
+}
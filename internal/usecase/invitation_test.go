@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestInvitationLink(t *testing.T) {
+	require.Equal(t, "https://pos.test/join?token=a%2Bb", invitationLink("https://pos.test/join", "a+b"))
+	require.Equal(t, "https://pos.test/join?ref=mail&token=abc", invitationLink("https://pos.test/join?ref=mail", "abc"))
+}
+
+func TestUserService_ResendInvitation_AlreadyJoined(t *testing.T) {
+	ctx := actorContext(1, entity.RoleSuperAdmin)
+	userRepo := new(mocks.UserRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
+	repo := repository.Repository{UserRepo: userRepo, InvitationRepo: invitationRepo}
+	service := NewUserService(new(infra.MockTxManager), &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	accepted := time.Now()
+	userRepo.On("GetUserByID", ctx, uint(2)).Return(entity.User{ID: 2, Role: entity.RoleStaff}, nil)
+	invitationRepo.On("FindLatestByUser", ctx, uint(2)).Return(&entity.Invitation{UserID: 2, AcceptedAt: &accepted}, nil)
+
+	_, err := service.ResendInvitation(ctx, 2)
+	require.ErrorIs(t, err, utils.ErrNoPendingInvitation)
+	invitationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserService_RevokeInvitation_NothingPending(t *testing.T) {
+	ctx := actorContext(1, entity.RoleAdmin)
+	userRepo := new(mocks.UserRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
+	repo := repository.Repository{UserRepo: userRepo, InvitationRepo: invitationRepo}
+	tx := new(infra.MockTxManager)
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	accepted := time.Now()
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.On("GetUserByID", ctx, uint(2)).Return(entity.User{ID: 2, Role: entity.RoleStaff}, nil)
+	invitationRepo.On("FindLatestByUser", ctx, uint(2)).Return(&entity.Invitation{UserID: 2, AcceptedAt: &accepted}, nil)
+	invitationRepo.On("RevokeByUser", ctx, uint(2)).Return(int64(0), nil)
+
+	err := service.RevokeInvitation(ctx, 2)
+	require.ErrorIs(t, err, utils.ErrNoPendingInvitation)
+}
+
+func TestUserService_RevokeInvitation_RecordsAudit(t *testing.T) {
+	ctx := actorContext(1, entity.RoleAdmin)
+	userRepo := new(mocks.UserRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{UserRepo: userRepo, InvitationRepo: invitationRepo, AuditLogRepo: auditRepo}
+	tx := new(infra.MockTxManager)
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.On("GetUserByID", ctx, uint(2)).Return(entity.User{ID: 2, Role: entity.RoleStaff}, nil)
+	invitationRepo.On("FindLatestByUser", ctx, uint(2)).Return(&entity.Invitation{ID: 5, UserID: 2}, nil)
+	invitationRepo.On("RevokeByUser", ctx, uint(2)).Return(int64(1), nil)
+	auditRepo.On("Create", ctx, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return l.EntityType == auditEntityInvitation && l.EntityID == 5 && *l.ActorID == 1
+	})).Return(nil)
+
+	require.NoError(t, service.RevokeInvitation(ctx, 2))
+	auditRepo.AssertExpectations(t)
+}
+
+func TestUserService_AcceptInvitation_InvalidToken(t *testing.T) {
+	invitationRepo := new(mocks.InvitationRepoMock)
+	repo := repository.Repository{InvitationRepo: invitationRepo}
+	service := NewUserService(new(infra.MockTxManager), &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	invitationRepo.On("FindValidByTokenHash", mock.Anything, utils.HashToken("stale")).Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.AcceptInvitation(context.Background(), request.AcceptInvitationRequest{Token: "stale", Password: "secret123", FullName: "Rina"})
+	require.ErrorIs(t, err, utils.ErrInvalidInvitation)
+}
+
+func TestUserService_AcceptInvitation_SetsPasswordAndProfile(t *testing.T) {
+	userRepo := new(mocks.UserRepoMock)
+	profileRepo := new(mocks.ProfileRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	tx := new(infra.MockTxManager)
+	repo := repository.Repository{UserRepo: userRepo, ProfileRepo: profileRepo, InvitationRepo: invitationRepo, AuditLogRepo: auditRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	invitationRepo.On("FindValidByTokenHash", mock.Anything, utils.HashToken("tok")).Return(&entity.Invitation{ID: 5, UserID: 3}, nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(3)).Return(entity.User{ID: 3, Email: "rina@mail.com", Role: entity.RoleStaff}, nil)
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	invitationRepo.On("MarkAccepted", mock.Anything, uint(5)).Return(nil)
+	invitationRepo.On("RevokeByUser", mock.Anything, uint(3)).Return(int64(0), nil)
+	userRepo.On("UpdateUser", mock.Anything, uint(3), mock.MatchedBy(func(u *entity.User) bool {
+		return utils.CheckPassword("secret123", u.PasswordHash)
+	})).Return(nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(3)).Return(&entity.User{ID: 3, Profile: &entity.Profile{UserID: 3}}, nil)
+	profileRepo.On("UpdateProfile", mock.Anything, mock.MatchedBy(func(p *entity.Profile) bool {
+		return p.UserID == 3 && p.FullName == "Rina" && p.DateOfBirth.Year() == 1999
+	})).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := service.AcceptInvitation(context.Background(), request.AcceptInvitationRequest{
+		Token:       "tok",
+		Password:    "secret123",
+		FullName:    "Rina",
+		DateOfBirth: "2 January 1999",
+	})
+	require.NoError(t, err)
+	invitationRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	profileRepo.AssertExpectations(t)
+}
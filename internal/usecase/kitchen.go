@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type KitchenService interface {
+	GetQueue(ctx context.Context, req request.KitchenQueueRequest) ([]response.KitchenStationResponse, error)
+	BumpItem(ctx context.Context, itemID uint) (*response.KitchenBumpResponse, error)
+	RecallItem(ctx context.Context, itemID uint) (*response.KitchenBumpResponse, error)
+}
+
+type kitchenService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewKitchenService(tx TxManager, repo *repository.Repository, log *zap.Logger) KitchenService {
+	return &kitchenService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "kitchen")),
+	}
+}
+
+// GetQueue lists the items still to be prepared, grouped by station.
+func (s *kitchenService) GetQueue(ctx context.Context, req request.KitchenQueueRequest) ([]response.KitchenStationResponse, error) {
+	station := ""
+	if req.Station != "" {
+		station = normalizeStation(req.Station)
+	}
+
+	items, err := s.repo.OrderRepo.FindKitchenItems(ctx, station)
+	if err != nil {
+		s.log.Error("Failed to get kitchen queue", zap.Error(err))
+		return nil, err
+	}
+
+	return groupKitchenItems(items, time.Now()), nil
+}
+
+// BumpItem marks an item as ready. The order moves to cooking, or to
+// completed once every item has been bumped.
+func (s *kitchenService) BumpItem(ctx context.Context, itemID uint) (*response.KitchenBumpResponse, error) {
+	now := time.Now()
+	return s.setBumped(ctx, itemID, &now)
+}
+
+// RecallItem puts a bumped item back on the KDS while its order is still open.
+func (s *kitchenService) RecallItem(ctx context.Context, itemID uint) (*response.KitchenBumpResponse, error) {
+	return s.setBumped(ctx, itemID, nil)
+}
+
+func (s *kitchenService) setBumped(ctx context.Context, itemID uint, bumpedAt *time.Time) (*response.KitchenBumpResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	var result *response.KitchenBumpResponse
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		item, err := s.repo.OrderRepo.FindItemByID(ctx, itemID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderItemNotFound
+			}
+			return err
+		}
+
+		order, err := s.repo.OrderRepo.FindByIDForUpdate(ctx, item.OrderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderNotFound
+			}
+			return err
+		}
+		if order.Status == entity.OrderStatusCompleted || order.Status == entity.OrderStatusCancelled {
+			return utils.ErrOrderNotEditable
+		}
+
+		switch {
+		case bumpedAt != nil && item.BumpedAt != nil:
+			return utils.ErrOrderItemAlreadyBumped
+		case bumpedAt == nil && item.BumpedAt == nil:
+			return utils.ErrOrderItemNotBumped
+		}
+
+		if err := s.repo.OrderRepo.SetItemBumpedAt(ctx, item.ID, bumpedAt); err != nil {
+			return err
+		}
+		bumped := *item
+		bumped.BumpedAt = bumpedAt
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityOrderItem, item.ID, item, bumped); err != nil {
+			return err
+		}
+		for i := range order.OrderItems {
+			if order.OrderItems[i].ID == item.ID {
+				order.OrderItems[i].BumpedAt = bumpedAt
+			}
+		}
+
+		next := kitchenOrderStatus(order.OrderItems)
+		if next != order.Status && canTransitionOrder(order.Status, next) {
+			s.log.Info("Rolling up order status from KDS",
+				zap.Uint("order_id", order.ID),
+				zap.String("from", string(order.Status)),
+				zap.String("to", string(next)))
+
+			before := *order
+			order.Status = next
+			if err := s.repo.OrderRepo.Update(ctx, order); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityOrder, order.ID, before, order); err != nil {
+				return err
+			}
+			if next == entity.OrderStatusCompleted {
+				if err := deductIngredients(ctx, s.repo, s.log, order, userID); err != nil {
+					return err
+				}
+			}
+		}
+
+		result = &response.KitchenBumpResponse{
+			ItemID:      item.ID,
+			OrderID:     order.ID,
+			BumpedAt:    bumpedAt,
+			OrderStatus: string(order.Status),
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to update kitchen item",
+			zap.Uint("item_id", itemID),
+			zap.Bool("bump", bumpedAt != nil),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// kitchenOrderStatus is the status an order rolls up to from its items: it is
+// completed once every item is bumped and cooking until then.
+func kitchenOrderStatus(items []entity.OrderItem) entity.OrderStatus {
+	if len(items) == 0 {
+		return entity.OrderStatusCooking
+	}
+	for _, item := range items {
+		if item.BumpedAt == nil {
+			return entity.OrderStatusCooking
+		}
+	}
+	return entity.OrderStatusCompleted
+}
+
+// groupKitchenItems buckets items by their category's station. Stations are
+// sorted by name and items keep their oldest-first order.
+func groupKitchenItems(items []entity.OrderItem, now time.Time) []response.KitchenStationResponse {
+	byStation := map[string]*response.KitchenStationResponse{}
+	for _, item := range items {
+		station := normalizeStation(item.Product.Category.Station)
+		group, ok := byStation[station]
+		if !ok {
+			group = &response.KitchenStationResponse{Station: station, Items: []response.KitchenItemResponse{}}
+			byStation[station] = group
+		}
+
+		modifiers := make([]string, 0, len(item.Modifiers))
+		for _, m := range item.Modifiers {
+			modifiers = append(modifiers, m.OptionName)
+		}
+
+		elapsed := now.Sub(item.CreatedAt)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+
+		group.Items = append(group.Items, response.KitchenItemResponse{
+			ItemID:         item.ID,
+			OrderID:        item.OrderID,
+			OrderNumber:    item.Order.OrderNumber,
+			OrderStatus:    string(item.Order.Status),
+			TableNumber:    item.Order.Table.TableNumber,
+			ProductName:    item.Product.Name,
+			Quantity:       item.Quantity,
+			Modifiers:      modifiers,
+			Notes:          item.Notes,
+			CreatedAt:      item.CreatedAt,
+			ElapsedSeconds: int64(elapsed / time.Second),
+			ElapsedMinutes: int(elapsed / time.Minute),
+		})
+		group.ItemCount++
+	}
+
+	stations := make([]response.KitchenStationResponse, 0, len(byStation))
+	for _, group := range byStation {
+		stations = append(stations, *group)
+	}
+	sort.Slice(stations, func(i, j int) bool { return stations[i].Station < stations[j].Station })
+
+	return stations
+}
+
+// normalizeStation lower-cases a station name, falling back to the default
+// kitchen station.
+func normalizeStation(station string) string {
+	station = strings.ToLower(strings.TrimSpace(station))
+	if station == "" {
+		return entity.DefaultStation
+	}
+	return station
+}
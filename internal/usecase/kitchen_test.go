@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKitchenOrderStatus(t *testing.T) {
+	bumped := time.Now()
+
+	require.Equal(t, entity.OrderStatusCooking, kitchenOrderStatus([]entity.OrderItem{{BumpedAt: &bumped}, {}}))
+	require.Equal(t, entity.OrderStatusCompleted, kitchenOrderStatus([]entity.OrderItem{{BumpedAt: &bumped}, {BumpedAt: &bumped}}))
+	require.Equal(t, entity.OrderStatusCooking, kitchenOrderStatus(nil))
+}
+
+func TestGroupKitchenItems_GroupsByStationWithElapsedTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	drinks := entity.Category{Station: "Bar"}
+	items := []entity.OrderItem{
+		{ID: 1, CreatedAt: now.Add(-12*time.Minute - 30*time.Second), Product: entity.Product{Name: "Steak", Category: entity.Category{Station: "grill"}}},
+		{ID: 2, CreatedAt: now.Add(-5 * time.Minute), Product: entity.Product{Name: "Latte", Category: drinks}},
+		{ID: 3, CreatedAt: now.Add(-2 * time.Minute), Product: entity.Product{Name: "Fries"}},
+		{ID: 4, CreatedAt: now.Add(-1 * time.Minute), Product: entity.Product{Name: "Tea", Category: drinks},
+			Modifiers: []entity.OrderItemModifier{{OptionName: "Less sugar"}}},
+	}
+
+	stations := groupKitchenItems(items, now)
+
+	require.Len(t, stations, 3)
+	require.Equal(t, "bar", stations[0].Station)
+	require.Equal(t, 2, stations[0].ItemCount)
+	require.Equal(t, uint(2), stations[0].Items[0].ItemID)
+	require.Equal(t, []string{"Less sugar"}, stations[0].Items[1].Modifiers)
+	require.Equal(t, "grill", stations[1].Station)
+	require.Equal(t, int64(750), stations[1].Items[0].ElapsedSeconds)
+	require.Equal(t, 12, stations[1].Items[0].ElapsedMinutes)
+	require.Equal(t, "kitchen", stations[2].Station)
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	content "project-POS-APP-golang-integer/pkg/utils/email"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLoginFreeAttempts    = 3
+	defaultLoginBaseDelay       = time.Second
+	defaultLoginMaxDelay        = time.Minute
+	defaultLoginAccountFailures = 10
+	defaultLoginIPFailures      = 50
+	defaultLoginLockout         = 15 * time.Minute
+	defaultLoginFailureWindow   = time.Hour
+	defaultOTPMaxAttempts       = 5
+	maxLoginDelayShift          = 20
+)
+
+// loginPolicy decides how long a key with recorded failures has to wait
+// before its next login attempt.
+type loginPolicy struct {
+	freeAttempts int
+	baseDelay    time.Duration
+	maxDelay     time.Duration
+	maxFailures  int
+	lockout      time.Duration
+	window       time.Duration
+}
+
+func loginAccountKey(userID uint) string { return fmt.Sprintf("account:%d", userID) }
+func loginIPKey(ip string) string        { return "ip:" + ip }
+
+// emailRequestKey throttles the emails sent to one address. The address is
+// hashed so the throttle table does not list who was targeted.
+func emailRequestKey(email string) string {
+	return "email:" + utils.HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+func emailRequestIPKey(ip string) string { return "email-ip:" + ip }
+
+// loginPolicies returns the policies for accounts and client addresses. They
+// share the delays; addresses tolerate more failures because several users
+// may log in from behind the same address.
+func loginPolicies(cfg utils.LoginProtectionConfig) (account, ip loginPolicy) {
+	account = loginPolicy{
+		freeAttempts: cfg.FreeAttempts,
+		baseDelay:    time.Duration(cfg.BaseDelaySeconds) * time.Second,
+		maxDelay:     time.Duration(cfg.MaxDelaySeconds) * time.Second,
+		maxFailures:  cfg.MaxAccountFailures,
+		lockout:      time.Duration(cfg.LockoutMinutes) * time.Minute,
+		window:       time.Duration(cfg.WindowMinutes) * time.Minute,
+	}
+	if account.freeAttempts <= 0 {
+		account.freeAttempts = defaultLoginFreeAttempts
+	}
+	if account.baseDelay <= 0 {
+		account.baseDelay = defaultLoginBaseDelay
+	}
+	if account.maxDelay <= 0 {
+		account.maxDelay = defaultLoginMaxDelay
+	}
+	if account.maxFailures <= 0 {
+		account.maxFailures = defaultLoginAccountFailures
+	}
+	if account.lockout <= 0 {
+		account.lockout = defaultLoginLockout
+	}
+	if account.window <= 0 {
+		account.window = defaultLoginFailureWindow
+	}
+
+	ip = account
+	ip.maxFailures = cfg.MaxIPFailures
+	if ip.maxFailures <= 0 {
+		ip.maxFailures = defaultLoginIPFailures
+	}
+	return account, ip
+}
+
+// stale reports whether the recorded failures no longer count, because the
+// last one is outside the window or the lockout they caused has passed.
+func (p loginPolicy) stale(t *entity.LoginThrottle, now time.Time) bool {
+	if t.Failures == 0 || now.Sub(t.LastFailureAt) > p.window {
+		return true
+	}
+	return t.LockedUntil != nil && !now.Before(*t.LockedUntil)
+}
+
+// retryAt returns when the next attempt is allowed and whether the key is
+// locked out until then.
+func (p loginPolicy) retryAt(t *entity.LoginThrottle, now time.Time) (time.Time, bool) {
+	if t.LockedUntil != nil && now.Before(*t.LockedUntil) {
+		return *t.LockedUntil, true
+	}
+	if p.stale(t, now) || t.Failures < p.freeAttempts {
+		return time.Time{}, false
+	}
+
+	shift := t.Failures - p.freeAttempts
+	if shift > maxLoginDelayShift {
+		shift = maxLoginDelayShift
+	}
+	delay := p.baseDelay << shift
+	if delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	return t.LastFailureAt.Add(delay), false
+}
+
+// fail records a failed attempt and reports whether it locked the key.
+func (p loginPolicy) fail(t *entity.LoginThrottle, now time.Time) bool {
+	if p.stale(t, now) {
+		t.Failures = 0
+		t.LockedUntil = nil
+	}
+
+	t.Failures++
+	t.LastFailureAt = now
+	if t.Failures < p.maxFailures {
+		return false
+	}
+
+	lockedUntil := now.Add(p.lockout)
+	t.LockedUntil = &lockedUntil
+	return true
+}
+
+// reserveLoginAttempt counts an attempt against key before the password is
+// checked. lockedErr is returned for a lockout so accounts and addresses can
+// report it differently.
+func (s *authService) reserveLoginAttempt(ctx context.Context, key string, policy loginPolicy, now time.Time, lockedErr error) (*time.Time, error) {
+	return reserveAttempt(ctx, s.tx, s.repo, s.log, key, policy, now, lockedErr)
+}
+
+// releaseLoginAttempt gives back an attempt that turned out to be right.
+func (s *authService) releaseLoginAttempt(ctx context.Context, key string, policy loginPolicy) {
+	releaseAttempt(ctx, s.tx, s.repo, s.log, key, policy)
+}
+
+// reserveAttempt counts an attempt against key under the row lock before the
+// secret is checked, so a burst of parallel guesses cannot all pass the delay
+// check. It fails with lockedErr while key is locked out and with
+// ErrTooManyLoginAttempts while it is delayed. It returns when the key is
+// locked until if this attempt locked it.
+func reserveAttempt(ctx context.Context, tx TxManager, repo *repository.Repository, log *zap.Logger, key string, policy loginPolicy, now time.Time, lockedErr error) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		throttle, err := repo.LoginThrottleRepo.FindForUpdate(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		retryAt, locked := policy.retryAt(throttle, now)
+		if locked {
+			return lockedErr
+		}
+		if now.Before(retryAt) {
+			return utils.ErrTooManyLoginAttempts
+		}
+
+		if policy.fail(throttle, now) {
+			lockedUntil = throttle.LockedUntil
+		}
+		return repo.LoginThrottleRepo.Save(ctx, throttle)
+	})
+	if err != nil {
+		if !errors.Is(err, lockedErr) && !errors.Is(err, utils.ErrTooManyLoginAttempts) {
+			log.Error("Error reserve login attempt", zap.String("key", key), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	if lockedUntil != nil {
+		log.Warn("Login locked after repeated failures", zap.String("key", key), zap.Time("locked_until", *lockedUntil))
+	}
+	return lockedUntil, nil
+}
+
+// releaseAttempt takes back an attempt reserved by reserveAttempt, along with
+// the lockout it caused, once the secret turned out to be right.
+func releaseAttempt(ctx context.Context, tx TxManager, repo *repository.Repository, log *zap.Logger, key string, policy loginPolicy) {
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		throttle, err := repo.LoginThrottleRepo.FindForUpdate(ctx, key)
+		if err != nil {
+			return err
+		}
+		if throttle.Failures == 0 {
+			return nil
+		}
+
+		throttle.Failures--
+		if throttle.Failures < policy.maxFailures {
+			throttle.LockedUntil = nil
+		}
+		return repo.LoginThrottleRepo.Save(ctx, throttle)
+	})
+	if err != nil {
+		log.Error("Error release login attempt", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// throttleEmailRequest counts a request that emails a code to email, against
+// both the address and the client asking. Every request counts, whether or
+// not the email belongs to an account, so anyone cannot flood an inbox and a
+// fresh code with fresh guesses cannot be requested at will.
+func (s *authService) throttleEmailRequest(ctx context.Context, email, ipAddress string) error {
+	now := time.Now()
+	emailPolicy, ipPolicy := loginPolicies(s.config.LoginProtection)
+
+	if err := s.reserveEmailRequest(ctx, emailRequestIPKey(ipAddress), ipPolicy, now); err != nil {
+		return err
+	}
+	return s.reserveEmailRequest(ctx, emailRequestKey(email), emailPolicy, now)
+}
+
+func (s *authService) reserveEmailRequest(ctx context.Context, key string, policy loginPolicy, now time.Time) error {
+	_, err := s.reserveLoginAttempt(ctx, key, policy, now, utils.ErrTooManyEmailRequests)
+	if errors.Is(err, utils.ErrTooManyLoginAttempts) {
+		return utils.ErrTooManyEmailRequests
+	}
+	return err
+}
+
+func (s *authService) notifyLockout(ctx context.Context, user *entity.User, lockedUntil time.Time, ipAddress string) {
+	loc := utils.LoadLocation(s.config.BusinessRules.Timezone)
+
+	err := s.email.Send(ctx, request.EmailRequest{
+		To:      user.Email,
+		Subject: "Account Locked",
+		Body:    content.AccountLocked(lockedUntil.In(loc), ipAddress),
+	})
+	if err != nil {
+		s.log.Error("Error send lockout email", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+}
+
+// UnlockAccount clears the failed logins of a user so they can log in again
+// before their lockout ends.
+func (s *authService) UnlockAccount(ctx context.Context, userID uint) error {
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if actorRole, _ := ctx.Value("user_role").(entity.UserRole); !canManageRole(actorRole, user.Role) {
+		return utils.ErrCannotManageUser
+	}
+
+	key := loginAccountKey(userID)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		throttle, err := s.repo.LoginThrottleRepo.FindForUpdate(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.LoginThrottleRepo.Delete(ctx, key); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityLoginLockout, userID, throttle, nil)
+	})
+	if err != nil {
+		s.log.Error("Error unlock account", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	adminID, _ := ctx.Value("user_id").(uint)
+	s.log.Info("Account unlocked", zap.Uint("user_id", userID), zap.Uint("admin_id", adminID))
+	return nil
+}
+
+func (s *authService) otpMaxAttempts() int {
+	if s.config.LoginProtection.OTPMaxAttempts > 0 {
+		return s.config.LoginProtection.OTPMaxAttempts
+	}
+	return defaultOTPMaxAttempts
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func testLoginPolicy() loginPolicy {
+	account, _ := loginPolicies(utils.LoginProtectionConfig{
+		FreeAttempts:       3,
+		BaseDelaySeconds:   1,
+		MaxDelaySeconds:    8,
+		MaxAccountFailures: 6,
+		LockoutMinutes:     15,
+		WindowMinutes:      60,
+	})
+	return account
+}
+
+func TestLoginPolicy_ProgressiveDelay(t *testing.T) {
+	policy := testLoginPolicy()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	throttle := &entity.LoginThrottle{}
+
+	// The first attempts fail without any delay
+	for i := 0; i < 2; i++ {
+		require.False(t, policy.fail(throttle, now))
+		retryAt, locked := policy.retryAt(throttle, now)
+		require.False(t, locked)
+		require.False(t, now.Before(retryAt))
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	for _, delay := range expected {
+		require.False(t, policy.fail(throttle, now))
+		retryAt, locked := policy.retryAt(throttle, now)
+		require.False(t, locked)
+		require.Equal(t, now.Add(delay), retryAt)
+	}
+}
+
+func TestLoginPolicy_DelayIsCapped(t *testing.T) {
+	policy := testLoginPolicy()
+	policy.maxFailures = 100
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	throttle := &entity.LoginThrottle{Failures: 40, LastFailureAt: now}
+
+	retryAt, _ := policy.retryAt(throttle, now)
+	require.Equal(t, now.Add(8*time.Second), retryAt)
+}
+
+func TestLoginPolicy_LockoutAndExpiry(t *testing.T) {
+	policy := testLoginPolicy()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	throttle := &entity.LoginThrottle{Failures: 5, LastFailureAt: now.Add(-time.Minute)}
+
+	require.True(t, policy.fail(throttle, now))
+	retryAt, locked := policy.retryAt(throttle, now.Add(time.Minute))
+	require.True(t, locked)
+	require.Equal(t, now.Add(15*time.Minute), retryAt)
+
+	// Once the lockout passes the next failure starts a fresh count
+	later := now.Add(16 * time.Minute)
+	_, locked = policy.retryAt(throttle, later)
+	require.False(t, locked)
+	require.False(t, policy.fail(throttle, later))
+	require.Equal(t, 1, throttle.Failures)
+	require.Nil(t, throttle.LockedUntil)
+}
+
+func TestLoginPolicy_OldFailuresAreForgotten(t *testing.T) {
+	policy := testLoginPolicy()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	throttle := &entity.LoginThrottle{Failures: 5, LastFailureAt: now.Add(-2 * time.Hour)}
+
+	retryAt, locked := policy.retryAt(throttle, now)
+	require.False(t, locked)
+	require.True(t, retryAt.IsZero())
+
+	require.False(t, policy.fail(throttle, now))
+	require.Equal(t, 1, throttle.Failures)
+}
+
+func TestLoginPolicies_Defaults(t *testing.T) {
+	account, ip := loginPolicies(utils.LoginProtectionConfig{})
+
+	require.Equal(t, defaultLoginAccountFailures, account.maxFailures)
+	require.Equal(t, defaultLoginIPFailures, ip.maxFailures)
+	require.Equal(t, account.baseDelay, ip.baseDelay)
+	require.Equal(t, defaultLoginLockout, ip.lockout)
+}
+
+func TestReserveAttempt_CountsBeforeTheCheck(t *testing.T) {
+	policy := testLoginPolicy()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tx := new(infra.MockTxManager)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	repo := &repository.Repository{LoginThrottleRepo: throttleRepo}
+	throttle := &entity.LoginThrottle{Key: "account:1", Failures: 2, LastFailureAt: now.Add(-time.Minute)}
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	throttleRepo.On("FindForUpdate", mock.Anything, "account:1").Return(throttle, nil)
+	throttleRepo.On("Save", mock.Anything, throttle).Return(nil)
+
+	// The reserved attempt already delays the next one, before any
+	// password is known to be wrong
+	lockedUntil, err := reserveAttempt(context.Background(), tx, repo, zap.NewNop(), "account:1", policy, now, utils.ErrAccountLocked)
+	require.NoError(t, err)
+	require.Nil(t, lockedUntil)
+	require.Equal(t, 3, throttle.Failures)
+
+	_, err = reserveAttempt(context.Background(), tx, repo, zap.NewNop(), "account:1", policy, now, utils.ErrAccountLocked)
+	require.ErrorIs(t, err, utils.ErrTooManyLoginAttempts)
+	require.Equal(t, 3, throttle.Failures)
+	throttleRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestReleaseAttempt_UndoesTheLockItCaused(t *testing.T) {
+	policy := testLoginPolicy()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tx := new(infra.MockTxManager)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	repo := &repository.Repository{LoginThrottleRepo: throttleRepo}
+	throttle := &entity.LoginThrottle{Key: "account:1", Failures: 5, LastFailureAt: now.Add(-time.Hour / 2)}
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	throttleRepo.On("FindForUpdate", mock.Anything, "account:1").Return(throttle, nil)
+	throttleRepo.On("Save", mock.Anything, throttle).Return(nil)
+
+	lockedUntil, err := reserveAttempt(context.Background(), tx, repo, zap.NewNop(), "account:1", policy, now, utils.ErrAccountLocked)
+	require.NoError(t, err)
+	require.NotNil(t, lockedUntil)
+
+	releaseAttempt(context.Background(), tx, repo, zap.NewNop(), "account:1", policy)
+	require.Equal(t, 5, throttle.Failures)
+	require.Nil(t, throttle.LockedUntil)
+}
+
+func TestAuthService_UnlockAccount_RespectsHierarchy(t *testing.T) {
+	userRepo := new(mocks.UserRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo}
+	service := NewAuthService(new(infra.MockTxManager), repo, zap.NewNop(), nil, utils.Configuration{})
+
+	userRepo.On("GetUserByID", mock.Anything, uint(5)).Return(entity.User{ID: 5, Role: entity.RoleAdmin}, nil)
+
+	err := service.UnlockAccount(actorContext(2, entity.RoleAdmin), 5)
+	require.ErrorIs(t, err, utils.ErrCannotManageUser)
+}
+
+func TestAuthService_UnlockAccount_RecordsAudit(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo, LoginThrottleRepo: throttleRepo, AuditLogRepo: auditRepo}
+	service := NewAuthService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(5)).Return(entity.User{ID: 5, Role: entity.RoleStaff}, nil)
+	throttleRepo.On("FindForUpdate", mock.Anything, "account:5").Return(&entity.LoginThrottle{Key: "account:5", Failures: 10, LockedUntil: &lockedUntil}, nil)
+	throttleRepo.On("Delete", mock.Anything, "account:5").Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return l.Action == entity.AuditActionDelete && l.EntityType == auditEntityLoginLockout &&
+			l.EntityID == 5 && *l.ActorID == 1
+	})).Return(nil)
+
+	require.NoError(t, service.UnlockAccount(actorContext(1, entity.RoleAdmin), 5))
+	throttleRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuthService_RequestResetPassword_ThrottledPerEmail(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo, LoginThrottleRepo: throttleRepo}
+	service := NewAuthService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	throttleRepo.On("FindForUpdate", mock.Anything, emailRequestIPKey("10.0.0.1")).Return(&entity.LoginThrottle{}, nil)
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	// Case does not give an address a fresh budget
+	throttleRepo.On("FindForUpdate", mock.Anything, emailRequestKey("ana@example.com")).
+		Return(&entity.LoginThrottle{Failures: 10, LastFailureAt: time.Now(), LockedUntil: &lockedUntil}, nil)
+
+	err := service.RequestResetPassword(context.Background(), request.ResetPasswordRequest{Email: "Ana@Example.com", IPAddress: "10.0.0.1"})
+	require.ErrorIs(t, err, utils.ErrTooManyEmailRequests)
+	userRepo.AssertNotCalled(t, "FindUserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_UnknownEmailSkipsAccountThrottle(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo, LoginThrottleRepo: throttleRepo}
+	service := NewAuthService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	throttleRepo.On("FindForUpdate", mock.Anything, loginIPKey("10.0.0.1")).Return(&entity.LoginThrottle{}, nil)
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("FindUserByEmail", mock.Anything, "ghost@example.com").Return((*entity.User)(nil), gorm.ErrRecordNotFound)
+
+	_, err := service.Login(context.Background(), request.LoginRequest{Email: "ghost@example.com", Password: "secret123", IPAddress: "10.0.0.1"})
+	require.ErrorIs(t, err, utils.ErrInvalidCredentials)
+	throttleRepo.AssertNotCalled(t, "FindForUpdate", mock.Anything, loginAccountKey(0))
+}
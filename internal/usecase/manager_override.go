@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultPINMaxFailures is lower than for passwords because a PIN has far
+// fewer combinations.
+const defaultPINMaxFailures = 5
+
+func managerPINKey(userID uint) string { return fmt.Sprintf("pin:%d", userID) }
+
+// pinPolicy throttles wrong manager PINs per manager with the login delays.
+func pinPolicy(cfg utils.LoginProtectionConfig) loginPolicy {
+	policy, _ := loginPolicies(cfg)
+	if policy.maxFailures > defaultPINMaxFailures {
+		policy.maxFailures = defaultPINMaxFailures
+	}
+	return policy
+}
+
+// canApproveOverrides reports whether the user behind ctx holds any of the
+// permissions a manager PIN can stand in for.
+func canApproveOverrides(ctx context.Context) bool {
+	for _, name := range entity.OverridePermissions {
+		if HasPermission(ctx, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// managerApprover authorizes privileged order actions. The requester either
+// holds the permission, or a manager who holds it approves with their PIN.
+type managerApprover struct {
+	tx          TxManager
+	repo        *repository.Repository
+	log         *zap.Logger
+	config      utils.Configuration
+	permissions PermissionService
+}
+
+func newManagerApprover(tx TxManager, repo *repository.Repository, log *zap.Logger, config utils.Configuration, permissions PermissionService) *managerApprover {
+	return &managerApprover{
+		tx:          tx,
+		repo:        repo,
+		log:         log.With(zap.String("component", "manager_override")),
+		config:      config,
+		permissions: permissions,
+	}
+}
+
+// approve returns who asked for the action and who allowed it. They are the
+// same user when the requester holds the permission. It must be called
+// outside the transaction of the action so failed PINs stay recorded.
+func (a *managerApprover) approve(ctx context.Context, permission string, override *request.ManagerOverride) (requesterID, approverID uint, err error) {
+	requesterID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return 0, 0, utils.ErrInvalidToken
+	}
+	if HasPermission(ctx, permission) {
+		return requesterID, requesterID, nil
+	}
+	if override == nil {
+		return 0, 0, utils.ErrManagerApprovalRequired
+	}
+
+	key := managerPINKey(override.ManagerID)
+	policy := pinPolicy(a.config.LoginProtection)
+	now := time.Now()
+
+	// The attempt counts before the PIN is checked, so parallel guesses
+	// cannot all slip past the delay
+	if _, err := reserveAttempt(ctx, a.tx, a.repo, a.log, key, policy, now, utils.ErrManagerPINLocked); err != nil {
+		if errors.Is(err, utils.ErrTooManyLoginAttempts) {
+			return 0, 0, utils.ErrManagerPINLocked
+		}
+		return 0, 0, err
+	}
+
+	manager, err := a.repo.UserRepo.GetUserByID(ctx, override.ManagerID)
+	if err != nil && !errors.Is(err, utils.ErrUserNotFound) {
+		releaseAttempt(ctx, a.tx, a.repo, a.log, key, policy)
+		return 0, 0, err
+	}
+	if err != nil || manager.ManagerPIN == "" || !utils.CheckPassword(override.PIN, manager.ManagerPIN) {
+		a.log.Warn("Invalid manager PIN",
+			zap.Uint("requester_id", requesterID),
+			zap.Uint("manager_id", override.ManagerID),
+			zap.String("permission", permission))
+		return 0, 0, utils.ErrInvalidManagerPIN
+	}
+
+	if err := a.repo.LoginThrottleRepo.Delete(ctx, key); err != nil {
+		a.log.Error("Error clear manager PIN failures", zap.Uint("manager_id", manager.ID), zap.Error(err))
+	}
+
+	if !a.permissions.EffectivePermissions(ctx, string(manager.Role), manager.ID).Has(permission) {
+		return 0, 0, fmt.Errorf("%w: manager cannot approve %s", utils.ErrManagerApprovalRequired, permission)
+	}
+
+	a.log.Info("Manager override approved",
+		zap.Uint("requester_id", requesterID),
+		zap.Uint("manager_id", manager.ID),
+		zap.String("permission", permission))
+	return requesterID, manager.ID, nil
+}
+
+// SetManagerPIN sets the PIN the current user approves overrides with. The
+// account password is asked for again so an unattended session cannot be
+// used to set one.
+func (s *authService) SetManagerPIN(ctx context.Context, req request.SetManagerPINRequest) error {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return utils.ErrInvalidToken
+	}
+	if !canApproveOverrides(ctx) {
+		return utils.ErrManagerPINNotAllowed
+	}
+
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPassword(req.Password, user.PasswordHash) {
+		return utils.ErrInvalidCredentials
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UserRepo.UpdateUser(ctx, userID, &entity.User{ID: userID, ManagerPIN: utils.HashPassword(req.PIN)}); err != nil {
+			return err
+		}
+		before, after := auditSecretChange("manager_pin")
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUser, userID, before, after)
+	})
+	if err != nil {
+		s.log.Error("Error set manager PIN", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	if err := s.repo.LoginThrottleRepo.Delete(ctx, managerPINKey(userID)); err != nil {
+		s.log.Error("Error clear manager PIN failures", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	s.log.Info("Manager PIN set", zap.Uint("user_id", userID))
+	return nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPinPolicy_CapsFailures(t *testing.T) {
+	require.Equal(t, defaultPINMaxFailures, pinPolicy(utils.LoginProtectionConfig{}).maxFailures)
+	require.Equal(t, 3, pinPolicy(utils.LoginProtectionConfig{MaxAccountFailures: 3}).maxFailures)
+}
+
+func TestManagerApprover_RequesterWithPermission(t *testing.T) {
+	a := newManagerApprover(nil, nil, zap.NewNop(), utils.Configuration{}, nil)
+	ctx := context.WithValue(context.Background(), "user_id", uint(7))
+	ctx = context.WithValue(ctx, "permissions", resolvePermissions(map[string]bool{entity.PermOrdersVoid: true}, nil))
+
+	requester, approver, err := a.approve(ctx, entity.PermOrdersVoid, &request.ManagerOverride{ManagerID: 1, PIN: "1234"})
+	require.NoError(t, err)
+	require.Equal(t, uint(7), requester)
+	require.Equal(t, uint(7), approver)
+}
+
+func TestManagerApprover_RequiresOverride(t *testing.T) {
+	a := newManagerApprover(nil, nil, zap.NewNop(), utils.Configuration{}, nil)
+	ctx := context.WithValue(context.Background(), "user_id", uint(7))
+	ctx = context.WithValue(ctx, "permissions", resolvePermissions(map[string]bool{entity.PermOrdersManage: true}, nil))
+
+	_, _, err := a.approve(ctx, entity.PermOrdersRefund, nil)
+	require.True(t, errors.Is(err, utils.ErrManagerApprovalRequired))
+	require.Equal(t, http.StatusForbidden, utils.AsError(err).Status)
+}
+
+func TestCanApproveOverrides(t *testing.T) {
+	staff := context.WithValue(context.Background(), "permissions", resolvePermissions(map[string]bool{entity.PermOrdersManage: true}, nil))
+	manager := context.WithValue(context.Background(), "permissions", resolvePermissions(map[string]bool{entity.PermOrdersDiscount: true}, nil))
+
+	require.False(t, canApproveOverrides(staff))
+	require.True(t, canApproveOverrides(manager))
+}
+
+// pinApprover returns an approver whose manager 2 has PIN 1234 and whose
+// admin role holds granted.
+func pinApprover(granted map[string]bool) (*managerApprover, *mocks.LoginThrottleRepoMock) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo, LoginThrottleRepo: throttleRepo}
+	permissions := &permissionService{
+		roles:     map[string]map[string]bool{string(entity.RoleAdmin): granted},
+		overrides: map[uint]map[string]bool{},
+		loadedAt:  time.Now(),
+	}
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(2)).Return(entity.User{
+		ID:         2,
+		Role:       entity.RoleAdmin,
+		ManagerPIN: utils.HashPassword("1234"),
+	}, nil)
+	return newManagerApprover(tx, repo, zap.NewNop(), utils.Configuration{}, permissions), throttleRepo
+}
+
+func cashierContext() context.Context {
+	ctx := context.WithValue(context.Background(), "user_id", uint(7))
+	return context.WithValue(ctx, "permissions", resolvePermissions(map[string]bool{entity.PermOrdersManage: true}, nil))
+}
+
+func TestManagerApprover_CorrectPIN(t *testing.T) {
+	a, throttleRepo := pinApprover(map[string]bool{entity.PermOrdersVoid: true})
+	throttleRepo.On("FindForUpdate", mock.Anything, "pin:2").Return(&entity.LoginThrottle{Key: "pin:2"}, nil)
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	throttleRepo.On("Delete", mock.Anything, "pin:2").Return(nil)
+
+	requester, approver, err := a.approve(cashierContext(), entity.PermOrdersVoid, &request.ManagerOverride{ManagerID: 2, PIN: "1234"})
+	require.NoError(t, err)
+	require.Equal(t, uint(7), requester)
+	require.Equal(t, uint(2), approver)
+	throttleRepo.AssertCalled(t, "Delete", mock.Anything, "pin:2")
+}
+
+func TestManagerApprover_WrongPINCountsTowardLockout(t *testing.T) {
+	a, throttleRepo := pinApprover(map[string]bool{entity.PermOrdersVoid: true})
+	throttle := &entity.LoginThrottle{Key: "pin:2", Failures: defaultPINMaxFailures - 1, LastFailureAt: time.Now().Add(-time.Hour / 2)}
+	throttleRepo.On("FindForUpdate", mock.Anything, "pin:2").Return(throttle, nil)
+	throttleRepo.On("Save", mock.Anything, throttle).Return(nil)
+
+	_, _, err := a.approve(cashierContext(), entity.PermOrdersVoid, &request.ManagerOverride{ManagerID: 2, PIN: "9999"})
+	require.ErrorIs(t, err, utils.ErrInvalidManagerPIN)
+	require.Equal(t, defaultPINMaxFailures, throttle.Failures)
+	require.NotNil(t, throttle.LockedUntil)
+
+	// Locked now, even with the right PIN
+	_, _, err = a.approve(cashierContext(), entity.PermOrdersVoid, &request.ManagerOverride{ManagerID: 2, PIN: "1234"})
+	require.ErrorIs(t, err, utils.ErrManagerPINLocked)
+	throttleRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestManagerApprover_ManagerWithoutPermission(t *testing.T) {
+	a, throttleRepo := pinApprover(map[string]bool{entity.PermOrdersDiscount: true})
+	throttleRepo.On("FindForUpdate", mock.Anything, "pin:2").Return(&entity.LoginThrottle{Key: "pin:2"}, nil)
+	throttleRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	throttleRepo.On("Delete", mock.Anything, "pin:2").Return(nil)
+
+	_, _, err := a.approve(cashierContext(), entity.PermOrdersVoid, &request.ManagerOverride{ManagerID: 2, PIN: "1234"})
+	require.ErrorIs(t, err, utils.ErrManagerApprovalRequired)
+}
+
+func TestAuthService_SetManagerPIN_AuditsWithoutHash(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	userRepo := new(mocks.UserRepoMock)
+	throttleRepo := new(mocks.LoginThrottleRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := &repository.Repository{UserRepo: userRepo, LoginThrottleRepo: throttleRepo, AuditLogRepo: auditRepo}
+	service := NewAuthService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
+	ctx := context.WithValue(context.Background(), "user_id", uint(2))
+	ctx = context.WithValue(ctx, "permissions", resolvePermissions(map[string]bool{entity.PermOrdersVoid: true}, nil))
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	userRepo.On("GetUserByID", mock.Anything, uint(2)).Return(entity.User{ID: 2, PasswordHash: utils.HashPassword("secret")}, nil)
+	userRepo.On("UpdateUser", mock.Anything, uint(2), mock.Anything).Return(nil)
+	throttleRepo.On("Delete", mock.Anything, "pin:2").Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return l.EntityType == auditEntityUser && l.EntityID == 2 &&
+			l.After == `{"manager_pin":"changed"}`
+	})).Return(nil)
+
+	require.NoError(t, service.SetManagerPIN(ctx, request.SetManagerPINRequest{Password: "secret", PIN: "4321"}))
+	auditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ModifierService interface {
+	CreateGroup(ctx context.Context, productID uint, req request.CreateModifierGroupRequest) (*response.ModifierGroupResponse, error)
+	GetGroupsByProduct(ctx context.Context, productID uint) ([]response.ModifierGroupResponse, error)
+	UpdateGroup(ctx context.Context, id uint, req request.UpdateModifierGroupRequest) (*response.ModifierGroupResponse, error)
+	DeleteGroup(ctx context.Context, id uint) error
+	CreateOption(ctx context.Context, groupID uint, req request.CreateModifierOptionRequest) (*response.ModifierOptionResponse, error)
+	UpdateOption(ctx context.Context, id uint, req request.UpdateModifierOptionRequest) (*response.ModifierOptionResponse, error)
+	DeleteOption(ctx context.Context, id uint) error
+}
+
+type modifierService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewModifierService(tx TxManager, repo *repository.Repository, log *zap.Logger) ModifierService {
+	return &modifierService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "modifier")),
+	}
+}
+
+func (s *modifierService) CreateGroup(ctx context.Context, productID uint, req request.CreateModifierGroupRequest) (*response.ModifierGroupResponse, error) {
+	s.log.Info("Creating modifier group",
+		zap.Uint("product_id", productID),
+		zap.String("name", req.Name))
+
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	// Groups are single choice unless told otherwise; 0 means no limit
+	maxSelect := 1
+	if req.MaxSelect != nil {
+		maxSelect = *req.MaxSelect
+	}
+	if maxSelect > 0 && req.MinSelect > maxSelect {
+		return nil, utils.ErrInvalidModifierRange
+	}
+
+	group := &entity.ModifierGroup{
+		ProductID:  productID,
+		Name:       req.Name,
+		IsRequired: req.IsRequired,
+		MinSelect:  req.MinSelect,
+		MaxSelect:  maxSelect,
+		SortOrder:  req.SortOrder,
+	}
+	for _, o := range req.Options {
+		group.Options = append(group.Options, entity.ModifierOption{
+			Name:       o.Name,
+			PriceDelta: o.PriceDelta,
+			IsActive:   true,
+			SortOrder:  o.SortOrder,
+		})
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err = s.repo.ModifierRepo.CreateGroup(ctx, group)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityModifierGroup, group.ID, nil, group)
+	})
+	if err != nil {
+		s.log.Error("Failed to create modifier group", zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.ModifierGroupToResponse(group)
+	return &resp, nil
+}
+
+func (s *modifierService) GetGroupsByProduct(ctx context.Context, productID uint) ([]response.ModifierGroupResponse, error) {
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	groups, err := s.repo.ModifierRepo.FindGroupsByProductID(ctx, productID)
+	if err != nil {
+		s.log.Error("Failed to get modifier groups",
+			zap.Uint("product_id", productID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]response.ModifierGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, response.ModifierGroupToResponse(&g))
+	}
+	return result, nil
+}
+
+func (s *modifierService) UpdateGroup(ctx context.Context, id uint, req request.UpdateModifierGroupRequest) (*response.ModifierGroupResponse, error) {
+	group, err := s.repo.ModifierRepo.FindGroupByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrModifierGroupNotFound
+		}
+		return nil, err
+	}
+	before := *group
+
+	if req.Name != "" {
+		group.Name = req.Name
+	}
+	if req.IsRequired != nil {
+		group.IsRequired = *req.IsRequired
+	}
+	if req.MinSelect != nil {
+		group.MinSelect = *req.MinSelect
+	}
+	if req.MaxSelect != nil {
+		group.MaxSelect = *req.MaxSelect
+	}
+	if req.SortOrder != nil {
+		group.SortOrder = *req.SortOrder
+	}
+	if group.MaxSelect > 0 && group.MinSelect > group.MaxSelect {
+		return nil, utils.ErrInvalidModifierRange
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ModifierRepo.UpdateGroup(ctx, group); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityModifierGroup, id, before, group)
+	})
+	if err != nil {
+		s.log.Error("Failed to update modifier group", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Modifier group updated", zap.Uint("id", id))
+
+	resp := response.ModifierGroupToResponse(group)
+	return &resp, nil
+}
+
+func (s *modifierService) DeleteGroup(ctx context.Context, id uint) error {
+	group, err := s.repo.ModifierRepo.FindGroupByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrModifierGroupNotFound
+		}
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ModifierRepo.DeleteGroup(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityModifierGroup, id, group, nil)
+	})
+	if err != nil {
+		s.log.Error("Failed to delete modifier group", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Modifier group deleted", zap.Uint("id", id))
+	return nil
+}
+
+func (s *modifierService) CreateOption(ctx context.Context, groupID uint, req request.CreateModifierOptionRequest) (*response.ModifierOptionResponse, error) {
+	if _, err := s.repo.ModifierRepo.FindGroupByID(ctx, groupID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrModifierGroupNotFound
+		}
+		return nil, err
+	}
+
+	option := &entity.ModifierOption{
+		GroupID:    groupID,
+		Name:       req.Name,
+		PriceDelta: req.PriceDelta,
+		IsActive:   true,
+		SortOrder:  req.SortOrder,
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		option, err = s.repo.ModifierRepo.CreateOption(ctx, option)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityModifierOption, option.ID, nil, option)
+	})
+	if err != nil {
+		s.log.Error("Failed to create modifier option", zap.Uint("group_id", groupID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Modifier option created",
+		zap.Uint("group_id", groupID),
+		zap.Uint("option_id", option.ID))
+
+	resp := response.ModifierOptionToResponse(option)
+	return &resp, nil
+}
+
+func (s *modifierService) UpdateOption(ctx context.Context, id uint, req request.UpdateModifierOptionRequest) (*response.ModifierOptionResponse, error) {
+	option, err := s.repo.ModifierRepo.FindOptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrModifierOptionNotFound
+		}
+		return nil, err
+	}
+	before := *option
+
+	if req.Name != "" {
+		option.Name = req.Name
+	}
+	if req.PriceDelta != nil {
+		option.PriceDelta = *req.PriceDelta
+	}
+	if req.IsActive != nil {
+		option.IsActive = *req.IsActive
+	}
+	if req.SortOrder != nil {
+		option.SortOrder = *req.SortOrder
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ModifierRepo.UpdateOption(ctx, option); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityModifierOption, id, before, option)
+	})
+	if err != nil {
+		s.log.Error("Failed to update modifier option", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.ModifierOptionToResponse(option)
+	return &resp, nil
+}
+
+func (s *modifierService) DeleteOption(ctx context.Context, id uint) error {
+	option, err := s.repo.ModifierRepo.FindOptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrModifierOptionNotFound
+		}
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ModifierRepo.DeleteOption(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityModifierOption, id, option, nil)
+	})
+	if err != nil {
+		s.log.Error("Failed to delete modifier option", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Modifier option deleted", zap.Uint("id", id))
+	return nil
+}
@@ -0,0 +1,706 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, req request.CreateOrderRequest) (*response.OrderResponse, error)
+	GetOrders(ctx context.Context, req request.GetOrdersRequest) ([]response.OrderResponse, response.PaginationMeta, error)
+	GetOrderByID(ctx context.Context, id uint) (*response.OrderResponse, error)
+	AddOrderItem(ctx context.Context, orderID uint, req request.OrderItemRequest) (*response.OrderResponse, error)
+	UpdateOrderStatus(ctx context.Context, orderID uint, req request.UpdateOrderStatusRequest) (*response.OrderResponse, error)
+	ApplyDiscount(ctx context.Context, orderID uint, req request.ApplyDiscountRequest) (*response.OrderResponse, error)
+	RefundOrder(ctx context.Context, orderID uint, req request.RefundOrderRequest) (*response.TransactionResponse, error)
+	ExportOrders(ctx context.Context, req request.GetOrdersRequest, w utils.ExportWriter) error
+}
+
+// orderTransitions lists the statuses an order may move to from each status.
+var orderTransitions = map[entity.OrderStatus][]entity.OrderStatus{
+	entity.OrderStatusPending:   {entity.OrderStatusInProcess, entity.OrderStatusCooking, entity.OrderStatusCompleted, entity.OrderStatusCancelled},
+	entity.OrderStatusInProcess: {entity.OrderStatusCooking, entity.OrderStatusCompleted, entity.OrderStatusCancelled},
+	entity.OrderStatusCooking:   {entity.OrderStatusInProcess, entity.OrderStatusCompleted, entity.OrderStatusCancelled},
+}
+
+type orderService struct {
+	tx       TxManager
+	repo     *repository.Repository
+	log      *zap.Logger
+	config   utils.Configuration
+	approver *managerApprover
+}
+
+func NewOrderService(tx TxManager, repo *repository.Repository, log *zap.Logger, config utils.Configuration, permissions PermissionService) OrderService {
+	return &orderService{
+		tx:       tx,
+		repo:     repo,
+		log:      log.With(zap.String("service", "order")),
+		config:   config,
+		approver: newManagerApprover(tx, repo, log, config, permissions),
+	}
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, req request.CreateOrderRequest) (*response.OrderResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	s.log.Info("Creating order",
+		zap.Uint("table_id", req.TableID),
+		zap.Int("items", len(req.Items)),
+		zap.Uint("created_by", userID))
+
+	if _, err := s.repo.TableRepo.FindByID(ctx, req.TableID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrTableNotFound
+		}
+		return nil, err
+	}
+
+	orderNumber, err := generateOrderNumber()
+	if err != nil {
+		s.log.Error("Failed to generate order number", zap.Error(err))
+		return nil, err
+	}
+
+	order := &entity.Order{
+		OrderNumber:   orderNumber,
+		CustomerID:    req.CustomerID,
+		TableID:       req.TableID,
+		Status:        entity.OrderStatusPending,
+		TaxPercentage: float64(s.config.BusinessRules.TaxRate),
+		CreatedBy:     userID,
+		Notes:         req.Notes,
+	}
+
+	for _, itemReq := range req.Items {
+		item, err := s.buildOrderItem(ctx, itemReq)
+		if err != nil {
+			return nil, err
+		}
+		order.OrderItems = append(order.OrderItems, *item)
+	}
+	calculateOrderTotals(order)
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.OrderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityOrder, order.ID, nil, order)
+	})
+	if err != nil {
+		s.log.Error("Failed to create order", zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetOrderByID(ctx, order.ID)
+}
+
+func (s *orderService) GetOrders(ctx context.Context, req request.GetOrdersRequest) ([]response.OrderResponse, response.PaginationMeta, error) {
+	orders, total, err := s.repo.OrderRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get orders", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.OrderResponse, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, response.OrderToResponse(&o))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+// ExportOrders writes every order matching the filters, one row per order.
+func (s *orderService) ExportOrders(ctx context.Context, req request.GetOrdersRequest, w utils.ExportWriter) error {
+	if err := w.WriteRow("order_number", "created_at", "status", "table", "customer_id", "items", "subtotal", "tax_amount", "total", "created_by", "notes"); err != nil {
+		return err
+	}
+
+	err := s.repo.OrderRepo.Stream(ctx, req, func(orders []entity.Order) error {
+		for _, o := range orders {
+			customerID := ""
+			if o.CustomerID != nil {
+				customerID = strconv.FormatUint(uint64(*o.CustomerID), 10)
+			}
+			err := w.WriteRow(o.OrderNumber, o.CreatedAt, string(o.Status), o.Table.TableNumber, customerID,
+				len(o.OrderItems), o.Subtotal, o.TaxAmount, o.Total, o.CreatedBy, o.Notes)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to export orders", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *orderService) GetOrderByID(ctx context.Context, id uint) (*response.OrderResponse, error) {
+	order, err := s.repo.OrderRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	resp := response.OrderToResponse(order)
+	return &resp, nil
+}
+
+func (s *orderService) AddOrderItem(ctx context.Context, orderID uint, req request.OrderItemRequest) (*response.OrderResponse, error) {
+	s.log.Info("Adding item to order",
+		zap.Uint("order_id", orderID),
+		zap.Uint("product_id", req.ProductID))
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locked, so an order paid or cancelled meanwhile is seen before
+		// the item is added
+		order, err := s.repo.OrderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderNotFound
+			}
+			return err
+		}
+
+		if order.Status == entity.OrderStatusCompleted || order.Status == entity.OrderStatusCancelled {
+			return utils.ErrOrderNotEditable
+		}
+		before := *order
+
+		item, err := s.buildOrderItem(ctx, req)
+		if err != nil {
+			return err
+		}
+		item.OrderID = order.ID
+
+		if _, err := s.repo.OrderRepo.CreateItem(ctx, item); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityOrderItem, item.ID, nil, item); err != nil {
+			return err
+		}
+
+		order.OrderItems = append(order.OrderItems, *item)
+		calculateOrderTotals(order)
+
+		if err := s.repo.OrderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityOrder, order.ID, before, order)
+	})
+	if err != nil {
+		s.log.Error("Failed to add order item",
+			zap.Uint("order_id", orderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetOrderByID(ctx, orderID)
+}
+
+func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID uint, req request.UpdateOrderStatusRequest) (*response.OrderResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	next := entity.OrderStatus(req.Status)
+
+	s.log.Info("Updating order status",
+		zap.Uint("order_id", orderID),
+		zap.String("status", req.Status))
+
+	// Cancelling is a void and needs the permission or a manager's PIN.
+	var voidedBy, voidApprovedBy uint
+	if next == entity.OrderStatusCancelled {
+		requesterID, approverID, err := s.approver.approve(ctx, entity.PermOrdersVoid, req.Override)
+		if err != nil {
+			return nil, err
+		}
+		voidedBy, voidApprovedBy = requesterID, approverID
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locked, so two requests cannot both complete the order and
+		// deduct its ingredients twice
+		order, err := s.repo.OrderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderNotFound
+			}
+			return err
+		}
+
+		if !canTransitionOrder(order.Status, next) {
+			return utils.ErrInvalidStatusTransition
+		}
+		before := *order
+
+		order.Status = next
+		order.StatusDesc = req.StatusDesc
+		if next == entity.OrderStatusCancelled {
+			order.VoidedBy = &voidedBy
+			order.VoidApprovedBy = &voidApprovedBy
+		}
+		if err := s.repo.OrderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityOrder, order.ID, before, order); err != nil {
+			return err
+		}
+
+		if next == entity.OrderStatusCompleted {
+			return deductIngredients(ctx, s.repo, s.log, order, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to update order status",
+			zap.Uint("order_id", orderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetOrderByID(ctx, orderID)
+}
+
+func (s *orderService) ApplyDiscount(ctx context.Context, orderID uint, req request.ApplyDiscountRequest) (*response.OrderResponse, error) {
+	discountType := entity.DiscountType(req.Type)
+	if discountType == entity.DiscountTypePercent && req.Value > 100 {
+		return nil, utils.ErrInvalidDiscount
+	}
+
+	requesterID, approverID, err := s.approver.approve(ctx, entity.PermOrdersDiscount, req.Override)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Applying order discount",
+		zap.Uint("order_id", orderID),
+		zap.String("type", req.Type),
+		zap.Float64("value", req.Value),
+		zap.Uint("requested_by", requesterID),
+		zap.Uint("approved_by", approverID))
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := s.repo.OrderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderNotFound
+			}
+			return err
+		}
+
+		if order.Status == entity.OrderStatusCompleted || order.Status == entity.OrderStatusCancelled {
+			return utils.ErrOrderNotEditable
+		}
+		if discountType == entity.DiscountTypeAmount && req.Value > order.Subtotal {
+			return utils.ErrInvalidDiscount
+		}
+		before := *order
+
+		order.DiscountType = discountType
+		order.DiscountValue = req.Value
+		order.DiscountReason = req.Reason
+		order.DiscountedBy = &requesterID
+		order.DiscountApprovedBy = &approverID
+		calculateOrderTotals(order)
+
+		if err := s.repo.OrderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityOrder, order.ID, before, order)
+	})
+	if err != nil {
+		s.log.Error("Failed to apply order discount",
+			zap.Uint("order_id", orderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetOrderByID(ctx, orderID)
+}
+
+// RefundOrder records a refund transaction against a completed order. It
+// goes back to a payment method the order was paid with, the first one
+// unless another is requested.
+func (s *orderService) RefundOrder(ctx context.Context, orderID uint, req request.RefundOrderRequest) (*response.TransactionResponse, error) {
+	requesterID, approverID, err := s.approver.approve(ctx, entity.PermOrdersRefund, req.Override)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Refunding order",
+		zap.Uint("order_id", orderID),
+		zap.Float64("amount", req.Amount),
+		zap.Uint("requested_by", requesterID),
+		zap.Uint("approved_by", approverID))
+
+	var refund *entity.Transaction
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		order, err := s.repo.OrderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrOrderNotFound
+			}
+			return err
+		}
+		if order.Status != entity.OrderStatusCompleted {
+			return utils.ErrOrderNotRefundable
+		}
+
+		transactions, err := s.repo.TransactionRepo.FindByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		payment, err := refundPayment(transactions, req.PaymentMethodID)
+		if err != nil {
+			return err
+		}
+		paid, refunded := orderPayments(transactions)
+		if roundTo(req.Amount, 2) > roundTo(paid-refunded, 2) {
+			return utils.ErrRefundExceedsPaid
+		}
+
+		number, err := generateRefundNumber()
+		if err != nil {
+			return err
+		}
+
+		refund = &entity.Transaction{
+			TransactionNumber: number,
+			OrderID:           order.ID,
+			TransactionType:   entity.TransactionTypeRefund,
+			PaymentMethodID:   payment.PaymentMethodID,
+			Amount:            roundTo(req.Amount, 2),
+			Status:            entity.TransactionStatusCompleted,
+			Notes:             req.Reason,
+			CreatedBy:         requesterID,
+			ApprovedBy:        &approverID,
+		}
+		if err := s.repo.TransactionRepo.Create(ctx, refund); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityTransaction, refund.ID, nil, refund); err != nil {
+			return err
+		}
+
+		refund.Order = *order
+		refund.PaymentMethod = payment.PaymentMethod
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to refund order",
+			zap.Uint("order_id", orderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.TransactionToResponse(refund)
+	return &resp, nil
+}
+
+// orderPayments totals the completed payments and refunds of an order.
+func orderPayments(transactions []entity.Transaction) (paid, refunded float64) {
+	for _, t := range transactions {
+		if t.Status != entity.TransactionStatusCompleted {
+			continue
+		}
+		switch t.TransactionType {
+		case entity.TransactionTypePayment:
+			paid += t.Amount
+		case entity.TransactionTypeRefund:
+			refunded += t.Amount
+		}
+	}
+	return paid, refunded
+}
+
+// refundPayment picks the completed payment whose method a refund goes back
+// to. methodID 0 means the first payment of the order.
+func refundPayment(transactions []entity.Transaction, methodID uint) (*entity.Transaction, error) {
+	var found bool
+	for i, t := range transactions {
+		if t.TransactionType != entity.TransactionTypePayment || t.Status != entity.TransactionStatusCompleted {
+			continue
+		}
+		found = true
+		if methodID == 0 || t.PaymentMethodID == methodID {
+			return &transactions[i], nil
+		}
+	}
+	if !found {
+		return nil, utils.ErrRefundExceedsPaid
+	}
+	return nil, utils.ErrRefundMethodNotUsed
+}
+
+// deductIngredients writes an "out" ingredient log for everything the
+// order's recipes consumed. Stock is allowed to go negative because the food
+// has already been served; low levels are only reported.
+func deductIngredients(ctx context.Context, repo *repository.Repository, log *zap.Logger, order *entity.Order, userID uint) error {
+	var productIDs, optionIDs []uint
+	for _, item := range order.OrderItems {
+		productIDs = append(productIDs, item.ProductID)
+		for _, m := range item.Modifiers {
+			optionIDs = append(optionIDs, m.ModifierOptionID)
+		}
+	}
+
+	productRecipes, err := repo.RecipeRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	optionRecipes, err := repo.RecipeRepo.FindByModifierOptionIDs(ctx, optionIDs)
+	if err != nil {
+		return err
+	}
+
+	usage := calculateIngredientUsage(order.OrderItems, productRecipes, optionRecipes)
+	for _, ingredientID := range sortedKeys(usage) {
+		ingredient, err := repo.IngredientRepo.AdjustStock(ctx, ingredientID, -usage[ingredientID])
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.IngredientRepo.CreateLog(ctx, &entity.IngredientLog{
+			IngredientID:      ingredientID,
+			Type:              entity.InventoryLogTypeOut,
+			QuantityChange:    -usage[ingredientID],
+			CurrentStockAfter: ingredient.Stock,
+			ReferenceID:       &order.ID,
+			ReferenceType:     "order",
+			Notes:             order.OrderNumber,
+			CreatedBy:         userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if ingredient.IsLowStock() {
+			log.Warn("Ingredient stock is low",
+				zap.Uint("ingredient_id", ingredient.ID),
+				zap.String("name", ingredient.Name),
+				zap.Float64("stock", ingredient.Stock),
+				zap.Float64("min_stock", ingredient.MinStock))
+		}
+	}
+
+	return nil
+}
+
+// buildOrderItem validates the product and its modifier selection and
+// prices the line from the product price plus the selected option deltas.
+func (s *orderService) buildOrderItem(ctx context.Context, req request.OrderItemRequest) (*entity.OrderItem, error) {
+	product, err := s.repo.Product.FindByID(req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+	if product.Status != entity.ProductStatusActive {
+		return nil, utils.ErrProductInactive
+	}
+
+	groups, err := s.repo.ModifierRepo.FindGroupsByProductID(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiers, priceDelta, err := resolveModifiers(groups, req.ModifierOptionIDs)
+	if err != nil {
+		s.log.Warn("Invalid modifier selection",
+			zap.Uint("product_id", product.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	unitPrice := product.Price + priceDelta
+	return &entity.OrderItem{
+		ProductID:  product.ID,
+		Quantity:   req.Quantity,
+		UnitPrice:  unitPrice,
+		TotalPrice: unitPrice * float64(req.Quantity),
+		Notes:      req.Notes,
+		Modifiers:  modifiers,
+	}, nil
+}
+
+// resolveModifiers checks the selected options against the product's
+// modifier groups and returns the snapshots to store on the order item
+// together with the summed price delta.
+func resolveModifiers(groups []entity.ModifierGroup, optionIDs []uint) ([]entity.OrderItemModifier, float64, error) {
+	type choice struct {
+		group  *entity.ModifierGroup
+		option *entity.ModifierOption
+	}
+
+	available := make(map[uint]choice)
+	for i := range groups {
+		for j := range groups[i].Options {
+			option := &groups[i].Options[j]
+			if option.IsActive {
+				available[option.ID] = choice{group: &groups[i], option: option}
+			}
+		}
+	}
+
+	var modifiers []entity.OrderItemModifier
+	var priceDelta float64
+	selected := make(map[uint]bool)
+	perGroup := make(map[uint]int)
+
+	for _, id := range optionIDs {
+		c, ok := available[id]
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: option %d is not available for this product", utils.ErrInvalidModifierSelection, id)
+		}
+		if selected[id] {
+			return nil, 0, fmt.Errorf("%w: option %d selected more than once", utils.ErrInvalidModifierSelection, id)
+		}
+		selected[id] = true
+		perGroup[c.group.ID]++
+
+		priceDelta += c.option.PriceDelta
+		modifiers = append(modifiers, entity.OrderItemModifier{
+			ModifierOptionID: c.option.ID,
+			GroupName:        c.group.Name,
+			OptionName:       c.option.Name,
+			PriceDelta:       c.option.PriceDelta,
+		})
+	}
+
+	for _, g := range groups {
+		minSelect := g.MinSelect
+		if g.IsRequired && minSelect < 1 {
+			minSelect = 1
+		}
+		count := perGroup[g.ID]
+		if count < minSelect {
+			return nil, 0, fmt.Errorf("%w: %s requires at least %d option(s)", utils.ErrInvalidModifierSelection, g.Name, minSelect)
+		}
+		if g.MaxSelect > 0 && count > g.MaxSelect {
+			return nil, 0, fmt.Errorf("%w: %s allows at most %d option(s)", utils.ErrInvalidModifierSelection, g.Name, g.MaxSelect)
+		}
+	}
+
+	return modifiers, priceDelta, nil
+}
+
+// calculateIngredientUsage totals the ingredient quantities consumed by the
+// given items, counting both the product recipe and the recipes of the
+// selected modifier options once per unit ordered.
+func calculateIngredientUsage(items []entity.OrderItem, productRecipes, optionRecipes []entity.RecipeItem) map[uint]float64 {
+	byProduct := make(map[uint][]entity.RecipeItem)
+	for _, r := range productRecipes {
+		if r.ProductID != nil {
+			byProduct[*r.ProductID] = append(byProduct[*r.ProductID], r)
+		}
+	}
+	byOption := make(map[uint][]entity.RecipeItem)
+	for _, r := range optionRecipes {
+		if r.ModifierOptionID != nil {
+			byOption[*r.ModifierOptionID] = append(byOption[*r.ModifierOptionID], r)
+		}
+	}
+
+	usage := make(map[uint]float64)
+	for _, item := range items {
+		qty := float64(item.Quantity)
+		for _, r := range byProduct[item.ProductID] {
+			usage[r.IngredientID] += r.Quantity * qty
+		}
+		for _, m := range item.Modifiers {
+			for _, r := range byOption[m.ModifierOptionID] {
+				usage[r.IngredientID] += r.Quantity * qty
+			}
+		}
+	}
+
+	return usage
+}
+
+func canTransitionOrder(current, next entity.OrderStatus) bool {
+	for _, allowed := range orderTransitions[current] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys keeps row locks in a stable order across concurrent orders.
+func sortedKeys(m map[uint]float64) []uint {
+	keys := make([]uint, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func calculateOrderTotals(order *entity.Order) {
+	var subtotal float64
+	for _, item := range order.OrderItems {
+		subtotal += item.TotalPrice
+	}
+	order.Subtotal = subtotal
+	order.DiscountAmount = orderDiscount(order.DiscountType, order.DiscountValue, subtotal)
+
+	taxable := subtotal - order.DiscountAmount
+	order.TaxAmount = taxable * order.TaxPercentage / 100
+	order.Total = taxable + order.TaxAmount
+}
+
+// orderDiscount returns the amount taken off subtotal, never more than it.
+func orderDiscount(discountType entity.DiscountType, value, subtotal float64) float64 {
+	var amount float64
+	switch discountType {
+	case entity.DiscountTypeAmount:
+		amount = value
+	case entity.DiscountTypePercent:
+		amount = roundTo(subtotal*value/100, 2)
+	}
+	if amount > subtotal {
+		amount = subtotal
+	}
+	return amount
+}
+
+func generateOrderNumber() (string, error) {
+	suffix, err := utils.GenerateOTP(4)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ORD-%s-%s", time.Now().Format("20060102150405"), suffix), nil
+}
+
+func generateRefundNumber() (string, error) {
+	suffix, err := utils.GenerateOTP(4)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("RFD-%s-%s", time.Now().Format("20060102150405"), suffix), nil
+}
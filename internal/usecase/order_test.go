@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func modifierGroupsFixture() []entity.ModifierGroup {
+	return []entity.ModifierGroup{
+		{
+			ID:         1,
+			Name:       "Size",
+			IsRequired: true,
+			MaxSelect:  1,
+			Options: []entity.ModifierOption{
+				{ID: 10, GroupID: 1, Name: "Regular", IsActive: true},
+				{ID: 11, GroupID: 1, Name: "Large", PriceDelta: 5000, IsActive: true},
+			},
+		},
+		{
+			ID:        2,
+			Name:      "Toppings",
+			MaxSelect: 2,
+			Options: []entity.ModifierOption{
+				{ID: 20, GroupID: 2, Name: "Cheese", PriceDelta: 3000, IsActive: true},
+				{ID: 21, GroupID: 2, Name: "Egg", PriceDelta: 4000, IsActive: true},
+				{ID: 22, GroupID: 2, Name: "Bacon", PriceDelta: 6000, IsActive: false},
+			},
+		},
+	}
+}
+
+func TestResolveModifiers_Success(t *testing.T) {
+	require := require.New(t)
+
+	modifiers, delta, err := resolveModifiers(modifierGroupsFixture(), []uint{11, 20, 21})
+
+	require.NoError(err)
+	require.Len(modifiers, 3)
+	require.Equal(float64(12000), delta)
+	require.Equal("Size", modifiers[0].GroupName)
+	require.Equal("Large", modifiers[0].OptionName)
+}
+
+func TestResolveModifiers_MissingRequiredGroup(t *testing.T) {
+	_, _, err := resolveModifiers(modifierGroupsFixture(), []uint{20})
+
+	require.True(t, errors.Is(err, utils.ErrInvalidModifierSelection))
+}
+
+func TestResolveModifiers_TooManyOptions(t *testing.T) {
+	_, _, err := resolveModifiers(modifierGroupsFixture(), []uint{10, 11})
+
+	require.True(t, errors.Is(err, utils.ErrInvalidModifierSelection))
+}
+
+func TestResolveModifiers_InactiveOrForeignOption(t *testing.T) {
+	_, _, err := resolveModifiers(modifierGroupsFixture(), []uint{10, 22})
+	require.True(t, errors.Is(err, utils.ErrInvalidModifierSelection))
+
+	_, _, err = resolveModifiers(modifierGroupsFixture(), []uint{10, 99})
+	require.True(t, errors.Is(err, utils.ErrInvalidModifierSelection))
+}
+
+func TestCalculateIngredientUsage(t *testing.T) {
+	require := require.New(t)
+
+	burgerID := uint(1)
+	cheeseOptionID := uint(20)
+
+	productRecipes := []entity.RecipeItem{
+		{ProductID: &burgerID, IngredientID: 100, Quantity: 150}, // beef, g
+		{ProductID: &burgerID, IngredientID: 101, Quantity: 1},   // bun, pcs
+	}
+	optionRecipes := []entity.RecipeItem{
+		{ModifierOptionID: &cheeseOptionID, IngredientID: 102, Quantity: 20}, // cheese, g
+	}
+
+	items := []entity.OrderItem{
+		{ProductID: burgerID, Quantity: 2, Modifiers: []entity.OrderItemModifier{{ModifierOptionID: cheeseOptionID}}},
+		{ProductID: burgerID, Quantity: 1},
+		{ProductID: 99, Quantity: 3}, // no recipe
+	}
+
+	usage := calculateIngredientUsage(items, productRecipes, optionRecipes)
+
+	require.Len(usage, 3)
+	require.Equal(float64(450), usage[100])
+	require.Equal(float64(3), usage[101])
+	require.Equal(float64(40), usage[102])
+}
+
+func TestCanTransitionOrder(t *testing.T) {
+	require.True(t, canTransitionOrder(entity.OrderStatusPending, entity.OrderStatusCooking))
+	require.True(t, canTransitionOrder(entity.OrderStatusCooking, entity.OrderStatusCompleted))
+	require.False(t, canTransitionOrder(entity.OrderStatusCompleted, entity.OrderStatusCooking))
+	require.False(t, canTransitionOrder(entity.OrderStatusCancelled, entity.OrderStatusPending))
+}
+
+func TestCalculateOrderTotals_DiscountBeforeTax(t *testing.T) {
+	order := &entity.Order{
+		TaxPercentage: 10,
+		DiscountType:  entity.DiscountTypePercent,
+		DiscountValue: 20,
+		OrderItems:    []entity.OrderItem{{TotalPrice: 30000}, {TotalPrice: 20000}},
+	}
+	calculateOrderTotals(order)
+
+	require.Equal(t, 50000.0, order.Subtotal)
+	require.Equal(t, 10000.0, order.DiscountAmount)
+	require.Equal(t, 4000.0, order.TaxAmount)
+	require.Equal(t, 44000.0, order.Total)
+
+	// A percent discount follows items added later.
+	order.OrderItems = append(order.OrderItems, entity.OrderItem{TotalPrice: 50000})
+	calculateOrderTotals(order)
+	require.Equal(t, 20000.0, order.DiscountAmount)
+	require.Equal(t, 88000.0, order.Total)
+}
+
+func TestOrderDiscount(t *testing.T) {
+	require.Equal(t, 5000.0, orderDiscount(entity.DiscountTypeAmount, 5000, 20000))
+	require.Equal(t, 20000.0, orderDiscount(entity.DiscountTypeAmount, 25000, 20000))
+	require.Equal(t, 3333.33, orderDiscount(entity.DiscountTypePercent, 10, 33333.3))
+	require.Equal(t, 0.0, orderDiscount("", 10, 20000))
+}
+
+func TestOrderPayments(t *testing.T) {
+	transactions := []entity.Transaction{
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, Amount: 100000},
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusFailed, Amount: 50000},
+		{TransactionType: entity.TransactionTypeRefund, Status: entity.TransactionStatusCompleted, Amount: 20000},
+	}
+
+	paid, refunded := orderPayments(transactions)
+	require.Equal(t, 100000.0, paid)
+	require.Equal(t, 20000.0, refunded)
+}
+
+func TestRefundPayment(t *testing.T) {
+	transactions := []entity.Transaction{
+		{ID: 1, TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusFailed, PaymentMethodID: 3},
+		{ID: 2, TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, PaymentMethodID: 1},
+		{ID: 3, TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, PaymentMethodID: 2},
+	}
+
+	payment, err := refundPayment(transactions, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), payment.ID)
+
+	payment, err = refundPayment(transactions, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), payment.ID)
+
+	_, err = refundPayment(transactions, 3)
+	require.True(t, errors.Is(err, utils.ErrRefundMethodNotUsed))
+
+	_, err = refundPayment(nil, 0)
+	require.True(t, errors.Is(err, utils.ErrRefundExceedsPaid))
+}
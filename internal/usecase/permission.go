@@ -0,0 +1,498 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// permissionCacheTTL is how stale role permissions may get before they are
+// reloaded. Edits made on this instance take effect immediately.
+const permissionCacheTTL = 30 * time.Second
+
+type PermissionService interface {
+	EffectivePermissions(ctx context.Context, role string, userID uint) PermissionSet
+	ListPermissions(ctx context.Context) ([]response.PermissionResponse, error)
+	ListRoles(ctx context.Context) ([]response.RoleResponse, error)
+	GetRole(ctx context.Context, id uint) (*response.RoleResponse, error)
+	CreateRole(ctx context.Context, req request.CreateRoleRequest) (*response.RoleResponse, error)
+	UpdateRole(ctx context.Context, id uint, req request.UpdateRoleRequest) (*response.RoleResponse, error)
+	DeleteRole(ctx context.Context, id uint) error
+	GetUserPermissions(ctx context.Context, userID uint) (*response.UserPermissionsResponse, error)
+	SetUserPermissions(ctx context.Context, userID uint, req request.UserPermissionsRequest) (*response.UserPermissionsResponse, error)
+}
+
+// PermissionSet is what the current user may do. The auth middleware stores
+// it in the request context under "permissions".
+type PermissionSet struct {
+	all   bool
+	names map[string]bool
+}
+
+func (p PermissionSet) Has(name string) bool {
+	return p.all || p.names[name]
+}
+
+// Names lists the held permissions in order. It is empty for a superadmin
+// set, which holds everything.
+func (p PermissionSet) Names() []string {
+	names := make([]string, 0, len(p.names))
+	for name := range p.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasPermission reports whether the user behind ctx holds the permission.
+func HasPermission(ctx context.Context, name string) bool {
+	set, _ := ctx.Value("permissions").(PermissionSet)
+	return set.Has(name)
+}
+
+type permissionService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+
+	mu        sync.RWMutex
+	roles     map[string]map[string]bool
+	overrides map[uint]map[string]bool
+	loadedAt  time.Time
+}
+
+func NewPermissionService(tx TxManager, repo *repository.Repository, log *zap.Logger) PermissionService {
+	return &permissionService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "permission")),
+	}
+}
+
+func (s *permissionService) EffectivePermissions(ctx context.Context, role string, userID uint) PermissionSet {
+	if role == string(entity.RoleSuperAdmin) {
+		return PermissionSet{all: true}
+	}
+
+	roles, overrides := s.snapshot(ctx)
+	return resolvePermissions(roles[role], overrides[userID])
+}
+
+// snapshot returns the cached role permissions and user overrides, reloading
+// them once they are older than the TTL. A failed reload keeps serving the
+// previous ones.
+func (s *permissionService) snapshot(ctx context.Context) (map[string]map[string]bool, map[uint]map[string]bool) {
+	s.mu.RLock()
+	if time.Since(s.loadedAt) < permissionCacheTTL {
+		defer s.mu.RUnlock()
+		return s.roles, s.overrides
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.loadedAt) < permissionCacheTTL {
+		return s.roles, s.overrides
+	}
+
+	roles, err := s.repo.RoleRepo.List(ctx)
+	if err != nil {
+		s.log.Warn("Failed to load role permissions", zap.Error(err))
+		return s.roles, s.overrides
+	}
+	userOverrides, err := s.repo.PermissionRepo.ListAllOverrides(ctx)
+	if err != nil {
+		s.log.Warn("Failed to load user permissions", zap.Error(err))
+		return s.roles, s.overrides
+	}
+
+	s.roles = make(map[string]map[string]bool, len(roles))
+	for _, role := range roles {
+		names := make(map[string]bool, len(role.Permissions))
+		for _, p := range role.Permissions {
+			names[p.Name] = true
+		}
+		s.roles[role.Name] = names
+	}
+
+	s.overrides = map[uint]map[string]bool{}
+	for _, o := range userOverrides {
+		if s.overrides[o.UserID] == nil {
+			s.overrides[o.UserID] = map[string]bool{}
+		}
+		s.overrides[o.UserID][o.Permission.Name] = o.Granted
+	}
+
+	s.loadedAt = time.Now()
+	return s.roles, s.overrides
+}
+
+func (s *permissionService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *permissionService) ListPermissions(ctx context.Context) ([]response.PermissionResponse, error) {
+	permissions, err := s.repo.PermissionRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.PermissionResponse, 0, len(permissions))
+	for _, p := range permissions {
+		res = append(res, response.PermissionResponse{Name: p.Name, Description: p.Description})
+	}
+	return res, nil
+}
+
+func (s *permissionService) ListRoles(ctx context.Context) ([]response.RoleResponse, error) {
+	roles, err := s.repo.RoleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.RoleResponse, 0, len(roles))
+	for i := range roles {
+		res = append(res, toRoleResponse(&roles[i]))
+	}
+	return res, nil
+}
+
+func (s *permissionService) GetRole(ctx context.Context, id uint) (*response.RoleResponse, error) {
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toRoleResponse(role)
+	return &res, nil
+}
+
+func (s *permissionService) CreateRole(ctx context.Context, req request.CreateRoleRequest) (*response.RoleResponse, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.RoleRepo.FindByName(ctx, req.Name); err == nil {
+		return nil, utils.ErrRoleExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	role := entity.Role{Name: req.Name, Description: req.Description}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		permissions, err := s.findPermissions(ctx, req.Permissions)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.RoleRepo.Create(ctx, &role); err != nil {
+			return err
+		}
+		if err := s.repo.RoleRepo.ReplacePermissions(ctx, &role, permissions); err != nil {
+			return err
+		}
+		role.Permissions = permissions
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityRole, role.ID, nil, toRoleResponse(&role))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	s.log.Info("Role created", zap.String("name", role.Name), zap.Strings("permissions", req.Permissions))
+	res := toRoleResponse(&role)
+	return &res, nil
+}
+
+func (s *permissionService) UpdateRole(ctx context.Context, id uint, req request.UpdateRoleRequest) (*response.RoleResponse, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role.Name == string(entity.RoleSuperAdmin) {
+		return nil, utils.ErrRoleNotEditable
+	}
+
+	before := toRoleResponse(role)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		permissions, err := s.findPermissions(ctx, req.Permissions)
+		if err != nil {
+			return err
+		}
+
+		if req.Description != nil {
+			role.Description = *req.Description
+		}
+		if err := s.repo.RoleRepo.Update(ctx, role); err != nil {
+			return err
+		}
+		if err := s.repo.RoleRepo.ReplacePermissions(ctx, role, permissions); err != nil {
+			return err
+		}
+		role.Permissions = permissions
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityRole, role.ID, before, toRoleResponse(role))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	s.log.Info("Role updated", zap.String("name", role.Name), zap.Strings("permissions", req.Permissions))
+	res := toRoleResponse(role)
+	return &res, nil
+}
+
+func (s *permissionService) DeleteRole(ctx context.Context, id uint) error {
+	role, err := s.findRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return utils.ErrSystemRole
+	}
+
+	users, err := s.repo.RoleRepo.CountUsers(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return utils.ErrRoleInUse
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.RoleRepo.Delete(ctx, role.ID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityRole, role.ID, toRoleResponse(role), nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.log.Info("Role deleted", zap.String("name", role.Name))
+	return nil
+}
+
+func (s *permissionService) GetUserPermissions(ctx context.Context, userID uint) (*response.UserPermissionsResponse, error) {
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.repo.PermissionRepo.ListUserOverrides(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userPermissions(ctx, &user, overrides)
+}
+
+func (s *permissionService) SetUserPermissions(ctx context.Context, userID uint, req request.UserPermissionsRequest) (*response.UserPermissionsResponse, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == entity.RoleSuperAdmin {
+		return nil, utils.ErrRoleNotEditable
+	}
+
+	names := make([]string, 0, len(req.Overrides))
+	for _, o := range req.Overrides {
+		names = append(names, o.Permission)
+	}
+
+	var overrides []entity.UserPermission
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		permissions, err := s.findPermissions(ctx, names)
+		if err != nil {
+			return err
+		}
+
+		previous, err := s.repo.PermissionRepo.ListUserOverrides(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		byName := make(map[string]entity.Permission, len(permissions))
+		for _, p := range permissions {
+			byName[p.Name] = p
+		}
+		overrides = make([]entity.UserPermission, 0, len(req.Overrides))
+		for _, o := range req.Overrides {
+			overrides = append(overrides, entity.UserPermission{
+				UserID:       userID,
+				PermissionID: byName[o.Permission].ID,
+				Granted:      *o.Granted,
+				Permission:   byName[o.Permission],
+			})
+		}
+
+		if err := s.repo.PermissionRepo.ReplaceUserOverrides(ctx, userID, overrides); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUserPermissions, userID,
+			overrideSnapshot(previous), overrideSnapshot(overrides))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	adminID, _ := ctx.Value("user_id").(uint)
+	s.log.Info("User permissions updated", zap.Uint("user_id", userID), zap.Uint("admin_id", adminID))
+	return s.userPermissions(ctx, &user, overrides)
+}
+
+func (s *permissionService) userPermissions(ctx context.Context, user *entity.User, overrides []entity.UserPermission) (*response.UserPermissionsResponse, error) {
+	res := &response.UserPermissionsResponse{
+		UserID:    user.ID,
+		Role:      string(user.Role),
+		Overrides: make([]response.PermissionOverrideResponse, 0, len(overrides)),
+	}
+
+	overrideSet := make(map[string]bool, len(overrides))
+	for _, o := range overrides {
+		overrideSet[o.Permission.Name] = o.Granted
+		res.Overrides = append(res.Overrides, response.PermissionOverrideResponse{
+			Permission: o.Permission.Name,
+			Granted:    o.Granted,
+		})
+	}
+
+	if user.Role == entity.RoleSuperAdmin {
+		all, err := s.repo.PermissionRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res.Permissions = make([]string, 0, len(all))
+		for _, p := range all {
+			res.Permissions = append(res.Permissions, p.Name)
+		}
+		return res, nil
+	}
+
+	rolePerms := map[string]bool{}
+	role, err := s.repo.RoleRepo.FindByName(ctx, string(user.Role))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if role != nil {
+		for _, p := range role.Permissions {
+			rolePerms[p.Name] = true
+		}
+	}
+
+	res.Permissions = resolvePermissions(rolePerms, overrideSet).Names()
+	return res, nil
+}
+
+// findPermissions loads the named permissions, rejecting unknown and
+// repeated names.
+func (s *permissionService) findPermissions(ctx context.Context, names []string) ([]entity.Permission, error) {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return nil, utils.ErrDuplicatePermission
+		}
+		seen[name] = true
+	}
+
+	permissions, err := s.repo.PermissionRepo.FindByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) != len(names) {
+		return nil, utils.ErrUnknownPermission
+	}
+	return permissions, nil
+}
+
+func (s *permissionService) findRole(ctx context.Context, id uint) (*entity.Role, error) {
+	role, err := s.repo.RoleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// resolvePermissions applies a user's overrides to their role's permissions.
+func resolvePermissions(rolePerms map[string]bool, overrides map[string]bool) PermissionSet {
+	names := make(map[string]bool, len(rolePerms)+len(overrides))
+	for name, ok := range rolePerms {
+		if ok {
+			names[name] = true
+		}
+	}
+	for name, granted := range overrides {
+		if granted {
+			names[name] = true
+		} else {
+			delete(names, name)
+		}
+	}
+	return PermissionSet{names: names}
+}
+
+// overrideSnapshot lists a user's overrides flat for the audit trail.
+func overrideSnapshot(overrides []entity.UserPermission) map[string][]string {
+	granted, revoked := []string{}, []string{}
+	for _, o := range overrides {
+		if o.Granted {
+			granted = append(granted, o.Permission.Name)
+		} else {
+			revoked = append(revoked, o.Permission.Name)
+		}
+	}
+	sort.Strings(granted)
+	sort.Strings(revoked)
+	return map[string][]string{"granted": granted, "revoked": revoked}
+}
+
+func toRoleResponse(role *entity.Role) response.RoleResponse {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		permissions = append(permissions, p.Name)
+	}
+	sort.Strings(permissions)
+
+	return response.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// requireSuperAdmin guards the role mappings and user overrides. roles.manage
+// lets a role read them, but only a superadmin may grant permissions, so no
+// one can widen their own access.
+func requireSuperAdmin(ctx context.Context) error {
+	if role, _ := ctx.Value("user_role").(entity.UserRole); role != entity.RoleSuperAdmin {
+		return utils.ErrSuperAdminOnly
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePermissions_AppliesOverrides(t *testing.T) {
+	role := map[string]bool{
+		entity.PermOrdersManage:   true,
+		entity.PermKitchenOperate: true,
+	}
+	overrides := map[string]bool{
+		entity.PermKitchenOperate: false,
+		entity.PermReportsView:    true,
+	}
+
+	set := resolvePermissions(role, overrides)
+
+	require.True(t, set.Has(entity.PermOrdersManage))
+	require.False(t, set.Has(entity.PermKitchenOperate))
+	require.True(t, set.Has(entity.PermReportsView))
+	require.Equal(t, []string{entity.PermOrdersManage, entity.PermReportsView}, set.Names())
+}
+
+func TestResolvePermissions_UnknownRoleHasNothing(t *testing.T) {
+	set := resolvePermissions(nil, nil)
+
+	require.False(t, set.Has(entity.PermOrdersManage))
+	require.Empty(t, set.Names())
+}
+
+func TestEffectivePermissions_SuperadminHoldsEverything(t *testing.T) {
+	s := &permissionService{}
+
+	set := s.EffectivePermissions(context.Background(), string(entity.RoleSuperAdmin), 1)
+
+	for _, p := range entity.PermissionCatalog {
+		require.True(t, set.Has(p.Name), p.Name)
+	}
+	require.True(t, set.Has("permission.added.later"))
+}
+
+func TestHasPermission_ReadsContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "permissions", resolvePermissions(map[string]bool{entity.PermCashDrawerManage: true}, nil))
+
+	require.True(t, HasPermission(ctx, entity.PermCashDrawerManage))
+	require.False(t, HasPermission(ctx, entity.PermReportsView))
+	require.False(t, HasPermission(context.Background(), entity.PermReportsView))
+}
+
+func TestDefaultRolePermissions_AreInCatalog(t *testing.T) {
+	catalog := map[string]bool{}
+	for _, p := range entity.PermissionCatalog {
+		require.False(t, catalog[p.Name], "duplicate %s", p.Name)
+		catalog[p.Name] = true
+	}
+
+	for role, names := range entity.DefaultRolePermissions {
+		for _, name := range names {
+			require.True(t, catalog[name], "%s grants unknown %s", role, name)
+		}
+	}
+	require.NotContains(t, entity.DefaultRolePermissions[entity.RoleAdmin], entity.PermRolesManage)
+}
+
+func TestPermissionService_MappingsNeedSuperadmin(t *testing.T) {
+	s := &permissionService{}
+	ctx := actorContext(2, entity.RoleAdmin)
+	granted := true
+
+	_, err := s.UpdateRole(ctx, 2, request.UpdateRoleRequest{Permissions: []string{entity.PermRolesManage}})
+	require.ErrorIs(t, err, utils.ErrSuperAdminOnly)
+
+	_, err = s.SetUserPermissions(ctx, 2, request.UserPermissionsRequest{
+		Overrides: []request.PermissionOverrideRequest{{Permission: entity.PermRolesManage, Granted: &granted}},
+	})
+	require.ErrorIs(t, err, utils.ErrSuperAdminOnly)
+}
+
+func TestUpdateRoleRequest_RequiresPermissions(t *testing.T) {
+	require.Error(t, utils.Validate(request.UpdateRoleRequest{}))
+	require.NoError(t, utils.Validate(request.UpdateRoleRequest{Permissions: []string{}}))
+}
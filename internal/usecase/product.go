@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
@@ -11,11 +12,11 @@ import (
 )
 
 type ProductService interface {
-	CreateProduct(req request.CreateProductRequest) (*response.ProductResponse, error)
+	CreateProduct(ctx context.Context, req request.CreateProductRequest) (*response.ProductResponse, error)
 	GetAllProducts(req request.GetProductsRequest) (*response.ProductListResponse, error) // INI!
 	GetProductByID(id uint) (*response.ProductResponse, error)
-	UpdateProduct(id uint, req request.UpdateProductRequest) (*response.ProductResponse, error)
-	DeleteProduct(id uint) error
+	UpdateProduct(ctx context.Context, id uint, req request.UpdateProductRequest) (*response.ProductResponse, error)
+	DeleteProduct(ctx context.Context, id uint) error
 }
 
 func (s *productService) GetAllProducts(req request.GetProductsRequest) (*response.ProductListResponse, error) {
@@ -64,6 +65,8 @@ type productService struct {
 	tx           TxManager
 	productRepo  repository.ProductRepository
 	categoryRepo repository.CategoryRepository
+	auditRepo    repository.AuditLogRepository
+	storage      FileStorage
 	log          *zap.Logger
 }
 
@@ -71,19 +74,27 @@ func NewProductService(
 	tx TxManager,
 	productRepo repository.ProductRepository,
 	categoryRepo repository.CategoryRepository,
+	auditRepo repository.AuditLogRepository,
+	storage FileStorage,
 	log *zap.Logger,
 ) ProductService {
 	return &productService{
 		tx:           tx,
 		productRepo:  productRepo,
 		categoryRepo: categoryRepo,
+		auditRepo:    auditRepo,
+		storage:      storage,
 		log:          log.With(zap.String("service", "product")),
 	}
 }
 
-func (s *productService) CreateProduct(req request.CreateProductRequest) (*response.ProductResponse, error) {
+func (s *productService) CreateProduct(ctx context.Context, req request.CreateProductRequest) (*response.ProductResponse, error) {
 	s.log.Info("Creating new product", zap.String("name", req.Name))
 
+	if err := checkImageURL(s.storage, req.ImageURL, ""); err != nil {
+		return nil, err
+	}
+
 	// Validate category exists
 	category, err := s.categoryRepo.FindByID(req.CategoryID)
 	if err != nil {
@@ -126,7 +137,12 @@ func (s *productService) CreateProduct(req request.CreateProductRequest) (*respo
 		CategoryID:  req.CategoryID,
 	}
 
-	err = s.productRepo.Create(product)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, entity.AuditActionCreate, auditEntityProduct, product.ID, nil, product)
+	})
 	if err != nil {
 		s.log.Error("Failed to create product in repository", zap.Error(err))
 		return nil, err
@@ -160,7 +176,7 @@ func (s *productService) GetProductByID(id uint) (*response.ProductResponse, err
 	return response.ToProductResponse(product), nil
 }
 
-func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest) (*response.ProductResponse, error) {
+func (s *productService) UpdateProduct(ctx context.Context, id uint, req request.UpdateProductRequest) (*response.ProductResponse, error) {
 	s.log.Info("Updating product", zap.Uint("id", id))
 
 	// Get existing product
@@ -174,6 +190,11 @@ func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest
 		s.log.Warn("Product not found for update", zap.Uint("id", id))
 		return nil, utils.ErrProductNotFound
 	}
+	before := *product
+
+	if err := checkImageURL(s.storage, req.ImageURL, product.ImageURL); err != nil {
+		return nil, err
+	}
 
 	// Check if updating category
 	if req.CategoryID != 0 && req.CategoryID != product.CategoryID {
@@ -233,7 +254,12 @@ func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest
 		return nil, utils.ErrNoChangesProvided
 	}
 
-	err = s.productRepo.Update(product)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.Update(ctx, product); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, entity.AuditActionUpdate, auditEntityProduct, id, before, product)
+	})
 	if err != nil {
 		s.log.Error("Failed to update product in repository", zap.Error(err))
 		return nil, err
@@ -243,7 +269,7 @@ func (s *productService) UpdateProduct(id uint, req request.UpdateProductRequest
 	return response.ToProductResponse(product), nil
 }
 
-func (s *productService) DeleteProduct(id uint) error {
+func (s *productService) DeleteProduct(ctx context.Context, id uint) error {
 	s.log.Info("Deleting product", zap.Uint("id", id))
 
 	// Check if product exists
@@ -271,7 +297,12 @@ func (s *productService) DeleteProduct(id uint) error {
 	}
 
 	// Perform soft delete
-	err = s.productRepo.SoftDelete(id)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.productRepo.SoftDelete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, entity.AuditActionDelete, auditEntityProduct, id, product, nil)
+	})
 	if err != nil {
 		s.log.Error("Failed to delete product from repository", zap.Error(err))
 		return err
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestProductService_DeleteProduct_FailsWithItsAudit(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	productRepo := new(mocks.ProductRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	service := NewProductService(tx, productRepo, nil, auditRepo, nil, zap.NewNop())
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	productRepo.On("FindByID", uint(3)).Return(&entity.Product{Name: "Latte"}, nil)
+	productRepo.On("CheckHasOrderItems", uint(3)).Return(false, nil)
+	productRepo.On("SoftDelete", mock.Anything, uint(3)).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	// The audit is written in the delete's transaction, so its failure
+	// undoes the delete instead of only being logged
+	err := service.DeleteProduct(actorContext(1, entity.RoleAdmin), 3)
+	require.Error(t, err)
+	tx.AssertCalled(t, "WithinTx", mock.Anything)
+}
@@ -6,6 +6,7 @@ import (
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
 	"time"
 
 	"go.uber.org/zap"
@@ -14,19 +15,27 @@ import (
 type ProfileService interface {
 	GetProfile(ctx context.Context) (*response.ProfileResponse, error)
 	UpdateProfile(ctx context.Context, data *request.ProfileRequest) error
+	GetStaffProfile(ctx context.Context, userID uint) (*response.StaffProfileResponse, error)
+	UpdateStaffProfile(ctx context.Context, userID uint, req request.StaffProfileRequest) (*response.StaffProfileResponse, error)
+	ChangeSalary(ctx context.Context, userID uint, req request.SalaryChangeRequest) (*response.SalaryChangeResponse, error)
+	GetSalaryHistory(ctx context.Context, userID uint) ([]response.SalaryChangeResponse, error)
 }
 
 type profileService struct {
-	tx   TxManager
-	repo *repository.Repository
-	log  *zap.Logger
+	tx     TxManager
+	repo   *repository.Repository
+	log     *zap.Logger
+	storage FileStorage
+	config  utils.Configuration
 }
 
-func NewProfileService(tx TxManager, repo *repository.Repository, log *zap.Logger) ProfileService {
+func NewProfileService(tx TxManager, repo *repository.Repository, log *zap.Logger, storage FileStorage, config utils.Configuration) ProfileService {
 	return &profileService{
 		tx: tx,
 		repo: repo,
 		log: log,
+		storage: storage,
+		config: config,
 	}
 }
 
@@ -66,15 +75,34 @@ func (s *profileService) UpdateProfile(ctx context.Context, data *request.Profil
 		FullName: data.FullName,
 		Phone: data.Phone,
 		DateOfBirth: birthday,
-		Salary: data.Salary,
 		ProfileImageURL: data.ProfileImageURL,
 		Address: data.Address,
 		AdditionalDetails: data.AdditionalDetails,
 	}
-	err := s.repo.ProfileRepo.UpdateProfile(ctx, &profile)
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		var currentImage string
+		if before.Profile != nil {
+			currentImage = before.Profile.ProfileImageURL
+		}
+		if err := checkImageURL(s.storage, profile.ProfileImageURL, currentImage); err != nil {
+			return err
+		}
+		if err := s.repo.ProfileRepo.UpdateProfile(ctx, &profile); err != nil {
+			return err
+		}
+		after, err := s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProfile, userID, before.Profile, after.Profile)
+	})
 	if err != nil {
 		s.log.Error("Error update profile service", zap.Error(err))
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -6,7 +6,9 @@ import (
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
 	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
 	"testing"
 	"time"
 
@@ -24,7 +26,7 @@ func TestProfileService_GetProfile_Success(t *testing.T) {
 		ProfileRepo: mockRepo,
 	}
 
-	service := NewProfileService(nil, repo, zap.NewNop())
+	service := NewProfileService(nil, repo, zap.NewNop(), nil, utils.Configuration{})
 
 	ctx := context.WithValue(context.Background(), "user_id", uint(1))
 
@@ -63,7 +65,7 @@ func TestProfileService_GetProfile_Error(t *testing.T) {
 		ProfileRepo: mockRepo,
 	}
 
-	service := NewProfileService(nil, repo, zap.NewNop())
+	service := NewProfileService(nil, repo, zap.NewNop(), nil, utils.Configuration{})
 
 	ctx := context.WithValue(context.Background(), "user_id", uint(1))
 
@@ -84,12 +86,15 @@ func TestProfileService_UpdateProfile_Success(t *testing.T) {
 	require := require.New(t)
 
 	mockRepo := new(mocks.ProfileRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	tx := new(infra.MockTxManager)
 
 	repo := &repository.Repository{
-		ProfileRepo: mockRepo,
+		ProfileRepo:  mockRepo,
+		AuditLogRepo: auditRepo,
 	}
 
-	service := NewProfileService(nil, repo, zap.NewNop())
+	service := NewProfileService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
 
 	ctx := context.WithValue(context.Background(), "user_id", uint(1))
 
@@ -98,28 +103,51 @@ func TestProfileService_UpdateProfile_Success(t *testing.T) {
 		DateOfBirth: "2 January 2000",
 	}
 
+	tx.On("WithinTx", ctx).Return(nil)
+
+	mockRepo.
+		On("GetProfileByID", ctx, uint(1)).
+		Return(&entity.User{ID: 1, Profile: &entity.Profile{UserID: 1, FullName: "Old"}}, nil).
+		Once()
 	mockRepo.
 		On("UpdateProfile", ctx, mock.AnythingOfType("*entity.Profile")).
 		Return(nil).
 		Once()
+	mockRepo.
+		On("GetProfileByID", ctx, uint(1)).
+		Return(&entity.User{ID: 1, Profile: &entity.Profile{UserID: 1, FullName: "Dandi"}}, nil).
+		Once()
+
+	auditRepo.
+		On("Create", ctx, mock.MatchedBy(func(l *entity.AuditLog) bool {
+			return l.Action == entity.AuditActionUpdate &&
+				l.EntityType == auditEntityProfile &&
+				*l.ActorID == 1 &&
+				l.Before == `{"full_name":"Old"}` &&
+				l.After == `{"full_name":"Dandi"}`
+		})).
+		Return(nil).
+		Once()
 
 	err := service.UpdateProfile(ctx, req)
 
 	require.NoError(err)
 
 	mockRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
 }
 
 func TestProfileService_UpdateProfile_Error(t *testing.T) {
 	require := require.New(t)
 
 	mockRepo := new(mocks.ProfileRepoMock)
+	tx := new(infra.MockTxManager)
 
 	repo := &repository.Repository{
 		ProfileRepo: mockRepo,
 	}
 
-	service := NewProfileService(nil, repo, zap.NewNop())
+	service := NewProfileService(tx, repo, zap.NewNop(), nil, utils.Configuration{})
 
 	ctx := context.WithValue(context.Background(), "user_id", uint(1))
 
@@ -128,6 +156,12 @@ func TestProfileService_UpdateProfile_Error(t *testing.T) {
 		DateOfBirth: "2 January 2000",
 	}
 
+	tx.On("WithinTx", ctx).Return(nil)
+
+	mockRepo.
+		On("GetProfileByID", ctx, uint(1)).
+		Return(&entity.User{ID: 1, Profile: &entity.Profile{UserID: 1}}, nil).
+		Once()
 	mockRepo.
 		On("UpdateProfile", ctx, mock.Anything).
 		Return(errors.New("update failed")).
@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Inventory logs written for purchase order receipts use this reference type
+// with ReferenceID pointing at the purchase order.
+const purchaseReferenceType = "purchase"
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(ctx context.Context, req request.CreatePurchaseOrderRequest) (*response.PurchaseOrderResponse, error)
+	GetPurchaseOrders(ctx context.Context, req request.GetPurchaseOrdersRequest) ([]response.PurchaseOrderResponse, response.PaginationMeta, error)
+	GetPurchaseOrderByID(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error)
+	UpdatePurchaseOrder(ctx context.Context, id uint, req request.UpdatePurchaseOrderRequest) (*response.PurchaseOrderResponse, error)
+	SubmitPurchaseOrder(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, id uint, req request.ReceivePurchaseOrderRequest) (*response.PurchaseOrderResponse, error)
+	CancelPurchaseOrder(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error)
+}
+
+type purchaseOrderService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewPurchaseOrderService(tx TxManager, repo *repository.Repository, log *zap.Logger) PurchaseOrderService {
+	return &purchaseOrderService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "purchase_order")),
+	}
+}
+
+func (s *purchaseOrderService) CreatePurchaseOrder(ctx context.Context, req request.CreatePurchaseOrderRequest) (*response.PurchaseOrderResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	if err := s.checkSupplier(ctx, req.SupplierID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.buildItems(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedAt, err := parseOptionalDate(req.ExpectedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := utils.GenerateOTP(4)
+	if err != nil {
+		return nil, err
+	}
+
+	po := &entity.PurchaseOrder{
+		PONumber:   fmt.Sprintf("PO-%s-%s", time.Now().Format("20060102150405"), suffix),
+		SupplierID: req.SupplierID,
+		Status:     entity.PurchaseOrderStatusDraft,
+		ExpectedAt: expectedAt,
+		Notes:      req.Notes,
+		CreatedBy:  userID,
+		Items:      items,
+	}
+	po.TotalAmount = purchaseOrderTotal(po.Items)
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.PurchaseOrderRepo.Create(ctx, po); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityPurchaseOrder, po.ID, nil, po)
+	})
+	if err != nil {
+		s.log.Error("Failed to create purchase order", zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Purchase order created",
+		zap.Uint("id", po.ID),
+		zap.String("po_number", po.PONumber))
+
+	return s.GetPurchaseOrderByID(ctx, po.ID)
+}
+
+func (s *purchaseOrderService) GetPurchaseOrders(ctx context.Context, req request.GetPurchaseOrdersRequest) ([]response.PurchaseOrderResponse, response.PaginationMeta, error) {
+	pos, total, err := s.repo.PurchaseOrderRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get purchase orders", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.PurchaseOrderResponse, 0, len(pos))
+	for _, po := range pos {
+		result = append(result, response.PurchaseOrderToResponse(&po))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrderByID(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error) {
+	po, err := s.findPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.PurchaseOrderToResponse(po)
+	return &resp, nil
+}
+
+func (s *purchaseOrderService) UpdatePurchaseOrder(ctx context.Context, id uint, req request.UpdatePurchaseOrderRequest) (*response.PurchaseOrderResponse, error) {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		po, err := s.lockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.Status != entity.PurchaseOrderStatusDraft {
+			return utils.ErrPurchaseOrderNotEditable
+		}
+		before := *po
+
+		if req.SupplierID > 0 && req.SupplierID != po.SupplierID {
+			if err := s.checkSupplier(ctx, req.SupplierID); err != nil {
+				return err
+			}
+			po.SupplierID = req.SupplierID
+		}
+		if req.ExpectedAt != "" {
+			expectedAt, err := parseOptionalDate(req.ExpectedAt)
+			if err != nil {
+				return err
+			}
+			po.ExpectedAt = expectedAt
+		}
+		if req.Notes != nil {
+			po.Notes = *req.Notes
+		}
+
+		if len(req.Items) > 0 {
+			items, err := s.buildItems(req.Items)
+			if err != nil {
+				return err
+			}
+			if err := s.repo.PurchaseOrderRepo.ReplaceItems(ctx, po.ID, items); err != nil {
+				return err
+			}
+			po.Items = items
+		}
+		po.TotalAmount = purchaseOrderTotal(po.Items)
+
+		if err := s.repo.PurchaseOrderRepo.Update(ctx, po); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityPurchaseOrder, id, before, po)
+	})
+	if err != nil {
+		s.log.Error("Failed to update purchase order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetPurchaseOrderByID(ctx, id)
+}
+
+func (s *purchaseOrderService) SubmitPurchaseOrder(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error) {
+	var po *entity.PurchaseOrder
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		po, err = s.lockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.Status != entity.PurchaseOrderStatusDraft {
+			return utils.ErrInvalidStatusTransition
+		}
+
+		before := *po
+		now := time.Now()
+		po.Status = entity.PurchaseOrderStatusOrdered
+		po.OrderedAt = &now
+		if err := s.repo.PurchaseOrderRepo.Update(ctx, po); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityPurchaseOrder, id, before, po)
+	})
+	if err != nil {
+		s.log.Error("Failed to submit purchase order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Purchase order submitted", zap.Uint("id", id))
+
+	resp := response.PurchaseOrderToResponse(po)
+	return &resp, nil
+}
+
+func (s *purchaseOrderService) ReceivePurchaseOrder(ctx context.Context, id uint, req request.ReceivePurchaseOrderRequest) (*response.PurchaseOrderResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	s.log.Info("Receiving purchase order",
+		zap.Uint("id", id),
+		zap.Int("lines", len(req.Items)))
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Locked, so two receipts cannot both book the same remaining
+		// quantity
+		po, err := s.lockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+		if po.Status != entity.PurchaseOrderStatusOrdered && po.Status != entity.PurchaseOrderStatusPartiallyReceived {
+			return utils.ErrInvalidStatusTransition
+		}
+		before := *po
+
+		received, err := applyReceipt(po, req.Items)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range received {
+			item := line.item
+
+			product, err := s.repo.Product.AdjustStock(ctx, item.ProductID, line.quantity)
+			if err != nil {
+				return err
+			}
+
+			_, err = s.repo.InventoryLogRepo.CreateInventoryLog(ctx, &entity.InventoryLog{
+				ProductID:         item.ProductID,
+				Type:              entity.InventoryLogTypeIn,
+				QuantityChange:    line.quantity,
+				CurrentStockAfter: product.Stock,
+				ReferenceID:       &po.ID,
+				ReferenceType:     purchaseReferenceType,
+				Notes:             receiptNote(po.PONumber, req.Notes),
+				CreatedBy:         userID,
+				CreatedAt:         time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := s.repo.Product.UpdateCostPrice(ctx, item.ProductID, item.UnitCost); err != nil {
+				return err
+			}
+			if err := s.repo.PurchaseOrderRepo.UpdateItem(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		po.Status = purchaseOrderStatusAfterReceipt(po.Items)
+		if po.Status == entity.PurchaseOrderStatusReceived {
+			now := time.Now()
+			po.ReceivedAt = &now
+		}
+		po.TotalAmount = purchaseOrderTotal(po.Items)
+
+		if err := s.repo.PurchaseOrderRepo.Update(ctx, po); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityPurchaseOrder, id, before, po)
+	})
+	if err != nil {
+		s.log.Error("Failed to receive purchase order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetPurchaseOrderByID(ctx, id)
+}
+
+func (s *purchaseOrderService) CancelPurchaseOrder(ctx context.Context, id uint) (*response.PurchaseOrderResponse, error) {
+	var po *entity.PurchaseOrder
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		// Locked, so a receipt booked meanwhile is seen before cancelling
+		po, err = s.lockPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Once goods have arrived the stock movement has happened; cancel
+		// only what has not been received yet.
+		if po.Status != entity.PurchaseOrderStatusDraft && po.Status != entity.PurchaseOrderStatusOrdered {
+			return utils.ErrInvalidStatusTransition
+		}
+
+		before := *po
+		po.Status = entity.PurchaseOrderStatusCancelled
+		if err := s.repo.PurchaseOrderRepo.Update(ctx, po); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityPurchaseOrder, id, before, po)
+	})
+	if err != nil {
+		s.log.Error("Failed to cancel purchase order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Purchase order cancelled", zap.Uint("id", id))
+
+	resp := response.PurchaseOrderToResponse(po)
+	return &resp, nil
+}
+
+func (s *purchaseOrderService) checkSupplier(ctx context.Context, supplierID uint) error {
+	supplier, err := s.repo.SupplierRepo.FindByID(ctx, supplierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrSupplierNotFound
+		}
+		return err
+	}
+	if !supplier.IsActive {
+		return utils.ErrSupplierInactive
+	}
+	return nil
+}
+
+func (s *purchaseOrderService) buildItems(reqs []request.PurchaseOrderItemRequest) ([]entity.PurchaseOrderItem, error) {
+	seen := make(map[uint]bool)
+	items := make([]entity.PurchaseOrderItem, 0, len(reqs))
+
+	for _, r := range reqs {
+		if seen[r.ProductID] {
+			return nil, fmt.Errorf("%w: product %d listed more than once", utils.ErrValidationFailed, r.ProductID)
+		}
+		seen[r.ProductID] = true
+
+		product, err := s.repo.Product.FindByID(r.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, utils.ErrProductNotFound
+		}
+
+		items = append(items, entity.PurchaseOrderItem{
+			ProductID:       r.ProductID,
+			QuantityOrdered: r.Quantity,
+			UnitCost:        r.UnitCost,
+		})
+	}
+
+	return items, nil
+}
+
+func (s *purchaseOrderService) findPurchaseOrder(ctx context.Context, id uint) (*entity.PurchaseOrder, error) {
+	return purchaseOrderOrNotFound(s.repo.PurchaseOrderRepo.FindByID(ctx, id))
+}
+
+// lockPurchaseOrder is findPurchaseOrder holding the row lock until the
+// transaction ends, so status changes and receipts run one after the other.
+func (s *purchaseOrderService) lockPurchaseOrder(ctx context.Context, id uint) (*entity.PurchaseOrder, error) {
+	return purchaseOrderOrNotFound(s.repo.PurchaseOrderRepo.FindByIDForUpdate(ctx, id))
+}
+
+func purchaseOrderOrNotFound(po *entity.PurchaseOrder, err error) (*entity.PurchaseOrder, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return po, nil
+}
+
+type receivedLine struct {
+	item     *entity.PurchaseOrderItem
+	quantity int
+}
+
+// applyReceipt books the received quantities (and any new unit cost) onto the
+// purchase order items and returns the lines that need a stock movement.
+func applyReceipt(po *entity.PurchaseOrder, lines []request.ReceiveItemRequest) ([]receivedLine, error) {
+	byID := make(map[uint]*entity.PurchaseOrderItem, len(po.Items))
+	for i := range po.Items {
+		byID[po.Items[i].ID] = &po.Items[i]
+	}
+
+	received := make([]receivedLine, 0, len(lines))
+	for _, line := range lines {
+		item, ok := byID[line.ItemID]
+		if !ok {
+			return nil, utils.ErrPurchaseOrderItemNotFound
+		}
+		if line.Quantity > item.RemainingQuantity() {
+			return nil, fmt.Errorf("%w: item %d has %d remaining", utils.ErrReceiveQuantityExceeded, item.ID, item.RemainingQuantity())
+		}
+
+		item.QuantityReceived += line.Quantity
+		if line.UnitCost != nil {
+			item.UnitCost = *line.UnitCost
+		}
+
+		received = append(received, receivedLine{item: item, quantity: line.Quantity})
+	}
+
+	return received, nil
+}
+
+func purchaseOrderStatusAfterReceipt(items []entity.PurchaseOrderItem) entity.PurchaseOrderStatus {
+	for _, item := range items {
+		if item.RemainingQuantity() > 0 {
+			return entity.PurchaseOrderStatusPartiallyReceived
+		}
+	}
+	return entity.PurchaseOrderStatusReceived
+}
+
+func purchaseOrderTotal(items []entity.PurchaseOrderItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.UnitCost * float64(item.QuantityOrdered)
+	}
+	return total
+}
+
+func receiptNote(poNumber, notes string) string {
+	if notes == "" {
+		return poNumber
+	}
+	return poNumber + ": " + notes
+}
+
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	return &t, nil
+}
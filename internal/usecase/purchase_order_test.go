@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func purchaseOrderFixture() *entity.PurchaseOrder {
+	return &entity.PurchaseOrder{
+		Items: []entity.PurchaseOrderItem{
+			{ID: 1, ProductID: 10, QuantityOrdered: 10, UnitCost: 1000},
+			{ID: 2, ProductID: 11, QuantityOrdered: 5, QuantityReceived: 2, UnitCost: 2000},
+		},
+	}
+}
+
+func TestApplyReceipt_PartialThenFull(t *testing.T) {
+	require := require.New(t)
+
+	po := purchaseOrderFixture()
+	newCost := float64(1200)
+
+	lines, err := applyReceipt(po, []request.ReceiveItemRequest{
+		{ItemID: 1, Quantity: 4, UnitCost: &newCost},
+	})
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal(4, lines[0].quantity)
+	require.Equal(4, po.Items[0].QuantityReceived)
+	require.Equal(newCost, po.Items[0].UnitCost)
+	require.Equal(entity.PurchaseOrderStatusPartiallyReceived, purchaseOrderStatusAfterReceipt(po.Items))
+
+	_, err = applyReceipt(po, []request.ReceiveItemRequest{
+		{ItemID: 1, Quantity: 6},
+		{ItemID: 2, Quantity: 3},
+	})
+	require.NoError(err)
+	require.Equal(entity.PurchaseOrderStatusReceived, purchaseOrderStatusAfterReceipt(po.Items))
+}
+
+func TestApplyReceipt_Errors(t *testing.T) {
+	_, err := applyReceipt(purchaseOrderFixture(), []request.ReceiveItemRequest{{ItemID: 2, Quantity: 4}})
+	require.True(t, errors.Is(err, utils.ErrReceiveQuantityExceeded))
+
+	_, err = applyReceipt(purchaseOrderFixture(), []request.ReceiveItemRequest{{ItemID: 99, Quantity: 1}})
+	require.True(t, errors.Is(err, utils.ErrPurchaseOrderItemNotFound))
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"project-POS-APP-golang-integer/pkg/utils/receipt"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ReceiptService interface {
+	GetReceipt(ctx context.Context, orderID uint) (*response.ReceiptResponse, error)
+	RenderReceipt(ctx context.Context, orderID uint, req request.ReceiptRequest) ([]byte, string, error)
+	EmailReceipt(ctx context.Context, orderID uint) (*response.ReceiptEmailResponse, error)
+}
+
+type receiptService struct {
+	repo   *repository.Repository
+	log    *zap.Logger
+	email  EmailSender
+	config utils.Configuration
+}
+
+func NewReceiptService(repo *repository.Repository, log *zap.Logger, email EmailSender, config utils.Configuration) ReceiptService {
+	return &receiptService{
+		repo:   repo,
+		log:    log.With(zap.String("service", "receipt")),
+		email:  email,
+		config: config,
+	}
+}
+
+func (s *receiptService) GetReceipt(ctx context.Context, orderID uint) (*response.ReceiptResponse, error) {
+	_, r, err := s.load(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// RenderReceipt returns the receipt in the requested format along with its
+// content type.
+func (s *receiptService) RenderReceipt(ctx context.Context, orderID uint, req request.ReceiptRequest) ([]byte, string, error) {
+	_, r, err := s.load(ctx, orderID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	paperWidth := req.PaperWidth
+	if paperWidth == 0 {
+		paperWidth = s.config.Receipt.PaperWidth
+	}
+
+	body, contentType, err := renderReceipt(r, req.Format, paperWidth)
+	if err != nil {
+		s.log.Error("Failed to render receipt", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, "", err
+	}
+	return body, contentType, nil
+}
+
+// EmailReceipt sends the receipt to the order's customer, with the PDF attached.
+func (s *receiptService) EmailReceipt(ctx context.Context, orderID uint) (*response.ReceiptEmailResponse, error) {
+	order, r, err := s.load(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.CustomerID == nil || strings.TrimSpace(order.Customer.Email) == "" {
+		return nil, utils.ErrCustomerNoEmail
+	}
+
+	body, err := receipt.HTML(r)
+	if err != nil {
+		s.log.Error("Failed to render receipt email", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+
+	err = s.email.Send(ctx, request.EmailRequest{
+		To:      order.Customer.Email,
+		Subject: fmt.Sprintf("Your receipt for order %s", order.OrderNumber),
+		Body:    body,
+		Attachments: []request.Attachment{{
+			FileName:    fmt.Sprintf("receipt-%s.pdf", order.OrderNumber),
+			FileByte:    receipt.PDF(r),
+			ContentType: "application/pdf",
+		}},
+	})
+	if err != nil {
+		s.log.Error("Failed to send receipt email", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Receipt emailed", zap.Uint("order_id", orderID), zap.String("email", order.Customer.Email))
+	return &response.ReceiptEmailResponse{
+		OrderNumber: order.OrderNumber,
+		Email:       order.Customer.Email,
+	}, nil
+}
+
+func (s *receiptService) load(ctx context.Context, orderID uint) (*entity.Order, response.ReceiptResponse, error) {
+	order, err := s.repo.OrderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, response.ReceiptResponse{}, utils.ErrOrderNotFound
+		}
+		return nil, response.ReceiptResponse{}, err
+	}
+
+	if order.Status != entity.OrderStatusCompleted {
+		return nil, response.ReceiptResponse{}, utils.ErrOrderNotCompleted
+	}
+
+	transactions, err := s.repo.TransactionRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, response.ReceiptResponse{}, err
+	}
+
+	r := buildReceipt(order, transactions, s.config.Receipt)
+	r.IssuedAt = r.IssuedAt.In(utils.LoadLocation(s.config.BusinessRules.Timezone))
+	return order, r, nil
+}
+
+// buildReceipt resolves an order and its transactions into receipt lines.
+// Only completed transactions count; change is whatever was paid beyond the
+// total.
+func buildReceipt(order *entity.Order, transactions []entity.Transaction, outlet utils.ReceiptConfig) response.ReceiptResponse {
+	r := response.ReceiptResponse{
+		OutletName:    outlet.OutletName,
+		Address:       outlet.Address,
+		Phone:         outlet.Phone,
+		Header:        splitReceiptLines(outlet.Header),
+		Footer:        splitReceiptLines(outlet.Footer),
+		OrderNumber:   order.OrderNumber,
+		TableNumber:   order.Table.TableNumber,
+		IssuedAt:      order.UpdatedAt,
+		Items:         make([]response.ReceiptItem, 0, len(order.OrderItems)),
+		Subtotal:      order.Subtotal,
+		Discount:      order.DiscountAmount,
+		TaxPercentage: order.TaxPercentage,
+		TaxAmount:     order.TaxAmount,
+		Total:         order.Total,
+		Payments:      []response.ReceiptPayment{},
+	}
+	if order.CustomerID != nil {
+		r.CustomerName = strings.TrimSpace(order.Customer.FirstName + " " + order.Customer.LastName)
+	}
+
+	for _, item := range order.OrderItems {
+		modifiers := make([]string, 0, len(item.Modifiers))
+		for _, m := range item.Modifiers {
+			label := m.OptionName
+			if m.PriceDelta != 0 {
+				label = fmt.Sprintf("%s (%s)", m.OptionName, receipt.Amount(m.PriceDelta))
+			}
+			modifiers = append(modifiers, label)
+		}
+		r.Items = append(r.Items, response.ReceiptItem{
+			Name:      item.Product.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Total:     item.TotalPrice,
+			Modifiers: modifiers,
+			Notes:     item.Notes,
+		})
+	}
+
+	for _, t := range transactions {
+		if t.Status != entity.TransactionStatusCompleted {
+			continue
+		}
+		r.Payments = append(r.Payments, response.ReceiptPayment{
+			Method: t.PaymentMethod.Name,
+			Type:   string(t.TransactionType),
+			Amount: t.Amount,
+		})
+		switch t.TransactionType {
+		case entity.TransactionTypePayment:
+			r.Paid += t.Amount
+		case entity.TransactionTypeRefund:
+			r.Refunded += t.Amount
+		}
+	}
+
+	if r.Paid > r.Total {
+		r.Change = roundTo(r.Paid-r.Total, 2)
+	}
+
+	return r
+}
+
+// renderReceipt encodes a receipt as html, pdf or escpos.
+func renderReceipt(r response.ReceiptResponse, format string, paperWidth int) ([]byte, string, error) {
+	switch format {
+	case "html":
+		body, err := receipt.HTML(r)
+		return []byte(body), "text/html; charset=utf-8", err
+	case "pdf":
+		return receipt.PDF(r), "application/pdf", nil
+	case "escpos":
+		return receipt.ESCPOS(r, paperWidth), "application/octet-stream", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported receipt format %q", format)
+	}
+}
+
+func splitReceiptLines(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(s, "|") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
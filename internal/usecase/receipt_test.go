@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"bytes"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"project-POS-APP-golang-integer/pkg/utils/receipt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func receiptOrder() *entity.Order {
+	customerID := uint(7)
+	return &entity.Order{
+		OrderNumber:   "ORD-20260301-0001",
+		CustomerID:    &customerID,
+		Customer:      entity.Customer{FirstName: "Budi", LastName: "Santoso", Email: "budi@example.com"},
+		Table:         entity.Table{TableNumber: "T05"},
+		Status:        entity.OrderStatusCompleted,
+		Subtotal:      60000,
+		TaxPercentage: 10,
+		TaxAmount:     6000,
+		Total:         66000,
+		OrderItems: []entity.OrderItem{
+			{
+				Product:    entity.Product{Name: "Nasi Goreng Spesial Dengan Telur Mata Sapi"},
+				Quantity:   2,
+				UnitPrice:  25000,
+				TotalPrice: 50000,
+				Modifiers:  []entity.OrderItemModifier{{OptionName: "Extra egg", PriceDelta: 5000}, {OptionName: "Spicy"}},
+			},
+			{Product: entity.Product{Name: "Es Teh"}, Quantity: 1, UnitPrice: 10000, TotalPrice: 10000, Notes: "less sugar"},
+		},
+	}
+}
+
+func TestBuildReceipt_ComputesPaidAndChange(t *testing.T) {
+	transactions := []entity.Transaction{
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, Amount: 50000, PaymentMethod: entity.PaymentMethod{Name: "Cash"}},
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, Amount: 20000, PaymentMethod: entity.PaymentMethod{Name: "Card"}},
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusFailed, Amount: 66000, PaymentMethod: entity.PaymentMethod{Name: "QRIS"}},
+	}
+
+	r := buildReceipt(receiptOrder(), transactions, utils.ReceiptConfig{OutletName: "POS", Footer: "Thanks | See you"})
+
+	require.Len(t, r.Payments, 2)
+	require.Equal(t, 70000.0, r.Paid)
+	require.Equal(t, 4000.0, r.Change)
+	require.Equal(t, "Budi Santoso", r.CustomerName)
+	require.Equal(t, []string{"Thanks", "See you"}, r.Footer)
+	require.Equal(t, []string{"Extra egg (5,000.00)", "Spicy"}, r.Items[0].Modifiers)
+}
+
+func TestBuildReceipt_NoChangeWhenUnderpaid(t *testing.T) {
+	transactions := []entity.Transaction{
+		{TransactionType: entity.TransactionTypePayment, Status: entity.TransactionStatusCompleted, Amount: 66000},
+		{TransactionType: entity.TransactionTypeRefund, Status: entity.TransactionStatusCompleted, Amount: 10000},
+	}
+
+	r := buildReceipt(receiptOrder(), transactions, utils.ReceiptConfig{})
+
+	require.Equal(t, 0.0, r.Change)
+	require.Equal(t, 10000.0, r.Refunded)
+}
+
+func TestRenderReceipt_ESCPOSFitsPaperWidth(t *testing.T) {
+	r := buildReceipt(receiptOrder(), nil, utils.ReceiptConfig{OutletName: "POS Integer", Address: "Jl. Sudirman No. 1, Jakarta Pusat"})
+
+	for _, width := range []int{58, 80} {
+		body, contentType, err := renderReceipt(r, "escpos", width)
+		require.NoError(t, err)
+		require.Equal(t, "application/octet-stream", contentType)
+		require.True(t, bytes.HasPrefix(body, []byte{0x1B, 0x40}))
+
+		for _, line := range receipt.Lines(r, receipt.Columns(width)) {
+			require.LessOrEqual(t, len(line), receipt.Columns(width), line)
+		}
+	}
+}
+
+func TestRenderReceipt_PDF(t *testing.T) {
+	r := buildReceipt(receiptOrder(), nil, utils.ReceiptConfig{OutletName: "POS (Integer)"})
+
+	body, contentType, err := renderReceipt(r, "pdf", 0)
+
+	require.NoError(t, err)
+	require.Equal(t, "application/pdf", contentType)
+	require.True(t, strings.HasPrefix(string(body), "%PDF-1.4"))
+	require.True(t, strings.HasSuffix(string(body), "%%EOF\n"))
+	require.Contains(t, string(body), `POS \(Integer\)`)
+}
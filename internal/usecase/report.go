@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultReportDays is the range used when no dates are given, ending today.
+	defaultReportDays = 30
+
+	defaultTopProducts   = 10
+	dashboardTopProducts = 5
+)
+
+type ReportService interface {
+	GetSummary(ctx context.Context, req request.ReportRequest) (*response.SalesSummaryResponse, error)
+	GetRevenue(ctx context.Context, req request.RevenueReportRequest) (*response.RevenueReportResponse, error)
+	GetSalesByCategory(ctx context.Context, req request.ReportRequest) ([]response.CategorySalesResponse, error)
+	GetSalesByProduct(ctx context.Context, req request.ProductSalesReportRequest) ([]response.ProductSalesResponse, error)
+	GetSalesByPaymentMethod(ctx context.Context, req request.ReportRequest) ([]response.PaymentMethodSalesResponse, error)
+	GetPeakHours(ctx context.Context, req request.ReportRequest) ([]response.PeakHourResponse, error)
+	GetDashboard(ctx context.Context, req request.ReportRequest) (*response.DashboardResponse, error)
+}
+
+type reportService struct {
+	repo   *repository.Repository
+	log    *zap.Logger
+	config utils.Configuration
+}
+
+func NewReportService(repo *repository.Repository, log *zap.Logger, config utils.Configuration) ReportService {
+	return &reportService{
+		repo:   repo,
+		log:    log.With(zap.String("service", "report")),
+		config: config,
+	}
+}
+
+// reportWindow is a resolved report range with its location.
+type reportWindow struct {
+	rng       repository.ReportRange
+	loc       *time.Location
+	startDate string
+	endDate   string
+}
+
+func (w reportWindow) meta() response.ReportRangeResponse {
+	return response.ReportRangeResponse{
+		StartDate: w.startDate,
+		EndDate:   w.endDate,
+		Timezone:  w.rng.Timezone,
+	}
+}
+
+func (s *reportService) GetSummary(ctx context.Context, req request.ReportRequest) (*response.SalesSummaryResponse, error) {
+	w, err := s.window(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.summary(ctx, w)
+}
+
+func (s *reportService) GetRevenue(ctx context.Context, req request.RevenueReportRequest) (*response.RevenueReportResponse, error) {
+	w, err := s.window(req.ReportRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	period := req.Period
+	if period == "" {
+		period = "day"
+	}
+
+	points, err := s.revenue(ctx, w, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.RevenueReportResponse{
+		ReportRangeResponse: w.meta(),
+		Period:              period,
+		Points:              points,
+	}, nil
+}
+
+func (s *reportService) GetSalesByCategory(ctx context.Context, req request.ReportRequest) ([]response.CategorySalesResponse, error) {
+	w, err := s.window(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.categories(ctx, w)
+}
+
+func (s *reportService) GetSalesByProduct(ctx context.Context, req request.ProductSalesReportRequest) ([]response.ProductSalesResponse, error) {
+	w, err := s.window(req.ReportRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTopProducts
+	}
+	return s.products(ctx, w, limit)
+}
+
+func (s *reportService) GetSalesByPaymentMethod(ctx context.Context, req request.ReportRequest) ([]response.PaymentMethodSalesResponse, error) {
+	w, err := s.window(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.paymentMethods(ctx, w)
+}
+
+func (s *reportService) GetPeakHours(ctx context.Context, req request.ReportRequest) ([]response.PeakHourResponse, error) {
+	w, err := s.window(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.peakHours(ctx, w)
+}
+
+func (s *reportService) GetDashboard(ctx context.Context, req request.ReportRequest) (*response.DashboardResponse, error) {
+	w, err := s.window(req)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.summary(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := s.revenue(ctx, w, "day")
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.products(ctx, w, dashboardTopProducts)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categories(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := s.paymentMethods(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.peakHours(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.DashboardResponse{
+		Summary:        *summary,
+		DailyRevenue:   daily,
+		TopProducts:    products,
+		Categories:     categories,
+		PaymentMethods: methods,
+		PeakHours:      hours,
+	}, nil
+}
+
+func (s *reportService) summary(ctx context.Context, w reportWindow) (*response.SalesSummaryResponse, error) {
+	row, err := s.repo.ReportRepo.GetSalesSummary(ctx, w.rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.SalesSummaryResponse{
+		ReportRangeResponse: w.meta(),
+		OrderCount:          row.OrderCount,
+		Subtotal:            roundTo(row.Subtotal, 2),
+		TaxAmount:           roundTo(row.TaxAmount, 2),
+		Revenue:             roundTo(row.Revenue, 2),
+		AverageTicket:       averageTicket(row.Revenue, row.OrderCount),
+	}, nil
+}
+
+func (s *reportService) revenue(ctx context.Context, w reportWindow, period string) ([]response.RevenuePoint, error) {
+	rows, err := s.repo.ReportRepo.GetRevenueSeries(ctx, w.rng, period)
+	if err != nil {
+		return nil, err
+	}
+	return fillRevenueSeries(rows, period, w.rng.From, w.rng.To, w.loc), nil
+}
+
+func (s *reportService) categories(ctx context.Context, w reportWindow) ([]response.CategorySalesResponse, error) {
+	rows, err := s.repo.ReportRepo.GetSalesByCategory(ctx, w.rng)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, row := range rows {
+		total += row.Revenue
+	}
+
+	result := make([]response.CategorySalesResponse, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, response.CategorySalesResponse{
+			CategoryID:   row.CategoryID,
+			CategoryName: row.CategoryName,
+			Quantity:     row.Quantity,
+			Revenue:      roundTo(row.Revenue, 2),
+			RevenueShare: percentOf(row.Revenue, total),
+		})
+	}
+	return result, nil
+}
+
+func (s *reportService) products(ctx context.Context, w reportWindow, limit int) ([]response.ProductSalesResponse, error) {
+	rows, err := s.repo.ReportRepo.GetSalesByProduct(ctx, w.rng, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ProductSalesResponse, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, response.ProductSalesResponse{
+			ProductID:    row.ProductID,
+			ProductName:  row.ProductName,
+			CategoryName: row.CategoryName,
+			Quantity:     row.Quantity,
+			Revenue:      roundTo(row.Revenue, 2),
+		})
+	}
+	return result, nil
+}
+
+func (s *reportService) paymentMethods(ctx context.Context, w reportWindow) ([]response.PaymentMethodSalesResponse, error) {
+	rows, err := s.repo.ReportRepo.GetSalesByPaymentMethod(ctx, w.rng)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, row := range rows {
+		total += row.Payments - row.Refunds
+	}
+
+	result := make([]response.PaymentMethodSalesResponse, 0, len(rows))
+	for _, row := range rows {
+		net := row.Payments - row.Refunds
+		result = append(result, response.PaymentMethodSalesResponse{
+			PaymentMethodID:   row.PaymentMethodID,
+			PaymentMethodName: row.PaymentMethodName,
+			TransactionCount:  row.TransactionCount,
+			Payments:          roundTo(row.Payments, 2),
+			Refunds:           roundTo(row.Refunds, 2),
+			Net:               roundTo(net, 2),
+			Share:             percentOf(net, total),
+		})
+	}
+	return result, nil
+}
+
+func (s *reportService) peakHours(ctx context.Context, w reportWindow) ([]response.PeakHourResponse, error) {
+	rows, err := s.repo.ReportRepo.GetPeakHours(ctx, w.rng)
+	if err != nil {
+		return nil, err
+	}
+	return fillPeakHours(rows), nil
+}
+
+// window resolves the requested dates and timezone. Without dates the report
+// covers the last defaultReportDays days up to and including today.
+func (s *reportService) window(req request.ReportRequest) (reportWindow, error) {
+	tz := req.Timezone
+	if tz == "" {
+		tz = s.config.BusinessRules.Timezone
+	}
+	// The name is also handed to Postgres, which knows no "Local"
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		return reportWindow{}, utils.ErrInvalidTimezone
+	}
+
+	startDate, endDate := req.StartDate, req.EndDate
+	if endDate == "" {
+		endDate = time.Now().In(loc).Format("2006-01-02")
+	}
+	if startDate == "" {
+		end, err := time.ParseInLocation("2006-01-02", endDate, loc)
+		if err != nil {
+			return reportWindow{}, utils.ErrInvalidDateFormat
+		}
+		startDate = end.AddDate(0, 0, -(defaultReportDays - 1)).Format("2006-01-02")
+	}
+
+	start, end, err := parseDateRangeIn(startDate, endDate, loc)
+	if err != nil {
+		return reportWindow{}, err
+	}
+
+	return reportWindow{
+		rng: repository.ReportRange{
+			From:     *start,
+			To:       *end,
+			Timezone: tz,
+		},
+		loc:       loc,
+		startDate: startDate,
+		endDate:   endDate,
+	}, nil
+}
+
+// fillRevenueSeries returns one point per period between from and to, with
+// zero rows for periods that had no orders. Buckets from the database are
+// local wall-clock times without a zone.
+func fillRevenueSeries(rows []repository.RevenueBucketRow, period string, from, to time.Time, loc *time.Location) []response.RevenuePoint {
+	byKey := make(map[string]repository.RevenueBucketRow, len(rows))
+	for _, row := range rows {
+		byKey[row.Bucket.Format("2006-01-02")] = row
+	}
+
+	points := []response.RevenuePoint{}
+	for cur := periodStart(from.In(loc), period); cur.Before(to); cur = nextPeriod(cur, period) {
+		key := cur.Format("2006-01-02")
+		row := byKey[key]
+		points = append(points, response.RevenuePoint{
+			PeriodStart:   key,
+			OrderCount:    row.OrderCount,
+			Revenue:       roundTo(row.Revenue, 2),
+			AverageTicket: averageTicket(row.Revenue, row.OrderCount),
+		})
+	}
+	return points
+}
+
+func periodStart(t time.Time, period string) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	switch period {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(t time.Time, period string) time.Time {
+	switch period {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// fillPeakHours expands the hourly rows to all 24 hours of the day.
+func fillPeakHours(rows []repository.PeakHourRow) []response.PeakHourResponse {
+	hours := make([]response.PeakHourResponse, 24)
+	for h := range hours {
+		hours[h].Hour = h
+	}
+	for _, row := range rows {
+		if row.Hour < 0 || row.Hour > 23 {
+			continue
+		}
+		hours[row.Hour] = response.PeakHourResponse{
+			Hour:          row.Hour,
+			OrderCount:    row.OrderCount,
+			Revenue:       roundTo(row.Revenue, 2),
+			AverageTicket: averageTicket(row.Revenue, row.OrderCount),
+		}
+	}
+	return hours
+}
+
+func averageTicket(revenue float64, orders int64) float64 {
+	if orders == 0 {
+		return 0
+	}
+	return roundTo(revenue/float64(orders), 2)
+}
+
+func percentOf(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundTo(part/total*100, 2)
+}
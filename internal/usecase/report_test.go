@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillRevenueSeries_FillsMissingDays(t *testing.T) {
+	loc := time.UTC
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 4, 0, 0, 0, 0, loc)
+	rows := []repository.RevenueBucketRow{
+		{Bucket: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), OrderCount: 4, Revenue: 100000},
+	}
+
+	points := fillRevenueSeries(rows, "day", from, to, loc)
+
+	require.Len(t, points, 3)
+	require.Equal(t, "2026-03-01", points[0].PeriodStart)
+	require.Equal(t, int64(0), points[0].OrderCount)
+	require.Equal(t, "2026-03-02", points[1].PeriodStart)
+	require.Equal(t, 25000.0, points[1].AverageTicket)
+}
+
+func TestFillRevenueSeries_WeeksStartOnMonday(t *testing.T) {
+	loc := time.UTC
+	// Sunday 1 March to Sunday 15 March 2026
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 16, 0, 0, 0, 0, loc)
+
+	points := fillRevenueSeries(nil, "week", from, to, loc)
+
+	require.Len(t, points, 3)
+	require.Equal(t, "2026-02-23", points[0].PeriodStart)
+	require.Equal(t, "2026-03-09", points[2].PeriodStart)
+}
+
+func TestFillPeakHours(t *testing.T) {
+	hours := fillPeakHours([]repository.PeakHourRow{{Hour: 12, OrderCount: 3, Revenue: 90000}})
+
+	require.Len(t, hours, 24)
+	require.Equal(t, 12, hours[12].Hour)
+	require.Equal(t, 30000.0, hours[12].AverageTicket)
+	require.Equal(t, int64(0), hours[13].OrderCount)
+}
+
+func TestReportWindow_Timezone(t *testing.T) {
+	s := &reportService{config: utils.Configuration{BusinessRules: utils.BusinessRules{Timezone: "UTC"}}}
+
+	w, err := s.window(request.ReportRequest{StartDate: "2026-03-01", EndDate: "2026-03-01", Timezone: "Asia/Jakarta"})
+	if err != nil && errors.Is(err, utils.ErrInvalidTimezone) {
+		t.Skip("tzdata not available")
+	}
+	require.NoError(t, err)
+	// Midnight in Jakarta is 17:00 UTC the day before
+	require.Equal(t, time.Date(2026, 2, 28, 17, 0, 0, 0, time.UTC), w.rng.From.UTC())
+	require.Equal(t, 24*time.Hour, w.rng.To.Sub(w.rng.From))
+
+	_, err = s.window(request.ReportRequest{Timezone: "Mars/Olympus"})
+	require.True(t, errors.Is(err, utils.ErrInvalidTimezone))
+}
+
+func TestReportWindow_DefaultsToUTC(t *testing.T) {
+	s := &reportService{}
+
+	w, err := s.window(request.ReportRequest{StartDate: "2026-03-01", EndDate: "2026-03-01"})
+	require.NoError(t, err)
+	require.Equal(t, "UTC", w.rng.Timezone)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), w.rng.From.UTC())
+
+	_, err = s.window(request.ReportRequest{Timezone: "Local"})
+	require.ErrorIs(t, err, utils.ErrInvalidTimezone)
+}
@@ -8,6 +8,7 @@ import (
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/dto/response"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -22,6 +23,7 @@ type ReservationService interface {
 	CancelReservation(ctx context.Context, id uint, reason string) error
 	CheckIn(ctx context.Context, id uint) error
 	GetAvailableTables(ctx context.Context, dateStr, timeStr string, paxNumber int) ([]response.TableResponse, error)
+	ExportReservations(ctx context.Context, req request.GetReservationsRequest, w utils.ExportWriter) error
 }
 
 type reservationService struct {
@@ -48,10 +50,10 @@ func (s *reservationService) CreateReservation(ctx context.Context, req request.
 		zap.Int("pax_number", req.Reservation.PaxNumber))
 
 	// 1. Validate request
-	if validationErrors, err := utils.ValidateErrors(req); err != nil {
+	if err := utils.Validate(req); err != nil {
 		s.log.Warn("Validation failed",
-			zap.Any("errors", validationErrors))
-		return nil, utils.ErrValidationFailed
+			zap.Error(err))
+		return nil, err
 	}
 
 	// 2. Parse dates
@@ -203,6 +205,9 @@ func (s *reservationService) CreateReservation(ctx context.Context, req request.
 				zap.Error(err))
 			return err
 		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityReservation, reservation.ID, nil, reservation); err != nil {
+			return err
+		}
 
 		// 8. Update table status
 		if err := s.repo.TableRepo.UpdateStatus(ctx, table.ID, entity.TableStatusReserved); err != nil {
@@ -348,6 +353,11 @@ func (s *reservationService) UpdateReservationStatus(ctx context.Context, id uin
 				zap.Error(err))
 			return err
 		}
+		after := *reservation
+		after.Status = reservationStatus
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityReservation, id, reservation, after); err != nil {
+			return err
+		}
 
 		// If cancelled or completed, free the table
 		if reservationStatus == entity.ReservationStatusCancelled ||
@@ -390,6 +400,7 @@ func (s *reservationService) CancelReservation(ctx context.Context, id uint, rea
 		}
 
 		// Update to cancelled
+		before := *reservation
 		reservation.Status = entity.ReservationStatusCancelled
 		if reason != "" {
 			reservation.Notes += "\nCancellation reason: " + reason
@@ -401,6 +412,9 @@ func (s *reservationService) CancelReservation(ctx context.Context, id uint, rea
 				zap.Error(err))
 			return err
 		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityReservation, id, before, reservation); err != nil {
+			return err
+		}
 
 		// Free the table
 		if err := s.repo.TableRepo.UpdateStatus(ctx, reservation.TableID, entity.TableStatusAvailable); err != nil {
@@ -436,6 +450,7 @@ func (s *reservationService) CheckIn(ctx context.Context, id uint) error {
 		}
 
 		// Update check in time
+		before := *reservation
 		now := time.Now()
 		reservation.CheckOutAt = &now
 
@@ -453,6 +468,9 @@ func (s *reservationService) CheckIn(ctx context.Context, id uint) error {
 				zap.Error(err))
 			return err
 		}
+		if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityReservation, id, before, reservation); err != nil {
+			return err
+		}
 
 		s.log.Info("Reservation checked in successfully", zap.Uint("id", id))
 		return nil
@@ -546,3 +564,28 @@ func (s *reservationService) isValidStatusTransition(from, to entity.Reservation
 
 	return false
 }
+
+// ExportReservations writes every reservation matching the filters.
+func (s *reservationService) ExportReservations(ctx context.Context, req request.GetReservationsRequest, w utils.ExportWriter) error {
+	if err := w.WriteRow("id", "reservation_date", "reservation_time", "status", "customer", "phone", "email", "table", "pax", "deposit_fee", "notes", "created_at"); err != nil {
+		return err
+	}
+
+	err := s.repo.ReservationRepo.Stream(ctx, req, func(reservations []entity.Reservation) error {
+		for _, r := range reservations {
+			name := strings.TrimSpace(r.Customer.FirstName + " " + r.Customer.LastName)
+			err := w.WriteRow(r.ID, r.ReservationDate.Format("2006-01-02"), r.ReservationTime.Format("15:04"), string(r.Status),
+				name, r.Customer.Phone, r.Customer.Email, r.Table.TableNumber, r.PaxNumber, r.DepositFee, r.Notes, r.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to export reservations", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReservationService_CreateReservation_ValidationFields(t *testing.T) {
+	service := NewReservationService(nil, &repository.Repository{}, zap.NewNop())
+
+	var req request.CreateReservationRequest
+	req.Customer.FirstName = "A"
+	req.Customer.Phone = "08123"
+	req.Reservation.PaxNumber = 30
+	req.Reservation.ReservationDate = "2026-01-01"
+	req.Reservation.ReservationTime = "19:00"
+
+	_, err := service.CreateReservation(context.Background(), req)
+	require.ErrorIs(t, err, utils.ErrValidationFailed)
+
+	apiErr := utils.AsError(err)
+	require.Equal(t, "validation_failed", apiErr.Code)
+	require.Equal(t, http.StatusBadRequest, apiErr.Status)
+	require.Equal(t, []utils.FieldError{
+		{Field: "customer.first_name", Code: "min", Param: "2", Message: "customer.first_name must be at least 2 characters long"},
+		{Field: "reservation.pax_number", Code: "max", Param: "20", Message: "reservation.pax_number must be at most 20"},
+	}, apiErr.Fields)
+
+	// The shared error is left without fields
+	require.Empty(t, utils.ErrValidationFailed.Fields)
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// upcomingShiftLimit caps how many shifts staff see on their profile.
+const upcomingShiftLimit = 50
+
+type ShiftService interface {
+	CreateShift(ctx context.Context, req request.CreateShiftRequest) (*response.ShiftResponse, error)
+	BulkAssignShifts(ctx context.Context, req request.BulkAssignShiftsRequest) (*response.BulkShiftResponse, error)
+	CopyWeek(ctx context.Context, req request.CopyWeekRequest) (*response.BulkShiftResponse, error)
+	GetShifts(ctx context.Context, req request.GetShiftsRequest) ([]response.ShiftResponse, response.PaginationMeta, error)
+	GetShiftByID(ctx context.Context, id uint) (*response.ShiftResponse, error)
+	UpdateShift(ctx context.Context, id uint, req request.UpdateShiftRequest) (*response.ShiftResponse, error)
+	DeleteShift(ctx context.Context, id uint) error
+	GetMyUpcomingShifts(ctx context.Context) ([]response.ShiftResponse, error)
+}
+
+type shiftService struct {
+	tx     TxManager
+	repo   *repository.Repository
+	log    *zap.Logger
+	config utils.Configuration
+	loc    *time.Location
+}
+
+func NewShiftService(tx TxManager, repo *repository.Repository, log *zap.Logger, config utils.Configuration) ShiftService {
+	return &shiftService{
+		tx:     tx,
+		repo:   repo,
+		log:    log.With(zap.String("service", "shift")),
+		config: config,
+		loc:    utils.LoadLocation(config.BusinessRules.Timezone),
+	}
+}
+
+func (s *shiftService) CreateShift(ctx context.Context, req request.CreateShiftRequest) (*response.ShiftResponse, error) {
+	profile, err := s.findProfile(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := s.window(req.Date, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps, err := s.repo.ShiftRepo.FindOverlapping(ctx, profile.ID, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlaps) > 0 {
+		return nil, fmt.Errorf("%w: %s", utils.ErrShiftOverlap, overlapDetail(profile.ID, overlaps[0]))
+	}
+
+	shift := s.newShift(profile.ID, start, end, req.Notes)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := s.repo.ShiftRepo.Create(ctx, shift)
+		if err != nil {
+			return err
+		}
+		shift = created
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityShift, shift.ID, nil, shift)
+	})
+	if err != nil {
+		s.log.Error("Failed to create shift", zap.Error(err))
+		return nil, err
+	}
+	shift.Profile = *profile
+
+	s.log.Info("Shift created",
+		zap.Uint("id", shift.ID),
+		zap.Uint("profile_id", shift.ProfileID),
+		zap.Time("start", shift.ShiftStart))
+
+	resp := response.ShiftToResponse(shift)
+	return &resp, nil
+}
+
+func (s *shiftService) BulkAssignShifts(ctx context.Context, req request.BulkAssignShiftsRequest) (*response.BulkShiftResponse, error) {
+	var candidates []entity.Shift
+	for _, date := range req.Dates {
+		start, end, err := s.window(date, req.StartTime, req.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		for _, profileID := range req.ProfileIDs {
+			candidates = append(candidates, *s.newShift(profileID, start, end, req.Notes))
+		}
+	}
+
+	return s.createShifts(ctx, candidates, req.SkipConflicts)
+}
+
+func (s *shiftService) CopyWeek(ctx context.Context, req request.CopyWeekRequest) (*response.BulkShiftResponse, error) {
+	if req.FromYear == req.ToYear && req.FromWeek == req.ToWeek {
+		return nil, fmt.Errorf("%w: source and target week are the same", utils.ErrInvalidDateRange)
+	}
+
+	source, err := s.repo.ShiftRepo.FindByWeek(ctx, req.FromYear, req.FromWeek, req.ProfileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	days := isoWeekDayOffset(req.FromYear, req.FromWeek, req.ToYear, req.ToWeek, s.loc)
+	candidates := copyShifts(source, days, s.loc)
+	for i := range candidates {
+		candidates[i].Year, candidates[i].WeekNumber = candidates[i].ShiftStart.In(s.loc).ISOWeek()
+	}
+
+	s.log.Info("Copying roster",
+		zap.Int("from_year", req.FromYear),
+		zap.Int("from_week", req.FromWeek),
+		zap.Int("to_year", req.ToYear),
+		zap.Int("to_week", req.ToWeek),
+		zap.Int("shifts", len(candidates)))
+
+	return s.createShifts(ctx, candidates, req.SkipConflicts)
+}
+
+func (s *shiftService) GetShifts(ctx context.Context, req request.GetShiftsRequest) ([]response.ShiftResponse, response.PaginationMeta, error) {
+	shifts, total, err := s.repo.ShiftRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get shifts", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.ShiftResponse, 0, len(shifts))
+	for _, sh := range shifts {
+		result = append(result, response.ShiftToResponse(&sh))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *shiftService) GetShiftByID(ctx context.Context, id uint) (*response.ShiftResponse, error) {
+	shift, err := s.findShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.ShiftToResponse(shift)
+	return &resp, nil
+}
+
+func (s *shiftService) UpdateShift(ctx context.Context, id uint, req request.UpdateShiftRequest) (*response.ShiftResponse, error) {
+	shift, err := s.findShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in whatever was left out from the current shift
+	localStart := shift.ShiftStart.In(s.loc)
+	date := req.Date
+	if date == "" {
+		date = localStart.Format("2006-01-02")
+	}
+	startTime := req.StartTime
+	if startTime == "" {
+		startTime = localStart.Format("15:04")
+	}
+	endTime := req.EndTime
+	if endTime == "" {
+		endTime = shift.ShiftEnd.In(s.loc).Format("15:04")
+	}
+
+	start, end, err := s.window(date, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps, err := s.repo.ShiftRepo.FindOverlapping(ctx, shift.ProfileID, start, end, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlaps) > 0 {
+		return nil, fmt.Errorf("%w: %s", utils.ErrShiftOverlap, overlapDetail(shift.ProfileID, overlaps[0]))
+	}
+
+	before := *shift
+	shift.ShiftStart = start
+	shift.ShiftEnd = end
+	shift.Year, shift.WeekNumber = start.In(s.loc).ISOWeek()
+	if req.Notes != nil {
+		shift.Notes = *req.Notes
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ShiftRepo.Update(ctx, shift); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityShift, id, before, shift)
+	})
+	if err != nil {
+		s.log.Error("Failed to update shift", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Shift updated", zap.Uint("id", id))
+
+	resp := response.ShiftToResponse(shift)
+	return &resp, nil
+}
+
+func (s *shiftService) DeleteShift(ctx context.Context, id uint) error {
+	shift, err := s.findShift(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ShiftRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityShift, id, shift, nil)
+	})
+	if err != nil {
+		s.log.Error("Failed to delete shift", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Shift deleted", zap.Uint("id", id))
+	return nil
+}
+
+func (s *shiftService) GetMyUpcomingShifts(ctx context.Context) ([]response.ShiftResponse, error) {
+	userID := ctx.Value("user_id").(uint)
+
+	user, err := s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to get profile", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	if user.Profile == nil || user.Profile.ID == 0 {
+		return nil, utils.ErrProfileNotFound
+	}
+
+	shifts, err := s.repo.ShiftRepo.FindUpcomingByProfile(ctx, user.Profile.ID, time.Now(), upcomingShiftLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ShiftResponse, 0, len(shifts))
+	for _, sh := range shifts {
+		sh.Profile = *user.Profile
+		result = append(result, response.ShiftToResponse(&sh))
+	}
+
+	return result, nil
+}
+
+// createShifts saves the candidates in one transaction. Conflicts with the
+// existing roster or with earlier candidates either abort the whole batch or,
+// with skipConflicts, are reported back and left out.
+func (s *shiftService) createShifts(ctx context.Context, candidates []entity.Shift, skipConflicts bool) (*response.BulkShiftResponse, error) {
+	result := &response.BulkShiftResponse{
+		Created: []response.ShiftResponse{},
+		Skipped: []response.ShiftConflictResponse{},
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		profiles := make(map[uint]*entity.Profile)
+		var accepted []entity.Shift
+
+		for _, candidate := range candidates {
+			profile, ok := profiles[candidate.ProfileID]
+			if !ok {
+				p, err := s.findProfile(ctx, candidate.ProfileID)
+				if err != nil {
+					return err
+				}
+				profiles[candidate.ProfileID] = p
+				profile = p
+			}
+
+			reason := ""
+			for _, other := range accepted {
+				if other.ProfileID == candidate.ProfileID && shiftsOverlap(candidate.ShiftStart, candidate.ShiftEnd, other.ShiftStart, other.ShiftEnd) {
+					reason = overlapDetail(candidate.ProfileID, other)
+					break
+				}
+			}
+			if reason == "" {
+				overlaps, err := s.repo.ShiftRepo.FindOverlapping(ctx, candidate.ProfileID, candidate.ShiftStart, candidate.ShiftEnd, 0)
+				if err != nil {
+					return err
+				}
+				if len(overlaps) > 0 {
+					reason = overlapDetail(candidate.ProfileID, overlaps[0])
+				}
+			}
+
+			if reason != "" {
+				if !skipConflicts {
+					return fmt.Errorf("%w: %s", utils.ErrShiftOverlap, reason)
+				}
+				result.Skipped = append(result.Skipped, response.ShiftConflictResponse{
+					ProfileID:  candidate.ProfileID,
+					ShiftStart: candidate.ShiftStart,
+					ShiftEnd:   candidate.ShiftEnd,
+					Reason:     reason,
+				})
+				continue
+			}
+
+			candidate.Profile = *profile
+			accepted = append(accepted, candidate)
+		}
+
+		if err := s.repo.ShiftRepo.CreateBatch(ctx, accepted); err != nil {
+			return err
+		}
+
+		for i := range accepted {
+			if err := recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityShift, accepted[i].ID, nil, accepted[i]); err != nil {
+				return err
+			}
+			result.Created = append(result.Created, response.ShiftToResponse(&accepted[i]))
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to create shifts", zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Shifts created",
+		zap.Int("created", len(result.Created)),
+		zap.Int("skipped", len(result.Skipped)))
+
+	return result, nil
+}
+
+func (s *shiftService) findShift(ctx context.Context, id uint) (*entity.Shift, error) {
+	shift, err := s.repo.ShiftRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrShiftNotFound
+		}
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (s *shiftService) findProfile(ctx context.Context, id uint) (*entity.Profile, error) {
+	profile, err := s.repo.ProfileRepo.GetByProfileID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", utils.ErrProfileNotFound, id)
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+// window resolves a date and optional clock times into a UTC shift window,
+// falling back to the configured default shift hours.
+func (s *shiftService) window(date, startTime, endTime string) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, s.loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %q", utils.ErrInvalidDateRange, date)
+	}
+	if startTime == "" {
+		startTime = s.config.BusinessRules.DefaultShiftStart
+	}
+	if endTime == "" {
+		endTime = s.config.BusinessRules.DefaultShiftEnd
+	}
+
+	return utils.ShiftWindow(day, startTime, endTime, s.loc)
+}
+
+func (s *shiftService) newShift(profileID uint, start, end time.Time, notes string) *entity.Shift {
+	year, week := start.In(s.loc).ISOWeek()
+	return &entity.Shift{
+		ProfileID:  profileID,
+		ShiftStart: start,
+		ShiftEnd:   end,
+		Year:       year,
+		WeekNumber: week,
+		Notes:      notes,
+	}
+}
+
+// shiftsOverlap reports whether [aStart, aEnd) and [bStart, bEnd) intersect.
+// Back-to-back shifts do not overlap.
+func shiftsOverlap(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+// isoWeekMonday returns midnight on the Monday of the given ISO week.
+func isoWeekMonday(year, week int, loc *time.Location) time.Time {
+	// 4 January is always in ISO week 1
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, -offset+(week-1)*7)
+}
+
+// isoWeekDayOffset returns the number of days between the Mondays of two ISO weeks.
+func isoWeekDayOffset(fromYear, fromWeek, toYear, toWeek int, loc *time.Location) int {
+	from := isoWeekMonday(fromYear, fromWeek, loc)
+	to := isoWeekMonday(toYear, toWeek, loc)
+	return int(to.Sub(from).Hours()/24 + 0.5)
+}
+
+// copyShifts moves shifts forward by whole days in local time, so a 09:00
+// shift stays at 09:00 across daylight saving changes.
+func copyShifts(source []entity.Shift, days int, loc *time.Location) []entity.Shift {
+	copies := make([]entity.Shift, 0, len(source))
+	for _, sh := range source {
+		copies = append(copies, entity.Shift{
+			ProfileID:  sh.ProfileID,
+			ShiftStart: sh.ShiftStart.In(loc).AddDate(0, 0, days).UTC(),
+			ShiftEnd:   sh.ShiftEnd.In(loc).AddDate(0, 0, days).UTC(),
+			Notes:      sh.Notes,
+		})
+	}
+	return copies
+}
+
+func overlapDetail(profileID uint, existing entity.Shift) string {
+	return fmt.Sprintf("profile %d already has a shift from %s to %s",
+		profileID,
+		existing.ShiftStart.Format(time.RFC3339),
+		existing.ShiftEnd.Format(time.RFC3339))
+}
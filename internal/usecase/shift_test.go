@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftsOverlap(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 2, h, 0, 0, 0, time.UTC) }
+
+	require.True(t, shiftsOverlap(at(9), at(17), at(16), at(20)))
+	require.True(t, shiftsOverlap(at(9), at(17), at(10), at(12)))
+	require.False(t, shiftsOverlap(at(9), at(17), at(17), at(22)), "back-to-back shifts must not overlap")
+	require.False(t, shiftsOverlap(at(9), at(12), at(13), at(17)))
+}
+
+func TestIsoWeekMonday(t *testing.T) {
+	// 2026-W01 starts on Monday 29 December 2025
+	monday := isoWeekMonday(2026, 1, time.UTC)
+	require.Equal(t, time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC), monday)
+
+	year, week := monday.ISOWeek()
+	require.Equal(t, 2026, year)
+	require.Equal(t, 1, week)
+
+	require.Equal(t, 7, isoWeekDayOffset(2026, 1, 2026, 2, time.UTC))
+	require.Equal(t, 7, isoWeekDayOffset(2025, 52, 2026, 1, time.UTC))
+}
+
+func TestCopyShifts_KeepsLocalClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+
+	// Week 12 of 2026 is before the switch to summer time, week 14 after
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, loc)
+	source := []entity.Shift{{ProfileID: 1, ShiftStart: start.UTC(), ShiftEnd: start.Add(8 * time.Hour).UTC()}}
+
+	days := isoWeekDayOffset(2026, 12, 2026, 14, loc)
+	copies := copyShifts(source, days, loc)
+
+	require.Len(t, copies, 1)
+	require.Equal(t, 9, copies[0].ShiftStart.In(loc).Hour())
+	require.Equal(t, 17, copies[0].ShiftEnd.In(loc).Hour())
+	require.Equal(t, uint(1), copies[0].ProfileID)
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const profileDateFormat = "2 January 2006"
+
+// findStaff loads a user with their profile.
+func (s *profileService) findStaff(ctx context.Context, id uint) (*entity.User, error) {
+	user, err := s.repo.ProfileRepo.GetProfileByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrUserNotFound
+	}
+	if err != nil {
+		s.log.Error("Error get profile", zap.Uint("user_id", id), zap.Error(err))
+		return nil, err
+	}
+	if user.Profile == nil {
+		return nil, utils.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// checkViewable makes sure the user behind ctx may see the profile of user:
+// their own, or one of a role they manage.
+func checkViewable(ctx context.Context, user entity.User) error {
+	actorID, _ := ctx.Value("user_id").(uint)
+	actorRole, _ := ctx.Value("user_role").(entity.UserRole)
+
+	if actorID != user.ID && !canManageRole(actorRole, user.Role) {
+		return utils.ErrCannotManageUser
+	}
+	return nil
+}
+
+// staffProfileResponse shows the salary only to those who manage salaries.
+func staffProfileResponse(ctx context.Context, user *entity.User) *response.StaffProfileResponse {
+	res := &response.StaffProfileResponse{
+		UserID:            user.ID,
+		Email:             user.Email,
+		Role:              user.Role,
+		FullName:          user.Profile.FullName,
+		Phone:             user.Profile.Phone,
+		ProfileImageURL:   user.Profile.ProfileImageURL,
+		Address:           user.Profile.Address,
+		AdditionalDetails: user.Profile.AdditionalDetails,
+	}
+	if !user.Profile.DateOfBirth.IsZero() {
+		res.DateOfBirth = user.Profile.DateOfBirth.Format(profileDateFormat)
+	}
+	if HasPermission(ctx, entity.PermSalaryManage) {
+		salary := user.Profile.Salary
+		res.Salary = &salary
+	}
+	return res
+}
+
+func salaryChangeResponse(change entity.SalaryChange) response.SalaryChangeResponse {
+	return response.SalaryChangeResponse{
+		ID:             change.ID,
+		Salary:         change.Salary,
+		PreviousSalary: change.PreviousSalary,
+		EffectiveDate:  change.EffectiveDate.Format(profileDateFormat),
+		Note:           change.Note,
+		ChangedBy:      change.ChangedBy,
+		CreatedAt:      change.CreatedAt,
+	}
+}
+
+// GetStaffProfile returns any profile the user behind ctx may see.
+func (s *profileService) GetStaffProfile(ctx context.Context, userID uint) (*response.StaffProfileResponse, error) {
+	user, err := s.findStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkViewable(ctx, *user); err != nil {
+		return nil, err
+	}
+	return staffProfileResponse(ctx, user), nil
+}
+
+// UpdateStaffProfile edits the profile of a user the caller manages. Salary
+// is changed through ChangeSalary so it keeps its history.
+func (s *profileService) UpdateStaffProfile(ctx context.Context, userID uint, req request.StaffProfileRequest) (*response.StaffProfileResponse, error) {
+	user, err := s.findStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManageable(ctx, *user); err != nil {
+		return nil, err
+	}
+	if err := checkImageURL(s.storage, req.ProfileImageURL, user.Profile.ProfileImageURL); err != nil {
+		return nil, err
+	}
+
+	profile := entity.Profile{
+		UserID:            userID,
+		FullName:          strings.TrimSpace(req.FullName),
+		Phone:             req.Phone,
+		ProfileImageURL:   req.ProfileImageURL,
+		Address:           req.Address,
+		AdditionalDetails: req.AdditionalDetails,
+	}
+	if req.DateOfBirth != "" {
+		profile.DateOfBirth, err = time.Parse(profileDateFormat, req.DateOfBirth)
+		if err != nil {
+			return nil, utils.ErrInvalidDateFormat
+		}
+	}
+
+	var after *entity.User
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.ProfileRepo.UpdateProfile(ctx, &profile); err != nil {
+			return err
+		}
+		after, err = s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProfile, userID, user.Profile, after.Profile)
+	})
+	if err != nil {
+		s.log.Error("Error update staff profile", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	return staffProfileResponse(ctx, after), nil
+}
+
+// effectiveDate parses the date a salary applies from, in the business
+// timezone. Empty means today.
+func (s *profileService) effectiveDate(value string) (time.Time, error) {
+	loc := utils.LoadLocation(s.config.BusinessRules.Timezone)
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if value == "" {
+		return today, nil
+	}
+
+	date, err := time.ParseInLocation(profileDateFormat, value, loc)
+	if err != nil {
+		return time.Time{}, utils.ErrInvalidDateFormat
+	}
+	if date.After(today) {
+		return time.Time{}, utils.ErrSalaryInFuture
+	}
+	return date, nil
+}
+
+// ChangeSalary records a new salary for a user the caller manages. A change
+// dated before the latest one only adds to the history; the profile always
+// carries the salary in effect now.
+func (s *profileService) ChangeSalary(ctx context.Context, userID uint, req request.SalaryChangeRequest) (*response.SalaryChangeResponse, error) {
+	user, err := s.findStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkManageable(ctx, *user); err != nil {
+		return nil, err
+	}
+
+	effective, err := s.effectiveDate(req.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &entity.SalaryChange{
+		UserID:        userID,
+		Salary:        *req.Salary,
+		EffectiveDate: effective,
+		Note:          strings.TrimSpace(req.Note),
+	}
+	if actorID, ok := ctx.Value("user_id").(uint); ok {
+		change.ChangedBy = &actorID
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Salaries from before the history started live only on the profile
+		previous, err := s.repo.SalaryChangeRepo.FindEffective(ctx, userID, effective)
+		switch {
+		case err == nil:
+			change.PreviousSalary = previous.Salary
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			change.PreviousSalary = user.Profile.Salary
+		default:
+			return err
+		}
+
+		if err := s.repo.SalaryChangeRepo.Create(ctx, change); err != nil {
+			return err
+		}
+
+		current, err := s.repo.SalaryChangeRepo.FindEffective(ctx, userID, time.Now())
+		if err != nil {
+			return err
+		}
+		if current.Salary != user.Profile.Salary {
+			if err := s.repo.ProfileRepo.UpdateSalary(ctx, userID, current.Salary); err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntitySalaryChange, change.ID, nil, change)
+	})
+	if err != nil {
+		s.log.Error("Error change salary", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Salary changed", zap.Uint("user_id", userID), zap.Time("effective_date", effective))
+	res := salaryChangeResponse(*change)
+	return &res, nil
+}
+
+// GetSalaryHistory lists the salary changes of a user, latest effective
+// first.
+func (s *profileService) GetSalaryHistory(ctx context.Context, userID uint) ([]response.SalaryChangeResponse, error) {
+	user, err := s.findStaff(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkViewable(ctx, *user); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.SalaryChangeRepo.FindByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("Error get salary history", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]response.SalaryChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		res = append(res, salaryChangeResponse(change))
+	}
+	return res, nil
+}
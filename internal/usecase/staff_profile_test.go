@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func staffUser(id uint, role entity.UserRole, salary float64) *entity.User {
+	return &entity.User{
+		ID:      id,
+		Email:   "staff@test.com",
+		Role:    role,
+		Profile: &entity.Profile{UserID: id, FullName: "Staff", Salary: salary},
+	}
+}
+
+func TestProfileService_GetStaffProfile_SalaryNeedsPermission(t *testing.T) {
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	service := NewProfileService(nil, &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(1, entity.RoleAdmin)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(2)).Return(staffUser(2, entity.RoleStaff, 5000), nil)
+
+	res, err := service.GetStaffProfile(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, "Staff", res.FullName)
+	require.Nil(t, res.Salary)
+
+	ctx = context.WithValue(ctx, "permissions", PermissionSet{names: map[string]bool{entity.PermSalaryManage: true}})
+	res, err = service.GetStaffProfile(ctx, 2)
+	require.NoError(t, err)
+	require.NotNil(t, res.Salary)
+	require.Equal(t, 5000.0, *res.Salary)
+}
+
+func TestProfileService_GetStaffProfile_HigherRole(t *testing.T) {
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	service := NewProfileService(nil, &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(2, entity.RoleAdmin)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(1)).Return(staffUser(1, entity.RoleSuperAdmin, 0), nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(9)).Return(&entity.User{}, gorm.ErrRecordNotFound)
+
+	_, err := service.GetStaffProfile(ctx, 1)
+	require.ErrorIs(t, err, utils.ErrCannotManageUser)
+
+	_, err = service.GetStaffProfile(ctx, 9)
+	require.ErrorIs(t, err, utils.ErrUserNotFound)
+}
+
+func TestProfileService_UpdateStaffProfile_NotOwnProfile(t *testing.T) {
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	service := NewProfileService(new(infra.MockTxManager), &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(2, entity.RoleAdmin)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(2)).Return(staffUser(2, entity.RoleAdmin, 0), nil)
+
+	_, err := service.UpdateStaffProfile(ctx, 2, request.StaffProfileRequest{FullName: "Me"})
+	require.ErrorIs(t, err, utils.ErrCannotModifySelf)
+	profileRepo.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+}
+
+func TestProfileService_ChangeSalary_FutureDate(t *testing.T) {
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	service := NewProfileService(new(infra.MockTxManager), &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(1, entity.RoleAdmin)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(2)).Return(staffUser(2, entity.RoleStaff, 5000), nil)
+
+	salary := 6000.0
+	later := time.Now().AddDate(0, 0, 2).Format(profileDateFormat)
+	_, err := service.ChangeSalary(ctx, 2, request.SalaryChangeRequest{Salary: &salary, EffectiveDate: later})
+	require.ErrorIs(t, err, utils.ErrSalaryInFuture)
+
+	_, err = service.ChangeSalary(ctx, 2, request.SalaryChangeRequest{Salary: &salary, EffectiveDate: "2024-01-01"})
+	require.ErrorIs(t, err, utils.ErrInvalidDateFormat)
+}
+
+func TestProfileService_ChangeSalary_FirstChange(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	profileRepo := new(mocks.ProfileRepoMock)
+	salaryRepo := new(mocks.SalaryChangeRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo, SalaryChangeRepo: salaryRepo, AuditLogRepo: auditRepo}
+	service := NewProfileService(tx, &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(1, entity.RoleAdmin)
+	salary := 6000.0
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(2)).Return(staffUser(2, entity.RoleStaff, 5000), nil)
+	salaryRepo.On("FindEffective", mock.Anything, uint(2), mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+	salaryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.SalaryChange) bool {
+		return c.UserID == 2 && c.Salary == 6000 && c.PreviousSalary == 5000 && *c.ChangedBy == 1 && c.Note == "Promotion"
+	})).Return(nil)
+	salaryRepo.On("FindEffective", mock.Anything, uint(2), mock.Anything).Return(&entity.SalaryChange{UserID: 2, Salary: 6000}, nil).Once()
+	profileRepo.On("UpdateSalary", mock.Anything, uint(2), 6000.0).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return l.Action == entity.AuditActionCreate && l.EntityType == auditEntitySalaryChange
+	})).Return(nil)
+
+	res, err := service.ChangeSalary(ctx, 2, request.SalaryChangeRequest{
+		Salary:        &salary,
+		EffectiveDate: "1 March 2024",
+		Note:          " Promotion ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1 March 2024", res.EffectiveDate)
+	require.Equal(t, 5000.0, res.PreviousSalary)
+
+	salaryRepo.AssertExpectations(t)
+	profileRepo.AssertExpectations(t)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestProfileService_ChangeSalary_BackdatedKeepsCurrent(t *testing.T) {
+	tx := new(infra.MockTxManager)
+	profileRepo := new(mocks.ProfileRepoMock)
+	salaryRepo := new(mocks.SalaryChangeRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo, SalaryChangeRepo: salaryRepo, AuditLogRepo: auditRepo}
+	service := NewProfileService(tx, &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(1, entity.RoleAdmin)
+	salary := 4500.0
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(2)).Return(staffUser(2, entity.RoleStaff, 6000), nil)
+	salaryRepo.On("FindEffective", mock.Anything, uint(2), mock.Anything).Return(&entity.SalaryChange{Salary: 4000}, nil).Once()
+	salaryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.SalaryChange) bool {
+		return c.Salary == 4500 && c.PreviousSalary == 4000
+	})).Return(nil)
+	// A later raise is still in effect
+	salaryRepo.On("FindEffective", mock.Anything, uint(2), mock.Anything).Return(&entity.SalaryChange{Salary: 6000}, nil).Once()
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.ChangeSalary(ctx, 2, request.SalaryChangeRequest{Salary: &salary, EffectiveDate: "1 January 2023"})
+	require.NoError(t, err)
+	profileRepo.AssertNotCalled(t, "UpdateSalary", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProfileService_ChangeSalary_HigherRole(t *testing.T) {
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	service := NewProfileService(new(infra.MockTxManager), &repo, zap.NewNop(), nil, utils.Configuration{})
+
+	ctx := actorContext(2, entity.RoleAdmin)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(3)).Return(staffUser(3, entity.RoleAdmin, 9000), nil)
+
+	salary := 1.0
+	_, err := service.ChangeSalary(ctx, 3, request.SalaryChangeRequest{Salary: &salary})
+	require.ErrorIs(t, err, utils.ErrCannotManageUser)
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const stockTakeReferenceType = "stock_take"
+
+type StockTakeService interface {
+	OpenStockTake(ctx context.Context, req request.CreateStockTakeRequest) (*response.StockTakeResponse, error)
+	GetStockTakes(ctx context.Context, req request.GetStockTakesRequest) ([]response.StockTakeResponse, response.PaginationMeta, error)
+	GetStockTakeByID(ctx context.Context, id uint) (*response.StockTakeResponse, error)
+	RecordCounts(ctx context.Context, id uint, req request.RecordStockTakeCountsRequest) (*response.StockTakeResponse, error)
+	RemoveCount(ctx context.Context, id, productID uint) error
+	CommitStockTake(ctx context.Context, id uint) (*response.StockTakeResponse, error)
+	CancelStockTake(ctx context.Context, id uint) error
+}
+
+type stockTakeService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewStockTakeService(tx TxManager, repo *repository.Repository, log *zap.Logger) StockTakeService {
+	return &stockTakeService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "stock_take")),
+	}
+}
+
+func (s *stockTakeService) OpenStockTake(ctx context.Context, req request.CreateStockTakeRequest) (*response.StockTakeResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	suffix, err := utils.GenerateOTP(4)
+	if err != nil {
+		return nil, err
+	}
+
+	stockTake := &entity.StockTake{
+		Reference: fmt.Sprintf("ST-%s-%s", time.Now().Format("20060102150405"), suffix),
+		Status:    entity.StockTakeStatusOpen,
+		Notes:     req.Notes,
+		CreatedBy: userID,
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.StockTakeRepo.Create(ctx, stockTake); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityStockTake, stockTake.ID, nil, stockTake)
+	})
+	if err != nil {
+		s.log.Error("Failed to open stock take", zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Stock take opened",
+		zap.Uint("id", stockTake.ID),
+		zap.String("reference", stockTake.Reference))
+
+	resp := response.StockTakeToResponse(stockTake)
+	return &resp, nil
+}
+
+func (s *stockTakeService) GetStockTakes(ctx context.Context, req request.GetStockTakesRequest) ([]response.StockTakeResponse, response.PaginationMeta, error) {
+	stockTakes, total, err := s.repo.StockTakeRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get stock takes", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.StockTakeResponse, 0, len(stockTakes))
+	for _, st := range stockTakes {
+		result = append(result, response.StockTakeToResponse(&st))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *stockTakeService) GetStockTakeByID(ctx context.Context, id uint) (*response.StockTakeResponse, error) {
+	stockTake, err := s.findStockTake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.StockTakeToResponse(stockTake)
+	return &resp, nil
+}
+
+func (s *stockTakeService) RecordCounts(ctx context.Context, id uint, req request.RecordStockTakeCountsRequest) (*response.StockTakeResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stockTake, err := s.lockStockTake(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stockTake.Status != entity.StockTakeStatusOpen {
+			return utils.ErrStockTakeNotOpen
+		}
+
+		for _, count := range req.Items {
+			product, err := s.repo.Product.FindByID(count.ProductID)
+			if err != nil {
+				return err
+			}
+			if product == nil {
+				return fmt.Errorf("%w: %d", utils.ErrProductNotFound, count.ProductID)
+			}
+
+			err = s.repo.StockTakeRepo.UpsertItem(ctx, &entity.StockTakeItem{
+				StockTakeID:     stockTake.ID,
+				ProductID:       count.ProductID,
+				CountedQuantity: count.CountedQuantity,
+				Notes:           count.Notes,
+				CountedBy:       userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to record stock take counts", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return s.GetStockTakeByID(ctx, id)
+}
+
+func (s *stockTakeService) RemoveCount(ctx context.Context, id, productID uint) error {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stockTake, err := s.lockStockTake(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stockTake.Status != entity.StockTakeStatusOpen {
+			return utils.ErrStockTakeNotOpen
+		}
+
+		for _, item := range stockTake.Items {
+			if item.ProductID != productID {
+				continue
+			}
+			if err := s.repo.StockTakeRepo.DeleteItem(ctx, id, productID); err != nil {
+				return err
+			}
+			return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityStockTakeItem, item.ID, item, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to remove stock take count",
+			zap.Uint("id", id),
+			zap.Uint("product_id", productID),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// CommitStockTake freezes the variance of every counted product and writes one
+// adjustment log per product whose count differs from its stock, all in the
+// same transaction so a failure leaves stock untouched.
+func (s *stockTakeService) CommitStockTake(ctx context.Context, id uint) (*response.StockTakeResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	s.log.Info("Committing stock take", zap.Uint("id", id))
+
+	var adjusted int
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stockTake, err := s.lockStockTake(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stockTake.Status != entity.StockTakeStatusOpen {
+			return utils.ErrStockTakeNotOpen
+		}
+		if len(stockTake.Items) == 0 {
+			return utils.ErrStockTakeEmpty
+		}
+		before := *stockTake
+
+		for i := range stockTake.Items {
+			item := &stockTake.Items[i]
+
+			product, err := s.repo.Product.FindByIDForUpdate(ctx, item.ProductID)
+			if err != nil {
+				return err
+			}
+
+			item.ExpectedQuantity = product.Stock
+			item.Variance = item.CountedQuantity - product.Stock
+
+			if item.Variance != 0 {
+				product, err = s.repo.Product.AdjustStock(ctx, item.ProductID, item.Variance)
+				if err != nil {
+					return err
+				}
+
+				_, err = s.repo.InventoryLogRepo.CreateInventoryLog(ctx, &entity.InventoryLog{
+					ProductID:         item.ProductID,
+					Type:              entity.InventoryLogTypeAdjustment,
+					QuantityChange:    item.Variance,
+					CurrentStockAfter: product.Stock,
+					ReferenceID:       &stockTake.ID,
+					ReferenceType:     stockTakeReferenceType,
+					Notes:             stockTake.Reference,
+					CreatedBy:         userID,
+					CreatedAt:         time.Now(),
+				})
+				if err != nil {
+					return err
+				}
+				adjusted++
+			}
+
+			if err := s.repo.StockTakeRepo.UpdateItem(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		stockTake.Status = entity.StockTakeStatusCommitted
+		stockTake.CommittedBy = &userID
+		stockTake.CommittedAt = &now
+
+		if err := s.repo.StockTakeRepo.Update(ctx, stockTake); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityStockTake, id, before, stockTake)
+	})
+	if err != nil {
+		s.log.Error("Failed to commit stock take", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Stock take committed",
+		zap.Uint("id", id),
+		zap.Int("adjusted_products", adjusted))
+
+	return s.GetStockTakeByID(ctx, id)
+}
+
+func (s *stockTakeService) CancelStockTake(ctx context.Context, id uint) error {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		stockTake, err := s.lockStockTake(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stockTake.Status != entity.StockTakeStatusOpen {
+			return utils.ErrStockTakeNotOpen
+		}
+
+		before := *stockTake
+		stockTake.Status = entity.StockTakeStatusCancelled
+		if err := s.repo.StockTakeRepo.Update(ctx, stockTake); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityStockTake, id, before, stockTake)
+	})
+	if err != nil {
+		s.log.Error("Failed to cancel stock take", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *stockTakeService) findStockTake(ctx context.Context, id uint) (*entity.StockTake, error) {
+	return stockTakeOrNotFound(s.repo.StockTakeRepo.FindByID(ctx, id))
+}
+
+// lockStockTake is findStockTake holding the row lock until the transaction
+// ends, so a session is not counted into or changed while it is committed.
+func (s *stockTakeService) lockStockTake(ctx context.Context, id uint) (*entity.StockTake, error) {
+	return stockTakeOrNotFound(s.repo.StockTakeRepo.FindByIDForUpdate(ctx, id))
+}
+
+func stockTakeOrNotFound(stockTake *entity.StockTake, err error) (*entity.StockTake, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrStockTakeNotFound
+		}
+		return nil, err
+	}
+	return stockTake, nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, req request.CreateSupplierRequest) (*response.SupplierResponse, error)
+	GetSuppliers(ctx context.Context, req request.GetSuppliersRequest) ([]response.SupplierResponse, response.PaginationMeta, error)
+	GetSupplierByID(ctx context.Context, id uint) (*response.SupplierResponse, error)
+	UpdateSupplier(ctx context.Context, id uint, req request.UpdateSupplierRequest) (*response.SupplierResponse, error)
+	DeleteSupplier(ctx context.Context, id uint) error
+}
+
+type supplierService struct {
+	tx   TxManager
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewSupplierService(tx TxManager, repo *repository.Repository, log *zap.Logger) SupplierService {
+	return &supplierService{
+		tx:   tx,
+		repo: repo,
+		log:  log.With(zap.String("service", "supplier")),
+	}
+}
+
+func (s *supplierService) CreateSupplier(ctx context.Context, req request.CreateSupplierRequest) (*response.SupplierResponse, error) {
+	supplier := &entity.Supplier{
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Address:     req.Address,
+		Notes:       req.Notes,
+		IsActive:    true,
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := s.repo.SupplierRepo.Create(ctx, supplier)
+		if err != nil {
+			return err
+		}
+		supplier = created
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntitySupplier, supplier.ID, nil, supplier)
+	})
+	if err != nil {
+		s.log.Error("Failed to create supplier", zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Supplier created", zap.Uint("id", supplier.ID), zap.String("name", supplier.Name))
+
+	resp := response.SupplierToResponse(supplier)
+	return &resp, nil
+}
+
+func (s *supplierService) GetSuppliers(ctx context.Context, req request.GetSuppliersRequest) ([]response.SupplierResponse, response.PaginationMeta, error) {
+	suppliers, total, err := s.repo.SupplierRepo.FindAll(ctx, req)
+	if err != nil {
+		s.log.Error("Failed to get suppliers", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.SupplierResponse, 0, len(suppliers))
+	for _, sp := range suppliers {
+		result = append(result, response.SupplierToResponse(&sp))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+func (s *supplierService) GetSupplierByID(ctx context.Context, id uint) (*response.SupplierResponse, error) {
+	supplier, err := s.findSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := response.SupplierToResponse(supplier)
+	return &resp, nil
+}
+
+func (s *supplierService) UpdateSupplier(ctx context.Context, id uint, req request.UpdateSupplierRequest) (*response.SupplierResponse, error) {
+	supplier, err := s.findSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *supplier
+
+	if req.Name != "" {
+		supplier.Name = req.Name
+	}
+	if req.ContactName != nil {
+		supplier.ContactName = *req.ContactName
+	}
+	if req.Phone != nil {
+		supplier.Phone = *req.Phone
+	}
+	if req.Email != nil {
+		supplier.Email = *req.Email
+	}
+	if req.Address != nil {
+		supplier.Address = *req.Address
+	}
+	if req.Notes != nil {
+		supplier.Notes = *req.Notes
+	}
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SupplierRepo.Update(ctx, supplier); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntitySupplier, id, before, supplier)
+	})
+	if err != nil {
+		s.log.Error("Failed to update supplier", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	resp := response.SupplierToResponse(supplier)
+	return &resp, nil
+}
+
+func (s *supplierService) DeleteSupplier(ctx context.Context, id uint) error {
+	supplier, err := s.findSupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.PurchaseOrderRepo.CountBySupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return utils.ErrSupplierHasPurchaseOrders
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.SupplierRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntitySupplier, id, supplier, nil)
+	})
+	if err != nil {
+		s.log.Error("Failed to delete supplier", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Supplier deleted", zap.Uint("id", id))
+	return nil
+}
+
+func (s *supplierService) findSupplier(ctx context.Context, id uint) (*entity.Supplier, error) {
+	supplier, err := s.repo.SupplierRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// denylistSyncInterval is how stale the in-memory denylist may get before
+// revocations made by other instances are pulled from the database.
+const denylistSyncInterval = 30 * time.Second
+
+// TokenDenylist tracks revoked signed access tokens. Revocations are kept in
+// memory so checking a token costs no query; the database only shares them
+// between instances and is read at most once per sync interval.
+type TokenDenylist interface {
+	Revoke(ctx context.Context, key string, until time.Time) error
+	IsRevoked(ctx context.Context, key string, issuedAt time.Time) bool
+}
+
+type tokenDenylist struct {
+	repo repository.TokenRevocationRepository
+	log  *zap.Logger
+
+	mu       sync.RWMutex
+	entries  map[string]entity.TokenRevocation
+	lastSync time.Time
+	syncedTo time.Time
+}
+
+func NewTokenDenylist(repo repository.TokenRevocationRepository, log *zap.Logger) TokenDenylist {
+	return &tokenDenylist{
+		repo:    repo,
+		log:     log.With(zap.String("service", "token_denylist")),
+		entries: map[string]entity.TokenRevocation{},
+	}
+}
+
+func sessionDenyKey(sessionID uint) string { return fmt.Sprintf("session:%d", sessionID) }
+func userDenyKey(userID uint) string       { return fmt.Sprintf("user:%d", userID) }
+
+// Revoke denies every token for key issued up to now. until is when the last
+// of those tokens expires.
+func (d *tokenDenylist) Revoke(ctx context.Context, key string, until time.Time) error {
+	revocation := entity.TokenRevocation{
+		Key:           key,
+		RevokedBefore: time.Now(),
+		ExpiresAt:     until,
+	}
+	if err := d.repo.Create(ctx, &revocation); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.remember(revocation)
+	d.mu.Unlock()
+	return nil
+}
+
+func (d *tokenDenylist) IsRevoked(ctx context.Context, key string, issuedAt time.Time) bool {
+	d.sync(ctx)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return denies(d.entries[key], issuedAt, time.Now())
+}
+
+// sync pulls revocations recorded since the last sync once the interval has
+// passed. A failed sync keeps serving the entries already known.
+func (d *tokenDenylist) sync(ctx context.Context) {
+	d.mu.RLock()
+	fresh := time.Since(d.lastSync) < denylistSyncInterval
+	d.mu.RUnlock()
+	if fresh {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if time.Since(d.lastSync) < denylistSyncInterval {
+		return
+	}
+	d.lastSync = time.Now()
+
+	// Overlap the window a little so rows committed late are not missed
+	revocations, err := d.repo.FindActiveSince(ctx, d.syncedTo.Add(-denylistSyncInterval))
+	if err != nil {
+		d.log.Warn("Failed to sync token denylist", zap.Error(err))
+		return
+	}
+
+	if err := d.repo.DeleteExpired(ctx); err != nil {
+		d.log.Warn("Failed to purge expired token revocations", zap.Error(err))
+	}
+
+	now := time.Now()
+	for key, r := range d.entries {
+		if !now.Before(r.ExpiresAt) {
+			delete(d.entries, key)
+		}
+	}
+	for _, r := range revocations {
+		d.remember(r)
+		if r.CreatedAt.After(d.syncedTo) {
+			d.syncedTo = r.CreatedAt
+		}
+	}
+}
+
+// remember merges r into the entries, keeping the latest cutoff and expiry
+// for its key. Callers hold the write lock.
+func (d *tokenDenylist) remember(r entity.TokenRevocation) {
+	current, ok := d.entries[r.Key]
+	if !ok {
+		d.entries[r.Key] = r
+		return
+	}
+	if r.RevokedBefore.After(current.RevokedBefore) {
+		current.RevokedBefore = r.RevokedBefore
+	}
+	if r.ExpiresAt.After(current.ExpiresAt) {
+		current.ExpiresAt = r.ExpiresAt
+	}
+	d.entries[r.Key] = current
+}
+
+// denies reports whether a token issued at issuedAt falls under revocation r.
+// Token times have second precision, so a token from the same second as the
+// revocation is denied too.
+func denies(r entity.TokenRevocation, issuedAt, now time.Time) bool {
+	if r.Key == "" || !now.Before(r.ExpiresAt) {
+		return false
+	}
+	return !issuedAt.After(r.RevokedBefore.Truncate(time.Second))
+}
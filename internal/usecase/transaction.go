@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+type TransactionService interface {
+	GetTransactions(ctx context.Context, req request.GetTransactionsRequest) ([]response.TransactionResponse, response.PaginationMeta, error)
+	ExportTransactions(ctx context.Context, req request.GetTransactionsRequest, w utils.ExportWriter) error
+}
+
+type transactionService struct {
+	repo *repository.Repository
+	log  *zap.Logger
+}
+
+func NewTransactionService(repo *repository.Repository, log *zap.Logger) TransactionService {
+	return &transactionService{
+		repo: repo,
+		log:  log.With(zap.String("service", "transaction")),
+	}
+}
+
+func (s *transactionService) GetTransactions(ctx context.Context, req request.GetTransactionsRequest) ([]response.TransactionResponse, response.PaginationMeta, error) {
+	params, err := transactionParams(req)
+	if err != nil {
+		return nil, response.PaginationMeta{}, err
+	}
+	params.Offset = req.GetOffset()
+	params.Limit = req.GetPerPage()
+
+	transactions, total, err := s.repo.TransactionRepo.FindAll(ctx, params)
+	if err != nil {
+		s.log.Error("Failed to get transactions", zap.Error(err))
+		return nil, response.PaginationMeta{}, err
+	}
+
+	result := make([]response.TransactionResponse, 0, len(transactions))
+	for _, t := range transactions {
+		result = append(result, response.TransactionToResponse(&t))
+	}
+
+	return result, paginationMeta(req.PaginationRequest, total), nil
+}
+
+// ExportTransactions writes every transaction matching the filters.
+func (s *transactionService) ExportTransactions(ctx context.Context, req request.GetTransactionsRequest, w utils.ExportWriter) error {
+	params, err := transactionParams(req)
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteRow("transaction_number", "created_at", "order_number", "type", "payment_method", "amount", "status", "created_by", "notes"); err != nil {
+		return err
+	}
+
+	err = s.repo.TransactionRepo.Stream(ctx, params, func(transactions []entity.Transaction) error {
+		for _, t := range transactions {
+			err := w.WriteRow(t.TransactionNumber, t.CreatedAt, t.Order.OrderNumber, string(t.TransactionType),
+				t.PaymentMethod.Name, t.Amount, string(t.Status), t.CreatedBy, t.Notes)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Failed to export transactions", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func transactionParams(req request.GetTransactionsRequest) (repository.TransactionParams, error) {
+	start, end, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return repository.TransactionParams{}, err
+	}
+
+	return repository.TransactionParams{
+		OrderID:         req.OrderID,
+		PaymentMethodID: req.PaymentMethodID,
+		Type:            req.Type,
+		Status:          req.Status,
+		StartDate:       start,
+		EndDate:         end,
+	}, nil
+}
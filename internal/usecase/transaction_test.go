@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionParams_EndDateIsExclusive(t *testing.T) {
+	params, err := transactionParams(request.GetTransactionsRequest{
+		Type:      "refund",
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-31",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "refund", params.Type)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), *params.StartDate)
+	require.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local), *params.EndDate)
+}
+
+func TestTransactionParams_RejectsReversedRange(t *testing.T) {
+	_, err := transactionParams(request.GetTransactionsRequest{
+		StartDate: "2026-03-31",
+		EndDate:   "2026-03-01",
+	})
+
+	require.ErrorIs(t, err, utils.ErrInvalidDateRange)
+}
@@ -0,0 +1,565 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/dto/request"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	content "project-POS-APP-golang-integer/pkg/utils/email"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTwoFactorRoles    = "superadmin,admin"
+	defaultTwoFactorIssuer   = "POS App"
+	defaultLoginChallengeTTL = 10 * time.Minute
+	loginCodeResendInterval  = time.Minute
+	recoveryCodeCount        = 10
+	totpAllowedSkew          = 1
+	twoFactorRolesNone       = "none"
+)
+
+// twoFactorRoles parses the roles that must always pass a second factor.
+func twoFactorRoles(cfg utils.TwoFactorConfig) map[entity.UserRole]bool {
+	list := strings.TrimSpace(cfg.RequiredRoles)
+	if list == "" {
+		list = defaultTwoFactorRoles
+	}
+
+	roles := map[entity.UserRole]bool{}
+	if strings.EqualFold(list, twoFactorRolesNone) {
+		return roles
+	}
+	for _, role := range strings.Split(list, ",") {
+		if role = strings.ToLower(strings.TrimSpace(role)); role != "" {
+			roles[entity.UserRole(role)] = true
+		}
+	}
+	return roles
+}
+
+// twoFactorMethods lists how a login challenge can be answered. The emailed
+// code is always offered so losing the phone does not lock anyone out.
+func twoFactorMethods(enrolled bool) []entity.TwoFactorMethod {
+	if !enrolled {
+		return []entity.TwoFactorMethod{entity.TwoFactorMethodEmail}
+	}
+	return []entity.TwoFactorMethod{
+		entity.TwoFactorMethodTOTP,
+		entity.TwoFactorMethodRecovery,
+		entity.TwoFactorMethodEmail,
+	}
+}
+
+// enrolledCodeMethod tells an authenticator code from a recovery code when
+// the caller does not say which one was entered.
+func enrolledCodeMethod(code string) entity.TwoFactorMethod {
+	code = strings.TrimSpace(code)
+	if len(code) != 6 {
+		return entity.TwoFactorMethodRecovery
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return entity.TwoFactorMethodRecovery
+		}
+	}
+	return entity.TwoFactorMethodTOTP
+}
+
+func (s *authService) twoFactorRequired(role entity.UserRole) bool {
+	return twoFactorRoles(s.config.TwoFactor)[role]
+}
+
+func (s *authService) twoFactorIssuer() string {
+	if s.config.TwoFactor.Issuer != "" {
+		return s.config.TwoFactor.Issuer
+	}
+	if s.config.AppName != "" {
+		return s.config.AppName
+	}
+	return defaultTwoFactorIssuer
+}
+
+func (s *authService) loginChallengeTTL() time.Duration {
+	if s.config.TwoFactor.ChallengeMinutes > 0 {
+		return time.Duration(s.config.TwoFactor.ChallengeMinutes) * time.Minute
+	}
+	return defaultLoginChallengeTTL
+}
+
+// findTwoFactor returns the user's authenticator enrollment, pending or not,
+// or nil when there is none.
+func (s *authService) findTwoFactor(ctx context.Context, userID uint) (*entity.UserTwoFactor, error) {
+	tf, err := s.repo.TwoFactorRepo.FindByUser(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		s.log.Error("Error find two factor", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return tf, nil
+}
+
+func (s *authService) twoFactorEnabled(ctx context.Context, userID uint) (bool, error) {
+	tf, err := s.findTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return tf != nil && tf.EnabledAt != nil, nil
+}
+
+// startLoginChallenge holds a login whose password was right until a second
+// factor is given.
+func (s *authService) startLoginChallenge(ctx context.Context, user *entity.User, data request.LoginRequest, enrolled bool) (*response.AuthResponse, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := entity.LoginChallenge{
+		UserID:     user.ID,
+		TokenHash:  utils.HashToken(token),
+		DeviceName: data.DeviceName,
+		IPAddress:  data.IPAddress,
+		UserAgent:  truncateUserAgent(data.UserAgent),
+		ExpiresAt:  time.Now().Add(s.loginChallengeTTL()),
+	}
+	if err := s.repo.LoginChallengeRepo.Create(ctx, &challenge); err != nil {
+		return nil, err
+	}
+
+	res := &response.TwoFactorChallengeResponse{
+		ChallengeToken: token,
+		Methods:        twoFactorMethods(enrolled),
+		ExpiresAt:      challenge.ExpiresAt,
+	}
+
+	// Without an authenticator app the emailed code is the only way in, so
+	// send it right away. A code sent moments ago still works.
+	if !enrolled {
+		err := s.sendLoginCode(ctx, user, data.IPAddress)
+		if err != nil && !errors.Is(err, utils.ErrLoginCodeTooSoon) {
+			return nil, err
+		}
+		res.EmailCodeSent = true
+	}
+
+	s.log.Info("Login waiting for second factor", zap.Uint("user_id", user.ID), zap.String("ip", data.IPAddress))
+	return &response.AuthResponse{TwoFactor: res}, nil
+}
+
+// sendLoginCode emails a one-time login code, replacing any sent before.
+func (s *authService) sendLoginCode(ctx context.Context, user *entity.User, ipAddress string) error {
+	last, err := s.repo.OTPRepo.GetValidByUser(ctx, user.ID, entity.OTPPurposeTwoFactor)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil && time.Since(last.CreatedAt) < loginCodeResendInterval {
+		return utils.ErrLoginCodeTooSoon
+	}
+
+	code, err := utils.GenerateOTP(6)
+	if err != nil {
+		return err
+	}
+	otp := entity.OTP{
+		UserID:    user.ID,
+		OTPCode:   code,
+		Purpose:   entity.OTPPurposeTwoFactor,
+		ExpiresAt: time.Now().Add(otpTTL),
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.OTPRepo.InvalidateByPurpose(ctx, user.ID, entity.OTPPurposeTwoFactor); err != nil {
+			return err
+		}
+		return s.repo.OTPRepo.Create(ctx, &otp)
+	})
+	if err != nil {
+		s.log.Error("Error create login code", zap.Uint("user_id", user.ID), zap.Error(err))
+		return err
+	}
+
+	loc := utils.LoadLocation(s.config.BusinessRules.Timezone)
+	go func(to string) {
+		err := s.email.Send(context.Background(), request.EmailRequest{
+			To:      to,
+			Subject: "Login Code",
+			Body:    content.LoginCode(code, otp.ExpiresAt.In(loc), ipAddress),
+		})
+		if err != nil {
+			s.log.Error("Error send email", zap.Error(err))
+		}
+	}(user.Email)
+
+	return nil
+}
+
+// findLoginChallenge returns the open challenge for token with its user.
+func (s *authService) findLoginChallenge(ctx context.Context, token string) (*entity.LoginChallenge, *entity.User, error) {
+	challenge, err := s.repo.LoginChallengeRepo.FindActiveByTokenHash(ctx, utils.HashToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, utils.ErrInvalidLoginChallenge
+	}
+	if err != nil {
+		s.log.Error("Error find login challenge", zap.Error(err))
+		return nil, nil, err
+	}
+
+	user, err := s.repo.UserRepo.GetUserByID(ctx, challenge.UserID)
+	if errors.Is(err, utils.ErrUserNotFound) {
+		return nil, nil, utils.ErrInvalidLoginChallenge
+	}
+	if err != nil {
+		s.log.Error("Error get user", zap.Uint("user_id", challenge.UserID), zap.Error(err))
+		return nil, nil, err
+	}
+	return challenge, &user, nil
+}
+
+// SendLoginCode emails a code that answers the login challenge, for users
+// without their authenticator app at hand.
+func (s *authService) SendLoginCode(ctx context.Context, req request.LoginCodeRequest) error {
+	challenge, user, err := s.findLoginChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return err
+	}
+	if err := s.throttleEmailRequest(ctx, user.Email, req.IPAddress); err != nil {
+		return err
+	}
+	return s.sendLoginCode(ctx, user, challenge.IPAddress)
+}
+
+// VerifyLogin finishes a login held by a challenge. Wrong codes count against
+// both the challenge and the account, the same as wrong passwords.
+func (s *authService) VerifyLogin(ctx context.Context, req request.VerifyLoginRequest) (*response.AuthResponse, error) {
+	now := time.Now()
+	challenge, user, err := s.findLoginChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey := loginAccountKey(user.ID)
+	accountPolicy, _ := loginPolicies(s.config.LoginProtection)
+	lockedUntil, err := s.reserveLoginAttempt(ctx, accountKey, accountPolicy, now, utils.ErrAccountLocked)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spending the code and closing the challenge go together, so a code
+	// is not lost when the challenge was already answered elsewhere
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.useSecondFactor(ctx, user.ID, entity.TwoFactorMethod(req.Method), req.Code); err != nil {
+			return err
+		}
+		if err := s.repo.LoginChallengeRepo.Complete(ctx, challenge.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrInvalidLoginChallenge
+			}
+			return err
+		}
+		return nil
+	})
+	if errors.Is(err, utils.ErrInvalidTwoFactorCode) {
+		s.log.Warn("Incorrect second factor", zap.Uint("user_id", user.ID), zap.String("method", req.Method))
+		attempts, err := s.repo.LoginChallengeRepo.RecordFailedAttempt(ctx, challenge.ID, s.otpMaxAttempts())
+		if err != nil {
+			return nil, err
+		}
+		if lockedUntil != nil {
+			s.notifyLockout(ctx, user, *lockedUntil, challenge.IPAddress)
+			return nil, utils.ErrAccountLocked
+		}
+		if attempts >= s.otpMaxAttempts() {
+			return nil, utils.ErrLoginChallengeExceeded
+		}
+		return nil, utils.ErrInvalidTwoFactorCode
+	}
+	if err != nil {
+		if !errors.Is(err, utils.ErrInvalidLoginChallenge) {
+			s.log.Error("Error verify login", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		s.releaseLoginAttempt(ctx, accountKey, accountPolicy)
+		return nil, err
+	}
+
+	s.clearLoginFailures(ctx, user.ID)
+	return s.openSession(ctx, user, challenge.DeviceName, challenge.IPAddress, challenge.UserAgent)
+}
+
+// useSecondFactor checks code and spends it so it cannot be used again. A
+// wrong, used or unknown code is ErrInvalidTwoFactorCode.
+func (s *authService) useSecondFactor(ctx context.Context, userID uint, method entity.TwoFactorMethod, code string) error {
+	code = strings.TrimSpace(code)
+	var err error
+
+	switch method {
+	case entity.TwoFactorMethodTOTP:
+		var tf *entity.UserTwoFactor
+		tf, err = s.findTwoFactor(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if tf == nil || tf.EnabledAt == nil {
+			return utils.ErrInvalidTwoFactorCode
+		}
+		step, ok := utils.VerifyTOTP(tf.Secret, code, time.Now(), totpAllowedSkew)
+		if !ok {
+			return utils.ErrInvalidTwoFactorCode
+		}
+		// A step already used means the code was replayed
+		err = s.repo.TwoFactorRepo.UseStep(ctx, userID, step)
+
+	case entity.TwoFactorMethodRecovery:
+		hash := utils.HashToken(utils.NormalizeRecoveryCode(code))
+		err = s.repo.TwoFactorRepo.UseRecoveryCode(ctx, userID, hash)
+
+	case entity.TwoFactorMethodEmail:
+		var otp *entity.OTP
+		otp, err = s.repo.OTPRepo.GetValidByUser(ctx, userID, entity.OTPPurposeTwoFactor)
+		if err == nil {
+			if subtle.ConstantTimeCompare([]byte(otp.OTPCode), []byte(code)) != 1 {
+				return utils.ErrInvalidTwoFactorCode
+			}
+			err = s.repo.OTPRepo.MarkUsed(ctx, otp.ID)
+		}
+
+	default:
+		return utils.ErrInvalidTwoFactorCode
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+// currentUser loads the user behind ctx and checks the password they entered
+// again to confirm a security change.
+func (s *authService) currentUser(ctx context.Context, password *string) (*entity.User, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	user, err := s.repo.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if password != nil && !utils.CheckPassword(*password, user.PasswordHash) {
+		return nil, utils.ErrInvalidCredentials
+	}
+	return &user, nil
+}
+
+func (s *authService) GetTwoFactorStatus(ctx context.Context) (*response.TwoFactorStatusResponse, error) {
+	user, err := s.currentUser(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := s.findTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.TwoFactorStatusResponse{
+		Required: s.twoFactorRequired(user.Role),
+	}
+	if tf == nil || tf.EnabledAt == nil {
+		return res, nil
+	}
+
+	left, err := s.repo.TwoFactorRepo.CountRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	res.Enabled = true
+	res.EnabledAt = tf.EnabledAt
+	res.RecoveryCodesLeft = left
+	return res, nil
+}
+
+// SetupTwoFactor starts enrolling an authenticator app. The new secret only
+// takes effect once EnableTwoFactor confirms a code from it.
+func (s *authService) SetupTwoFactor(ctx context.Context, req request.SetupTwoFactorRequest) (*response.TwoFactorSetupResponse, error) {
+	user, err := s.currentUser(ctx, &req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, utils.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.TwoFactorRepo.Save(ctx, &entity.UserTwoFactor{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &response.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.twoFactorIssuer(), user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor confirms the enrollment with a first code and hands out
+// the recovery codes.
+func (s *authService) EnableTwoFactor(ctx context.Context, req request.EnableTwoFactorRequest) (*response.RecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := s.findTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, utils.ErrTwoFactorNotSetUp
+	}
+	if tf.EnabledAt != nil {
+		return nil, utils.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := utils.VerifyTOTP(tf.Secret, req.Code, time.Now(), totpAllowedSkew)
+	if !ok {
+		return nil, utils.ErrInvalidTwoFactorCode
+	}
+
+	codes, records, err := newRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.TwoFactorRepo.Enable(ctx, user.ID, step); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrTwoFactorAlreadyEnabled
+			}
+			return err
+		}
+		if err := s.repo.TwoFactorRepo.ReplaceRecoveryCodes(ctx, user.ID, records); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityTwoFactor, tf.ID, nil, tf)
+	})
+	if err != nil {
+		if !errors.Is(err, utils.ErrTwoFactorAlreadyEnabled) {
+			s.log.Error("Error enable two factor", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.log.Info("Two factor enabled", zap.Uint("user_id", user.ID))
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor removes the authenticator app and its recovery codes.
+// Users of a role that requires two factor keep receiving emailed codes.
+func (s *authService) DisableTwoFactor(ctx context.Context, req request.TwoFactorConfirmRequest) error {
+	user, tf, err := s.confirmTwoFactorChange(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.useSecondFactor(ctx, user.ID, enrolledCodeMethod(req.Code), req.Code); err != nil {
+			return err
+		}
+		if err := s.repo.TwoFactorRepo.Delete(ctx, user.ID); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityTwoFactor, tf.ID, tf, nil)
+	})
+	if err != nil {
+		if !errors.Is(err, utils.ErrInvalidTwoFactorCode) {
+			s.log.Error("Error disable two factor", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	s.log.Info("Two factor disabled", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code with a new set.
+func (s *authService) RegenerateRecoveryCodes(ctx context.Context, req request.TwoFactorConfirmRequest) (*response.RecoveryCodesResponse, error) {
+	user, _, err := s.confirmTwoFactorChange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, records, err := newRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.useSecondFactor(ctx, user.ID, enrolledCodeMethod(req.Code), req.Code); err != nil {
+			return err
+		}
+		return s.repo.TwoFactorRepo.ReplaceRecoveryCodes(ctx, user.ID, records)
+	})
+	if err != nil {
+		if !errors.Is(err, utils.ErrInvalidTwoFactorCode) {
+			s.log.Error("Error regenerate recovery codes", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.log.Info("Recovery codes regenerated", zap.Uint("user_id", user.ID))
+	return &response.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// confirmTwoFactorChange checks the password for a change to an enabled
+// second factor. The code is checked by the caller, inside its transaction.
+func (s *authService) confirmTwoFactorChange(ctx context.Context, req request.TwoFactorConfirmRequest) (*entity.User, *entity.UserTwoFactor, error) {
+	user, err := s.currentUser(ctx, &req.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tf, err := s.findTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tf == nil || tf.EnabledAt == nil {
+		return nil, nil, utils.ErrTwoFactorNotEnabled
+	}
+	return user, tf, nil
+}
+
+// newRecoveryCodes returns fresh recovery codes to show the user and the
+// hashed rows to store for them.
+func newRecoveryCodes(userID uint) ([]string, []entity.RecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]entity.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		records = append(records, entity.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashToken(code),
+		})
+	}
+	return codes, records, nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"encoding/base32"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The SHA1 test vectors of RFC 6238, truncated to six digits.
+func TestTOTPCode_RFC6238(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, want, code, "t=%d", unix)
+	}
+}
+
+func TestVerifyTOTP_AllowsClockSkew(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	previous, err := utils.TOTPCode(secret, utils.TOTPStep(now)-1)
+	require.NoError(t, err)
+	step, ok := utils.VerifyTOTP(secret, previous, now, totpAllowedSkew)
+	require.True(t, ok)
+	require.Equal(t, utils.TOTPStep(now)-1, step)
+
+	stale, err := utils.TOTPCode(secret, utils.TOTPStep(now)-2)
+	require.NoError(t, err)
+	_, ok = utils.VerifyTOTP(secret, stale, now, totpAllowedSkew)
+	require.False(t, ok)
+}
+
+func TestTwoFactorRoles(t *testing.T) {
+	roles := twoFactorRoles(utils.TwoFactorConfig{})
+	require.True(t, roles[entity.RoleSuperAdmin])
+	require.True(t, roles[entity.RoleAdmin])
+	require.Len(t, roles, 2)
+
+	roles = twoFactorRoles(utils.TwoFactorConfig{RequiredRoles: " Admin , manager,"})
+	require.Equal(t, map[entity.UserRole]bool{"admin": true, "manager": true}, roles)
+
+	require.Empty(t, twoFactorRoles(utils.TwoFactorConfig{RequiredRoles: "none"}))
+}
+
+func TestTwoFactorMethods_EmailAlwaysOffered(t *testing.T) {
+	require.Equal(t, []entity.TwoFactorMethod{entity.TwoFactorMethodEmail}, twoFactorMethods(false))
+	require.Contains(t, twoFactorMethods(true), entity.TwoFactorMethodEmail)
+	require.Contains(t, twoFactorMethods(true), entity.TwoFactorMethodTOTP)
+}
+
+func TestEnrolledCodeMethod(t *testing.T) {
+	require.Equal(t, entity.TwoFactorMethodTOTP, enrolledCodeMethod(" 123456 "))
+	require.Equal(t, entity.TwoFactorMethodRecovery, enrolledCodeMethod("abcde-12345"))
+	require.Equal(t, entity.TwoFactorMethodRecovery, enrolledCodeMethod("12345a"))
+}
+
+func TestNewRecoveryCodes_StoresHashesOfNormalizedCodes(t *testing.T) {
+	codes, records, err := newRecoveryCodes(4)
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, records, recoveryCodeCount)
+
+	for i, code := range codes {
+		require.Equal(t, uint(4), records[i].UserID)
+		require.Equal(t, utils.NormalizeRecoveryCode(code), code)
+		// However it is typed back, the code matches its stored hash
+		typed := strings.ToUpper(strings.ReplaceAll(code, "-", " "))
+		require.Equal(t, records[i].CodeHash, utils.HashToken(utils.NormalizeRecoveryCode(typed)))
+	}
+}
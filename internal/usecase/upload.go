@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"io"
+	"net/http"
+	"path"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxUploadMB = 5
+
+	// maxImagePixels bounds the decoded size, so a small file cannot
+	// expand into an image too large to hold in memory.
+	maxImagePixels = 24_000_000
+
+	imageMaxSide     = 1600
+	thumbnailMaxSide = 256
+	thumbnailSuffix  = "_thumb"
+
+	uploadFolderProfiles   = "profiles"
+	uploadFolderProducts   = "products"
+	uploadFolderCategories = "categories"
+)
+
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type UploadService interface {
+	UploadProfileImage(ctx context.Context, file io.Reader) (*response.UploadResponse, error)
+	UploadProductImage(ctx context.Context, productID uint, file io.Reader) (*response.UploadResponse, error)
+	UploadCategoryIcon(ctx context.Context, categoryID uint, file io.Reader) (*response.UploadResponse, error)
+}
+
+type uploadService struct {
+	tx      TxManager
+	repo    *repository.Repository
+	log     *zap.Logger
+	storage FileStorage
+	config  utils.Configuration
+}
+
+func NewUploadService(tx TxManager, repo *repository.Repository, log *zap.Logger, storage FileStorage, config utils.Configuration) UploadService {
+	return &uploadService{
+		tx:      tx,
+		repo:    repo,
+		log:     log,
+		storage: storage,
+		config:  config,
+	}
+}
+
+// MaxUploadBytes is the largest file the upload endpoints accept.
+func MaxUploadBytes(cfg utils.StorageConfig) int64 {
+	mb := cfg.MaxUploadMB
+	if mb <= 0 {
+		mb = defaultMaxUploadMB
+	}
+	return int64(mb) << 20
+}
+
+// entityFolder is where the uploads of one entity are kept, such as
+// "products/12". Files in it belong to that entity alone.
+func entityFolder(base string, id uint) string {
+	return base + "/" + strconv.FormatUint(uint64(id), 10)
+}
+
+// thumbnailKey is where the thumbnail of the image stored under key lives.
+func thumbnailKey(key string) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + thumbnailSuffix + ext
+}
+
+// storedImage is an uploaded image written to storage.
+type storedImage struct {
+	key          string
+	thumbnailKey string
+}
+
+// storeImage checks the upload, strips it down to pixels and stores it with
+// a thumbnail. Re-encoding drops metadata such as the GPS position phones
+// write into photos; animated GIFs keep their first frame.
+func (s *uploadService) storeImage(ctx context.Context, folder string, file io.Reader) (*storedImage, error) {
+	limit := MaxUploadBytes(s.config.Storage)
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, utils.ErrFileTooLarge
+	}
+
+	// Trust the content, not the name or header the client sent
+	if !allowedImageTypes[http.DetectContentType(data)] {
+		return nil, utils.ErrUnsupportedFileType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, utils.ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, utils.ErrImageTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, utils.ErrInvalidImage
+	}
+
+	keepAlpha := !isOpaque(img)
+	full, contentType, ext, err := utils.EncodeImage(utils.ResizeToFit(img, imageMaxSide), keepAlpha)
+	if err != nil {
+		return nil, err
+	}
+	thumb, _, _, err := utils.EncodeImage(utils.ResizeToFit(img, thumbnailMaxSide), keepAlpha)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &storedImage{key: folder + "/" + uuid.NewString() + ext}
+	stored.thumbnailKey = thumbnailKey(stored.key)
+
+	if err := s.storage.Put(ctx, stored.key, full, contentType); err != nil {
+		s.log.Error("Error store image", zap.String("key", stored.key), zap.Error(err))
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, stored.thumbnailKey, thumb, contentType); err != nil {
+		s.log.Error("Error store thumbnail", zap.String("key", stored.thumbnailKey), zap.Error(err))
+		s.removeImage(ctx, stored.key)
+		return nil, err
+	}
+	return stored, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+func (s *uploadService) response(stored *storedImage) *response.UploadResponse {
+	return &response.UploadResponse{
+		URL:          s.storage.URL(stored.key),
+		ThumbnailURL: s.storage.URL(stored.thumbnailKey),
+	}
+}
+
+// removeImage deletes a stored image and its thumbnail. Failures only leave
+// an orphaned file behind, so they are logged and otherwise ignored.
+func (s *uploadService) removeImage(ctx context.Context, key string) {
+	for _, k := range []string{key, thumbnailKey(key)} {
+		if err := s.storage.Delete(ctx, k); err != nil {
+			s.log.Warn("Error delete stored file", zap.String("key", k), zap.Error(err))
+		}
+	}
+}
+
+// ownedKey returns the key of the upload behind url when it lies directly
+// in folder. Links entered by hand and files of other entities are not the
+// entity's to delete.
+func (s *uploadService) ownedKey(folder, url string) (string, bool) {
+	key, ok := s.storage.Key(url)
+	if !ok || !strings.HasPrefix(key, folder+"/") {
+		return "", false
+	}
+	return key, !strings.Contains(strings.TrimPrefix(key, folder+"/"), "/")
+}
+
+// replaced cleans up after an image was swapped: the new one when saving
+// the change failed, otherwise the old one if it was uploaded for the same
+// entity.
+func (s *uploadService) replaced(ctx context.Context, stored *storedImage, folder, oldURL string, err error) {
+	if err != nil {
+		s.removeImage(ctx, stored.key)
+		return
+	}
+	if key, ok := s.ownedKey(folder, oldURL); ok {
+		s.removeImage(ctx, key)
+	}
+}
+
+// UploadProfileImage sets the picture on the profile of the user behind ctx.
+func (s *uploadService) UploadProfileImage(ctx context.Context, file io.Reader) (*response.UploadResponse, error) {
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		return nil, utils.ErrInvalidToken
+	}
+
+	folder := entityFolder(uploadFolderProfiles, userID)
+	stored, err := s.storeImage(ctx, folder, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldURL string
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.ProfileRepo.GetProfileByID(ctx, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if before.Profile == nil {
+			return utils.ErrUserNotFound
+		}
+		oldURL = before.Profile.ProfileImageURL
+
+		after := *before.Profile
+		after.ProfileImageURL = s.storage.URL(stored.key)
+		if err := s.repo.ProfileRepo.UpdateProfile(ctx, &entity.Profile{UserID: userID, ProfileImageURL: after.ProfileImageURL}); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProfile, userID, before.Profile, after)
+	})
+	s.replaced(ctx, stored, folder, oldURL, err)
+	if err != nil {
+		s.log.Error("Error update profile image", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	return s.response(stored), nil
+}
+
+func (s *uploadService) UploadProductImage(ctx context.Context, productID uint, file io.Reader) (*response.UploadResponse, error) {
+	product, err := s.repo.Product.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrProductNotFound
+	}
+
+	folder := entityFolder(uploadFolderProducts, productID)
+	stored, err := s.storeImage(ctx, folder, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldURL string
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Read again under lock: storing took a while and the stock may
+		// have moved since
+		before, err := s.repo.Product.FindByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+		oldURL = before.ImageURL
+
+		after := *before
+		after.ImageURL = s.storage.URL(stored.key)
+		if err := s.repo.Product.UpdateImageURL(ctx, productID, after.ImageURL); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityProduct, productID, before, after)
+	})
+	s.replaced(ctx, stored, folder, oldURL, err)
+	if err != nil {
+		s.log.Error("Error update product image", zap.Uint("id", productID), zap.Error(err))
+		return nil, err
+	}
+
+	return s.response(stored), nil
+}
+
+func (s *uploadService) UploadCategoryIcon(ctx context.Context, categoryID uint, file io.Reader) (*response.UploadResponse, error) {
+	category, err := s.repo.Category.FindByID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, utils.ErrCategoryNotFound
+	}
+
+	folder := entityFolder(uploadFolderCategories, categoryID)
+	stored, err := s.storeImage(ctx, folder, file)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *category
+	after.IconURL = s.storage.URL(stored.key)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Category.UpdateIconURL(ctx, categoryID, after.IconURL); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityCategory, categoryID, category, after)
+	})
+	s.replaced(ctx, stored, folder, category.IconURL, err)
+	if err != nil {
+		s.log.Error("Error update category icon", zap.Uint("id", categoryID), zap.Error(err))
+		return nil, err
+	}
+
+	return s.response(stored), nil
+}
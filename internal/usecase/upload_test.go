@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/internal/infra"
+	"project-POS-APP-golang-integer/internal/mocks"
+	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryStorage is a FileStorage that keeps files in a map.
+type memoryStorage struct {
+	files map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: map[string][]byte{}}
+}
+
+func (m *memoryStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	m.files[key] = data
+	return nil
+}
+
+func (m *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+func (m *memoryStorage) URL(key string) string {
+	return "https://cdn.test/" + key
+}
+
+func (m *memoryStorage) Key(url string) (string, bool) {
+	if !strings.HasPrefix(url, "https://cdn.test/") {
+		return "", false
+	}
+	return strings.TrimPrefix(url, "https://cdn.test/"), true
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestThumbnailKey(t *testing.T) {
+	require.Equal(t, "products/abc_thumb.jpg", thumbnailKey("products/abc.jpg"))
+	require.Equal(t, "products/abc_thumb", thumbnailKey("products/abc"))
+}
+
+func TestMaxUploadBytes(t *testing.T) {
+	require.Equal(t, int64(5<<20), MaxUploadBytes(utils.StorageConfig{}))
+	require.Equal(t, int64(2<<20), MaxUploadBytes(utils.StorageConfig{MaxUploadMB: 2}))
+}
+
+func TestUploadService_RejectsBadFiles(t *testing.T) {
+	ctx := actorContext(1, entity.RoleStaff)
+	storage := newMemoryStorage()
+	config := utils.Configuration{Storage: utils.StorageConfig{MaxUploadMB: 1}}
+	service := NewUploadService(new(infra.MockTxManager), &repository.Repository{}, zap.NewNop(), storage, config)
+
+	_, err := service.UploadProfileImage(ctx, bytes.NewReader(make([]byte, 1<<20+1)))
+	require.ErrorIs(t, err, utils.ErrFileTooLarge)
+
+	_, err = service.UploadProfileImage(ctx, strings.NewReader("%PDF-1.4 not an image"))
+	require.ErrorIs(t, err, utils.ErrUnsupportedFileType)
+
+	// Looks like a PNG but is cut off after the signature
+	_, err = service.UploadProfileImage(ctx, bytes.NewReader(testPNG(t, 4, 4)[:16]))
+	require.ErrorIs(t, err, utils.ErrInvalidImage)
+
+	require.Empty(t, storage.files)
+}
+
+func TestUploadService_UploadProfileImage_ReplacesOldImage(t *testing.T) {
+	ctx := actorContext(7, entity.RoleStaff)
+	tx := new(infra.MockTxManager)
+	profileRepo := new(mocks.ProfileRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo, AuditLogRepo: auditRepo}
+	storage := newMemoryStorage()
+	service := NewUploadService(tx, &repo, zap.NewNop(), storage, utils.Configuration{})
+
+	storage.files["profiles/7/old.jpg"] = []byte("old")
+	storage.files["profiles/7/old_thumb.jpg"] = []byte("old")
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(7)).Return(&entity.User{
+		ID:      7,
+		Profile: &entity.Profile{UserID: 7, ProfileImageURL: "https://cdn.test/profiles/7/old.jpg"},
+	}, nil)
+	profileRepo.On("UpdateProfile", mock.Anything, mock.AnythingOfType("*entity.Profile")).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := service.UploadProfileImage(ctx, bytes.NewReader(testPNG(t, 2000, 1000)))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res.URL, "https://cdn.test/profiles/7/"))
+	require.True(t, strings.HasSuffix(res.URL, ".jpg"))
+
+	key, _ := storage.Key(res.URL)
+	thumbKey, _ := storage.Key(res.ThumbnailURL)
+	require.Equal(t, thumbnailKey(key), thumbKey)
+	require.Len(t, storage.files, 2)
+
+	full, _, err := image.DecodeConfig(bytes.NewReader(storage.files[key]))
+	require.NoError(t, err)
+	require.Equal(t, 1600, full.Width)
+	require.Equal(t, 800, full.Height)
+
+	thumb, _, err := image.DecodeConfig(bytes.NewReader(storage.files[thumbKey]))
+	require.NoError(t, err)
+	require.Equal(t, 256, thumb.Width)
+	require.Equal(t, 128, thumb.Height)
+
+	profileRepo.AssertCalled(t, "UpdateProfile", mock.Anything, &entity.Profile{UserID: 7, ProfileImageURL: res.URL})
+}
+
+func TestUploadService_UploadProfileImage_UpdateFailsRemovesUpload(t *testing.T) {
+	ctx := actorContext(7, entity.RoleStaff)
+	tx := new(infra.MockTxManager)
+	profileRepo := new(mocks.ProfileRepoMock)
+	repo := repository.Repository{ProfileRepo: profileRepo}
+	storage := newMemoryStorage()
+	service := NewUploadService(tx, &repo, zap.NewNop(), storage, utils.Configuration{})
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	profileRepo.On("GetProfileByID", mock.Anything, uint(7)).Return(&entity.User{ID: 7, Profile: &entity.Profile{UserID: 7}}, nil)
+	profileRepo.On("UpdateProfile", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := service.UploadProfileImage(ctx, bytes.NewReader(testPNG(t, 10, 10)))
+	require.Error(t, err)
+	require.Empty(t, storage.files)
+}
+
+func TestUploadService_UploadProfileImage_KeepsFilesItDoesNotOwn(t *testing.T) {
+	for _, oldURL := range []string{
+		"https://cdn.test/products/3/shared.jpg",
+		"https://cdn.test/profiles/70/other.jpg",
+		"https://cdn.test/profiles/old.jpg",
+	} {
+		ctx := actorContext(7, entity.RoleStaff)
+		tx := new(infra.MockTxManager)
+		profileRepo := new(mocks.ProfileRepoMock)
+		auditRepo := new(mocks.AuditLogRepoMock)
+		repo := repository.Repository{ProfileRepo: profileRepo, AuditLogRepo: auditRepo}
+		storage := newMemoryStorage()
+		service := NewUploadService(tx, &repo, zap.NewNop(), storage, utils.Configuration{})
+
+		oldKey, _ := storage.Key(oldURL)
+		storage.files[oldKey] = []byte("old")
+
+		tx.On("WithinTx", mock.Anything).Return(nil)
+		profileRepo.On("GetProfileByID", mock.Anything, uint(7)).Return(&entity.User{
+			ID:      7,
+			Profile: &entity.Profile{UserID: 7, ProfileImageURL: oldURL},
+		}, nil)
+		profileRepo.On("UpdateProfile", mock.Anything, mock.Anything).Return(nil)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.UploadProfileImage(ctx, bytes.NewReader(testPNG(t, 10, 10)))
+		require.NoError(t, err)
+		require.Contains(t, storage.files, oldKey, oldURL)
+	}
+}
+
+func TestCheckImageURL(t *testing.T) {
+	storage := newMemoryStorage()
+
+	require.NoError(t, checkImageURL(storage, "https://example.com/logo.png", ""))
+	require.NoError(t, checkImageURL(storage, "https://cdn.test/products/3/a.jpg", "https://cdn.test/products/3/a.jpg"))
+	require.ErrorIs(t, checkImageURL(storage, "https://cdn.test/products/3/a.jpg", ""), utils.ErrStoredImageURL)
+	require.NoError(t, checkImageURL(nil, "https://cdn.test/products/3/a.jpg", ""))
+}
+
+func TestUploadService_UploadProductImage_UpdatesOnlyImage(t *testing.T) {
+	ctx := actorContext(1, entity.RoleAdmin)
+	tx := new(infra.MockTxManager)
+	productRepo := new(mocks.ProductRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	repo := repository.Repository{Product: productRepo, AuditLogRepo: auditRepo}
+	storage := newMemoryStorage()
+	service := NewUploadService(tx, &repo, zap.NewNop(), storage, utils.Configuration{})
+
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	productRepo.On("FindByID", uint(3)).Return(&entity.Product{Stock: 10}, nil)
+	// An order sold some while the image was processed
+	productRepo.On("FindByIDForUpdate", mock.Anything, uint(3)).Return(&entity.Product{Stock: 8}, nil)
+	productRepo.On("UpdateImageURL", mock.Anything, uint(3), mock.AnythingOfType("string")).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *entity.AuditLog) bool {
+		return l.EntityType == auditEntityProduct && !strings.Contains(l.Before+l.After, "stock")
+	})).Return(nil)
+
+	res, err := service.UploadProductImage(ctx, 3, bytes.NewReader(testPNG(t, 10, 10)))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res.URL, "https://cdn.test/products/3/"))
+
+	productRepo.AssertCalled(t, "UpdateImageURL", mock.Anything, uint(3), res.URL)
+	productRepo.AssertNotCalled(t, "Update", mock.Anything)
+	auditRepo.AssertExpectations(t)
+}
@@ -2,28 +2,64 @@ package usecase
 
 import (
 	"project-POS-APP-golang-integer/internal/data/repository"
+	"project-POS-APP-golang-integer/pkg/utils"
 
 	"go.uber.org/zap"
 )
 
 type Usecase struct {
-	UserService         UserService
-	AuthService         AuthService
-	CategoryService     CategoryService
-	ProfileService      ProfileService
-	ReservationService  ReservationService
-	InventoryLogService InventoryLogService
-	ProductService      ProductService
+	UserService          UserService
+	AuthService          AuthService
+	CategoryService      CategoryService
+	ProfileService       ProfileService
+	ReservationService   ReservationService
+	InventoryLogService  InventoryLogService
+	ProductService       ProductService
+	ModifierService      ModifierService
+	OrderService         OrderService
+	IngredientService    IngredientService
+	SupplierService      SupplierService
+	PurchaseOrderService PurchaseOrderService
+	StockTakeService     StockTakeService
+	ShiftService         ShiftService
+	AttendanceService    AttendanceService
+	CashDrawerService    CashDrawerService
+	ReportService        ReportService
+	TransactionService   TransactionService
+	ReceiptService       ReceiptService
+	KitchenService       KitchenService
+	PermissionService    PermissionService
+	AuditService         AuditService
+	UploadService        UploadService
 }
 
-func NewUsecase(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender) *Usecase {
+func NewUsecase(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender, storage FileStorage, config utils.Configuration) *Usecase {
+	permissions := NewPermissionService(tx, repo, log)
+	auth := NewAuthService(tx, repo, log, email, config)
+
 	return &Usecase{
-		UserService:         NewUserService(tx, repo, log, email),
-		AuthService:         NewAuthService(tx, repo, log, email),
-		ProfileService:      NewProfileService(tx, repo, log),
-		CategoryService:     NewCategoryService(tx, repo.Category, log),
-		ProductService:      NewProductService(tx, repo.Product, repo.Category, log),
-		ReservationService:  NewReservationService(tx, repo, log),
-		InventoryLogService: NewInventoryLogService(tx, repo, log),
+		UserService:          NewUserService(tx, repo, log, email, auth, config),
+		AuthService:          auth,
+		ProfileService:       NewProfileService(tx, repo, log, storage, config),
+		CategoryService:      NewCategoryService(tx, repo.Category, repo.AuditLogRepo, storage, log),
+		ProductService:       NewProductService(tx, repo.Product, repo.Category, repo.AuditLogRepo, storage, log),
+		ReservationService:   NewReservationService(tx, repo, log),
+		InventoryLogService:  NewInventoryLogService(tx, repo, log),
+		ModifierService:      NewModifierService(tx, repo, log),
+		OrderService:         NewOrderService(tx, repo, log, config, permissions),
+		IngredientService:    NewIngredientService(tx, repo, log),
+		SupplierService:      NewSupplierService(tx, repo, log),
+		PurchaseOrderService: NewPurchaseOrderService(tx, repo, log),
+		StockTakeService:     NewStockTakeService(tx, repo, log),
+		ShiftService:         NewShiftService(tx, repo, log, config),
+		AttendanceService:    NewAttendanceService(tx, repo, log, config),
+		CashDrawerService:    NewCashDrawerService(tx, repo, log),
+		ReportService:        NewReportService(repo, log, config),
+		TransactionService:   NewTransactionService(repo, log),
+		ReceiptService:       NewReceiptService(repo, log, email, config),
+		KitchenService:       NewKitchenService(tx, repo, log),
+		PermissionService:    permissions,
+		AuditService:         NewAuditService(repo, log),
+		UploadService:        NewUploadService(tx, repo, log, storage, config),
 	}
 }
@@ -2,14 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/dto/request"
 	"project-POS-APP-golang-integer/internal/dto/response"
 	"project-POS-APP-golang-integer/pkg/utils"
-	content "project-POS-APP-golang-integer/pkg/utils/email"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type UserService interface{
@@ -19,6 +20,16 @@ type UserService interface{
 	CreateUser(ctx context.Context, req request.UserRequest) (*response.CreateUserResponse, error)
 	UpdateRole(ctx context.Context, req request.UpdateUserRequest) error
 	DeleteUser(ctx context.Context, id uint) error
+	RestoreUser(ctx context.Context, id uint) error
+	ResendInvitation(ctx context.Context, id uint) (*response.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, id uint) error
+	GetInvitation(ctx context.Context, token string) (*response.InvitationResponse, error)
+	AcceptInvitation(ctx context.Context, req request.AcceptInvitationRequest) error
+}
+
+// SessionRevoker ends the sessions of a user whose access changed.
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID uint) (int64, error)
 }
 
 type userService struct {
@@ -26,14 +37,18 @@ type userService struct {
 	repo *repository.Repository
 	log *zap.Logger
 	email EmailSender
+	sessions SessionRevoker
+	config utils.Configuration
 }
 
-func NewUserService(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender) UserService {
+func NewUserService(tx TxManager, repo *repository.Repository, log *zap.Logger, email EmailSender, sessions SessionRevoker, config utils.Configuration) UserService {
 	return &userService{
 		tx: tx,
 		repo: repo,
 		log: log,
 		email: email,
+		sessions: sessions,
+		config: config,
 	}
 }
 
@@ -89,15 +104,31 @@ func (s *userService) FindUserByEmail(ctx context.Context, email string) (*entit
 }
 
 func (s *userService) CreateUser(ctx context.Context, req request.UserRequest) (*response.CreateUserResponse, error) {
-	password, _ := utils.GenerateRandomString(10)
+	if err := s.checkRole(ctx, req.Role); err != nil {
+		return nil, err
+	}
+	if err := checkAssignable(ctx, entity.UserRole(req.Role)); err != nil {
+		return nil, err
+	}
+
+	// Nobody knows this password; the user sets their own from the invitation
+	placeholder, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	token, invitation, err := s.newInvitation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	user := entity.User{
 		Email: req.Email,
 		Role: entity.UserRole(req.Role),
-		PasswordHash: utils.HashPassword(password),
+		PasswordHash: utils.HashPassword(placeholder),
 	}
 	var createdUser *entity.User
 	var profile entity.Profile
-	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
 		// Create user
 		result, e := s.repo.UserRepo.CreateUser(ctx, &user)
 		if e != nil {
@@ -111,8 +142,13 @@ func (s *userService) CreateUser(ctx context.Context, req request.UserRequest) (
 		if e != nil {
 			return e
 		}
+
+		invitation.UserID = createdUser.ID
+		if e := s.repo.InvitationRepo.Create(ctx, invitation); e != nil {
+			return e
+		}
 		
-		return nil
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionCreate, auditEntityUser, createdUser.ID, nil, createdUser)
 	})
 
 	if err != nil {
@@ -121,22 +157,16 @@ func (s *userService) CreateUser(ctx context.Context, req request.UserRequest) (
 	}
 
 	res := response.CreateUserResponse{
+		ID: createdUser.ID,
 		Email: createdUser.Email,
 		Role: createdUser.Role,
-		Password: password,
+		InvitationExpiresAt: invitation.ExpiresAt,
 	}
 
-	err = s.email.Send(ctx, request.EmailRequest{
-		To:      user.Email,
-		Subject: "Account Registered",
-		Body:    content.AccountCreated(res),
-	})
-
-	if err != nil {
-		s.log.Error("Error send email", zap.Error(err))
-	}
+	// The user exists either way; a failed email can be resent
+	res.InvitationSent = s.sendInvitation(ctx, createdUser, token, invitation.ExpiresAt) == nil
 
-	return &res, err
+	return &res, nil
 }
 
 func (s *userService) UpdateRole(ctx context.Context, req request.UpdateUserRequest) error {
@@ -145,24 +175,190 @@ func (s *userService) UpdateRole(ctx context.Context, req request.UpdateUserRequ
 		s.log.Error("Error get user by id", zap.Error(err))
 		return err
 	}
-	
+	if err := checkManageable(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.checkRole(ctx, req.Role); err != nil {
+		return err
+	}
+	role := entity.UserRole(req.Role)
+	if err := checkAssignable(ctx, role); err != nil {
+		return err
+	}
+	if role == user.Role {
+		return nil
+	}
+
 	// Update user role
-	user.Role = entity.UserRole(req.Role)
-	err = s.repo.UserRepo.UpdateUser(ctx, req.ID, &user)
+	before := user
+	user.Role = role
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if before.Role == entity.RoleSuperAdmin {
+			if err := s.keepSuperAdmin(ctx); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.UserRepo.UpdateUser(ctx, req.ID, &user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUser, user.ID, before, user)
+	})
 	if err != nil {
 		s.log.Error("Error update user", zap.Error(err))
 		return err
 	}
 
+	// Sessions and signed tokens carry the old role
+	s.revokeSessions(ctx, user.ID)
+
+	s.log.Info("User role changed",
+		zap.Uint("user_id", user.ID),
+		zap.String("from", string(before.Role)),
+		zap.String("to", string(user.Role)))
 	return nil
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id uint) error {
-	err := s.repo.UserRepo.DeleteUser(ctx, id)
+	user, err := s.repo.UserRepo.GetUserByID(ctx, id)
+	if err != nil {
+		s.log.Error("Error get user by id", zap.Error(err))
+		return err
+	}
+	if err := checkManageable(ctx, user); err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if user.Role == entity.RoleSuperAdmin {
+			if err := s.keepSuperAdmin(ctx); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.UserRepo.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionDelete, auditEntityUser, id, user, nil)
+	})
 	if err != nil {
 		s.log.Error("Error delete user", zap.Error(err))
 		return err
 	}
 
+	s.revokeSessions(ctx, id)
+
+	s.log.Info("User deleted", zap.Uint("user_id", id))
+	return nil
+}
+
+// RestoreUser brings back a soft-deleted user with the role they had. Their
+// sessions stay revoked, so they sign in again.
+func (s *userService) RestoreUser(ctx context.Context, id uint) error {
+	user, err := s.repo.UserRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, utils.ErrUserNotFound) {
+			s.log.Error("Error get deleted user by id", zap.Error(err))
+		}
+		return err
+	}
+	if err := checkManageable(ctx, user); err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UserRepo.RestoreUser(ctx, id); err != nil {
+			return err
+		}
+		restored := user
+		restored.DeletedAt = gorm.DeletedAt{}
+		return recordAudit(ctx, s.repo.AuditLogRepo, entity.AuditActionUpdate, auditEntityUser, id, user, restored)
+	})
+	if err != nil {
+		s.log.Error("Error restore user", zap.Error(err))
+		return err
+	}
+
+	s.log.Info("User restored", zap.Uint("user_id", id))
+	return nil
+}
+
+// keepSuperAdmin stops the last superadmin from being demoted or deleted.
+// It must run inside the transaction of the change, which it locks against
+// other changes to superadmins.
+func (s *userService) keepSuperAdmin(ctx context.Context) error {
+	count, err := s.repo.UserRepo.CountByRole(ctx, entity.RoleSuperAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return utils.ErrLastSuperAdmin
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// revokeSessions logs the user out everywhere once a change that ends their
+// access has been committed. The change stands if this fails; the user can
+// still be forced out later.
+func (s *userService) revokeSessions(ctx context.Context, userID uint) {
+	revoked, err := s.sessions.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		s.log.Error("Error revoke sessions of changed user", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+	s.log.Info("Sessions of changed user revoked", zap.Uint("user_id", userID), zap.Int64("revoked", revoked))
+}
+
+// roleRank orders the built-in roles for who may manage whom. Custom roles
+// rank with staff.
+func roleRank(role entity.UserRole) int {
+	switch role {
+	case entity.RoleSuperAdmin:
+		return 2
+	case entity.RoleAdmin:
+		return 1
+	}
+	return 0
+}
+
+// canManageRole reports whether a user with role actor may manage users
+// holding target or give target to someone. Superadmins manage everyone,
+// everyone else only the roles ranked below their own.
+func canManageRole(actor, target entity.UserRole) bool {
+	if actor == entity.RoleSuperAdmin {
+		return true
+	}
+	return roleRank(actor) > roleRank(target)
+}
+
+// checkManageable makes sure the user behind ctx may manage user. Nobody
+// manages their own account this way.
+func checkManageable(ctx context.Context, user entity.User) error {
+	actorID, _ := ctx.Value("user_id").(uint)
+	actorRole, _ := ctx.Value("user_role").(entity.UserRole)
+
+	if actorID == user.ID {
+		return utils.ErrCannotModifySelf
+	}
+	if !canManageRole(actorRole, user.Role) {
+		return utils.ErrCannotManageUser
+	}
+	return nil
+}
+
+// checkAssignable makes sure the user behind ctx may hand out role.
+func checkAssignable(ctx context.Context, role entity.UserRole) error {
+	actorRole, _ := ctx.Value("user_role").(entity.UserRole)
+	if !canManageRole(actorRole, role) {
+		return utils.ErrCannotManageUser
+	}
+	return nil
+}
+
+// checkRole makes sure the role exists, built-in or created by a superadmin.
+func (s *userService) checkRole(ctx context.Context, role string) error {
+	_, err := s.repo.RoleRepo.FindByName(ctx, role)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.ErrRoleNotFound
+	}
+	return err
+}
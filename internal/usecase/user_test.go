@@ -9,11 +9,14 @@ import (
 	"project-POS-APP-golang-integer/internal/infra"
 	"project-POS-APP-golang-integer/internal/mocks"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func TestUserService_GetUserByID_Success(t *testing.T) {
@@ -30,7 +33,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 
 	log := zap.NewNop()
 	emailSender := new(mocks.EmailSenderMock)
-	service := NewUserService(tx, &repo, log, emailSender)
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
 
 	expectedUser := entity.User{
 		ID:    1,
@@ -64,7 +67,7 @@ func TestUserService_GetUserByID_Error(t *testing.T) {
 
 	log := zap.NewNop()
 	emailSender := new(mocks.EmailSenderMock)
-	service := NewUserService(tx, &repo, log, emailSender)
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
 
 	expectedErr := utils.ErrUserNotFound
 	userRepo.
@@ -81,26 +84,38 @@ func TestUserService_GetUserByID_Error(t *testing.T) {
 }
 
 func TestUserService_CreateUser_Success(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), "user_role", entity.RoleSuperAdmin)
+	ctx = context.WithValue(ctx, "user_id", uint(9))
 
 	userRepo := new(mocks.UserRepoMock)
 	profileRepo := new(mocks.ProfileRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
 	tx := new(infra.MockTxManager)
 
 	repo := repository.Repository{
-		UserRepo:    userRepo,
-		ProfileRepo: profileRepo,
+		UserRepo:       userRepo,
+		ProfileRepo:    profileRepo,
+		RoleRepo:       roleRepo,
+		AuditLogRepo:   auditRepo,
+		InvitationRepo: invitationRepo,
 	}
 
 	log := zap.NewNop()
 	emailSender := new(mocks.EmailSenderMock)
-	service := NewUserService(tx, &repo, log, emailSender)
+	config := utils.Configuration{Invitation: utils.InvitationConfig{URL: "https://pos.test/invite"}}
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), config)
 
 	req := request.UserRequest{
 		Email: "test@mail.com",
 		Role:  "admin",
 	}
 
+	roleRepo.
+		On("FindByName", mock.Anything, "admin").
+		Return(&entity.Role{Name: "admin"}, nil)
+
 	createdUser := &entity.User{
 		ID:    1,
 		Email: req.Email,
@@ -119,39 +134,102 @@ func TestUserService_CreateUser_Success(t *testing.T) {
 		On("CreateProfile", mock.Anything, mock.AnythingOfType("*entity.Profile")).
 		Return(&entity.Profile{}, nil)
 
+	invitationRepo.
+		On("Create", mock.Anything, mock.MatchedBy(func(i *entity.Invitation) bool {
+			return i.UserID == 1 && *i.InvitedBy == 9 && i.TokenHash != "" && i.ExpiresAt.After(time.Now())
+		})).
+		Return(nil)
+
+	auditRepo.
+		On("Create", mock.Anything, mock.AnythingOfType("*entity.AuditLog")).
+		Return(nil)
+
+	// The email carries a link to set a password, never a password
+	emailSender.
+		On("Send", mock.Anything, mock.MatchedBy(func(r request.EmailRequest) bool {
+			return r.To == req.Email && strings.Contains(r.Body, "https://pos.test/invite?token=")
+		})).
+		Return(nil)
+
 	res, err := service.CreateUser(ctx, req)
 
 	assert.NoError(t, err)
+	assert.Equal(t, uint(1), res.ID)
 	assert.Equal(t, req.Email, res.Email)
 	assert.Equal(t, entity.RoleAdmin, res.Role)
-	assert.NotEmpty(t, res.Password) // generated password
+	assert.True(t, res.InvitationSent)
 
 	userRepo.AssertExpectations(t)
 	profileRepo.AssertExpectations(t)
+	invitationRepo.AssertExpectations(t)
+	emailSender.AssertExpectations(t)
 	tx.AssertExpectations(t)
 }
 
+func TestUserService_CreateUser_EmailFailureKeepsUser(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user_role", entity.RoleSuperAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	profileRepo := new(mocks.ProfileRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	invitationRepo := new(mocks.InvitationRepoMock)
+	tx := new(infra.MockTxManager)
+
+	repo := repository.Repository{
+		UserRepo:       userRepo,
+		ProfileRepo:    profileRepo,
+		RoleRepo:       roleRepo,
+		AuditLogRepo:   auditRepo,
+		InvitationRepo: invitationRepo,
+	}
+
+	emailSender := new(mocks.EmailSenderMock)
+	service := NewUserService(tx, &repo, zap.NewNop(), emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	roleRepo.On("FindByName", mock.Anything, "staff").Return(&entity.Role{Name: "staff"}, nil)
+	tx.On("WithinTx", mock.Anything).Return(nil)
+	userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*entity.User")).
+		Return(&entity.User{ID: 2, Email: "new@mail.com", Role: entity.RoleStaff}, nil)
+	profileRepo.On("CreateProfile", mock.Anything, mock.AnythingOfType("*entity.Profile")).Return(&entity.Profile{}, nil)
+	invitationRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Invitation")).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.AuditLog")).Return(nil)
+	emailSender.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+	res, err := service.CreateUser(ctx, request.UserRequest{Email: "new@mail.com", Role: "staff"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), res.ID)
+	assert.False(t, res.InvitationSent)
+}
+
 func TestUserService_CreateUser_UserRepoError(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), "user_role", entity.RoleSuperAdmin)
 
 	userRepo := new(mocks.UserRepoMock)
 	profileRepo := new(mocks.ProfileRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
 	tx := new(infra.MockTxManager)
 
 	repo := repository.Repository{
 		UserRepo:    userRepo,
 		ProfileRepo: profileRepo,
+		RoleRepo:    roleRepo,
 	}
 
 	log := zap.NewNop()
 	emailSender := new(mocks.EmailSenderMock)
-	service := NewUserService(tx, &repo, log, emailSender)
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
 
 	req := request.UserRequest{
 		Email: "test@mail.com",
 		Role:  "admin",
 	}
 
+	roleRepo.
+		On("FindByName", mock.Anything, "admin").
+		Return(&entity.Role{Name: "admin"}, nil)
+
 	expectedErr := errors.New("db error")
 
 	// Tx always runs
@@ -173,26 +251,32 @@ func TestUserService_CreateUser_UserRepoError(t *testing.T) {
 }
 
 func TestUserService_CreateUser_ProfileRepoError(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), "user_role", entity.RoleSuperAdmin)
 
 	userRepo := new(mocks.UserRepoMock)
 	profileRepo := new(mocks.ProfileRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
 	tx := new(infra.MockTxManager)
 
 	repo := repository.Repository{
 		UserRepo:    userRepo,
 		ProfileRepo: profileRepo,
+		RoleRepo:    roleRepo,
 	}
 
 	log := zap.NewNop()
 	emailSender := new(mocks.EmailSenderMock)
-	service := NewUserService(tx, &repo, log, emailSender)
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
 
 	req := request.UserRequest{
 		Email: "test@mail.com",
 		Role:  "admin",
 	}
 
+	roleRepo.
+		On("FindByName", mock.Anything, "admin").
+		Return(&entity.Role{Name: "admin"}, nil)
+
 	tx.On("WithinTx", mock.Anything).Return(nil)
 
 	createdUser := &entity.User{
@@ -221,3 +305,221 @@ func TestUserService_CreateUser_ProfileRepoError(t *testing.T) {
 	userRepo.AssertExpectations(t)
 	profileRepo.AssertExpectations(t)
 }
+
+func TestUserService_CreateUser_UnknownRole(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := new(mocks.UserRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	tx := new(infra.MockTxManager)
+
+	repo := repository.Repository{
+		UserRepo: userRepo,
+		RoleRepo: roleRepo,
+	}
+
+	log := zap.NewNop()
+	emailSender := new(mocks.EmailSenderMock)
+	service := NewUserService(tx, &repo, log, emailSender, new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	roleRepo.
+		On("FindByName", mock.Anything, "barista").
+		Return((*entity.Role)(nil), gorm.ErrRecordNotFound)
+
+	res, err := service.CreateUser(ctx, request.UserRequest{Email: "test@mail.com", Role: "barista"})
+
+	assert.ErrorIs(t, err, utils.ErrRoleNotFound)
+	assert.Nil(t, res)
+	userRepo.AssertNotCalled(t, "CreateUser")
+}
+
+func TestCanManageRole(t *testing.T) {
+	assert.True(t, canManageRole(entity.RoleSuperAdmin, entity.RoleSuperAdmin))
+	assert.True(t, canManageRole(entity.RoleSuperAdmin, entity.RoleAdmin))
+	assert.True(t, canManageRole(entity.RoleAdmin, entity.RoleStaff))
+	assert.True(t, canManageRole(entity.RoleAdmin, entity.UserRole("cashier")))
+	assert.False(t, canManageRole(entity.RoleAdmin, entity.RoleAdmin))
+	assert.False(t, canManageRole(entity.RoleAdmin, entity.RoleSuperAdmin))
+	assert.False(t, canManageRole(entity.RoleStaff, entity.RoleStaff))
+}
+
+func actorContext(id uint, role entity.UserRole) context.Context {
+	ctx := context.WithValue(context.Background(), "user_id", id)
+	return context.WithValue(ctx, "user_role", role)
+}
+
+func TestUserService_UpdateRole_AdminCannotDemoteSuperAdmin(t *testing.T) {
+	ctx := actorContext(2, entity.RoleAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	tx := new(infra.MockTxManager)
+	repo := repository.Repository{UserRepo: userRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(1)).
+		Return(entity.User{ID: 1, Role: entity.RoleSuperAdmin}, nil)
+
+	err := service.UpdateRole(ctx, request.UpdateUserRequest{ID: 1, Role: "staff"})
+
+	assert.ErrorIs(t, err, utils.ErrCannotManageUser)
+	tx.AssertNotCalled(t, "WithinTx", mock.Anything)
+}
+
+func TestUserService_UpdateRole_Self(t *testing.T) {
+	ctx := actorContext(1, entity.RoleSuperAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	repo := repository.Repository{UserRepo: userRepo}
+	service := NewUserService(new(infra.MockTxManager), &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(1)).
+		Return(entity.User{ID: 1, Role: entity.RoleSuperAdmin}, nil)
+
+	err := service.UpdateRole(ctx, request.UpdateUserRequest{ID: 1, Role: "staff"})
+
+	assert.ErrorIs(t, err, utils.ErrCannotModifySelf)
+}
+
+func TestUserService_UpdateRole_AdminCannotPromoteToAdmin(t *testing.T) {
+	ctx := actorContext(2, entity.RoleAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	repo := repository.Repository{UserRepo: userRepo, RoleRepo: roleRepo}
+	service := NewUserService(new(infra.MockTxManager), &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(3)).
+		Return(entity.User{ID: 3, Role: entity.RoleStaff}, nil)
+	roleRepo.
+		On("FindByName", ctx, "admin").
+		Return(&entity.Role{Name: "admin"}, nil)
+
+	err := service.UpdateRole(ctx, request.UpdateUserRequest{ID: 3, Role: "admin"})
+
+	assert.ErrorIs(t, err, utils.ErrCannotManageUser)
+	userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserService_UpdateRole_LastSuperAdmin(t *testing.T) {
+	ctx := actorContext(1, entity.RoleSuperAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	tx := new(infra.MockTxManager)
+	sessions := new(mocks.SessionRevokerMock)
+	repo := repository.Repository{UserRepo: userRepo, RoleRepo: roleRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), sessions, utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(2)).
+		Return(entity.User{ID: 2, Role: entity.RoleSuperAdmin}, nil)
+	roleRepo.
+		On("FindByName", ctx, "admin").
+		Return(&entity.Role{Name: "admin"}, nil)
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.
+		On("CountByRole", ctx, entity.RoleSuperAdmin).
+		Return(int64(1), nil)
+
+	err := service.UpdateRole(ctx, request.UpdateUserRequest{ID: 2, Role: "admin"})
+
+	assert.ErrorIs(t, err, utils.ErrLastSuperAdmin)
+	userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything)
+	sessions.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything)
+}
+
+func TestUserService_UpdateRole_RevokesSessions(t *testing.T) {
+	ctx := actorContext(2, entity.RoleAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	roleRepo := new(mocks.RoleRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	tx := new(infra.MockTxManager)
+	sessions := new(mocks.SessionRevokerMock)
+	repo := repository.Repository{UserRepo: userRepo, RoleRepo: roleRepo, AuditLogRepo: auditRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), sessions, utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(3)).
+		Return(entity.User{ID: 3, Role: entity.RoleStaff}, nil)
+	roleRepo.
+		On("FindByName", ctx, "cashier").
+		Return(&entity.Role{Name: "cashier"}, nil)
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.
+		On("UpdateUser", ctx, uint(3), mock.MatchedBy(func(u *entity.User) bool { return u.Role == "cashier" })).
+		Return(nil)
+	auditRepo.
+		On("Create", ctx, mock.AnythingOfType("*entity.AuditLog")).
+		Return(nil)
+	sessions.
+		On("RevokeUserSessions", ctx, uint(3)).
+		Return(int64(2), nil)
+
+	err := service.UpdateRole(ctx, request.UpdateUserRequest{ID: 3, Role: "cashier"})
+
+	assert.NoError(t, err)
+	userRepo.AssertNotCalled(t, "CountByRole", mock.Anything, mock.Anything)
+	userRepo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
+
+func TestUserService_DeleteUser_RevokesSessions(t *testing.T) {
+	ctx := actorContext(1, entity.RoleSuperAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	tx := new(infra.MockTxManager)
+	sessions := new(mocks.SessionRevokerMock)
+	repo := repository.Repository{UserRepo: userRepo, AuditLogRepo: auditRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), sessions, utils.Configuration{})
+
+	userRepo.
+		On("GetUserByID", ctx, uint(2)).
+		Return(entity.User{ID: 2, Role: entity.RoleSuperAdmin}, nil)
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.
+		On("CountByRole", ctx, entity.RoleSuperAdmin).
+		Return(int64(2), nil)
+	userRepo.On("DeleteUser", ctx, uint(2)).Return(nil)
+	auditRepo.
+		On("Create", ctx, mock.AnythingOfType("*entity.AuditLog")).
+		Return(nil)
+	sessions.
+		On("RevokeUserSessions", ctx, uint(2)).
+		Return(int64(1), nil)
+
+	err := service.DeleteUser(ctx, 2)
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	sessions.AssertExpectations(t)
+}
+
+func TestUserService_RestoreUser(t *testing.T) {
+	ctx := actorContext(2, entity.RoleAdmin)
+
+	userRepo := new(mocks.UserRepoMock)
+	auditRepo := new(mocks.AuditLogRepoMock)
+	tx := new(infra.MockTxManager)
+	repo := repository.Repository{UserRepo: userRepo, AuditLogRepo: auditRepo}
+	service := NewUserService(tx, &repo, zap.NewNop(), new(mocks.EmailSenderMock), new(mocks.SessionRevokerMock), utils.Configuration{})
+
+	deleted := entity.User{ID: 3, Role: entity.RoleStaff}
+	deleted.DeletedAt.Valid = true
+	userRepo.On("FindDeletedByID", ctx, uint(3)).Return(deleted, nil)
+	tx.On("WithinTx", ctx).Return(nil)
+	userRepo.On("RestoreUser", ctx, uint(3)).Return(nil)
+	auditRepo.
+		On("Create", ctx, mock.AnythingOfType("*entity.AuditLog")).
+		Return(nil)
+
+	assert.NoError(t, service.RestoreUser(ctx, 3))
+	userRepo.AssertExpectations(t)
+
+	userRepo.On("FindDeletedByID", ctx, uint(4)).Return(entity.User{}, utils.ErrUserNotFound)
+	assert.ErrorIs(t, service.RestoreUser(ctx, 4), utils.ErrUserNotFound)
+}
@@ -2,12 +2,15 @@ package wire
 
 import (
 	"project-POS-APP-golang-integer/internal/adaptor"
+	"project-POS-APP-golang-integer/internal/data/entity"
 	"project-POS-APP-golang-integer/internal/data/repository"
 	"project-POS-APP-golang-integer/internal/infra"
 	"project-POS-APP-golang-integer/internal/infra/email"
+	"project-POS-APP-golang-integer/internal/infra/storage"
 	"project-POS-APP-golang-integer/internal/usecase"
 	mCustom "project-POS-APP-golang-integer/pkg/middleware"
 	"project-POS-APP-golang-integer/pkg/utils"
+	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +27,11 @@ type App struct {
 
 func Wiring(db *gorm.DB, repo *repository.Repository, log *zap.Logger, config utils.Configuration) *App {
 	r := gin.Default()
+	// Client addresses feed the login throttle, so only believe forwarded
+	// headers from our own proxies
+	if err := r.SetTrustedProxies(trustedProxies(config.TrustedProxies)); err != nil {
+		log.Fatal("Error set trusted proxies", zap.Error(err))
+	}
 	r1 := r.Group("/api/v1")
 
 	emailJobs := make(chan utils.EmailJob, 10) // BUFFER
@@ -35,11 +43,19 @@ func Wiring(db *gorm.DB, repo *repository.Repository, log *zap.Logger, config ut
 
 	tx := infra.NewGormTxManager(db)
 	email := email.NewAsyncEmailSender(emailJobs, config, log)
-	usecase := usecase.NewUsecase(tx, repo, log, email)
+	files, err := storage.New(config.Storage, config.BaseURL)
+	if err != nil {
+		log.Fatal("Error init file storage", zap.Error(err))
+	}
+	if local, ok := files.(*storage.Local); ok {
+		r.Static(storage.LocalRoute, local.Dir())
+	}
+	usecase := usecase.NewUsecase(tx, repo, log, email, files, config)
 	handler := adaptor.NewHandler(usecase, log, config)
 	mw := mCustom.NewMiddlewareCustom(usecase, log)
 
 	// Panggil ApiV1 dengan semua routes termasuk category
+	r1.Use(mw.RequestContext())
 	ApiV1(r1, &handler, mw)
 
 	return &App{
@@ -50,6 +66,17 @@ func Wiring(db *gorm.DB, repo *repository.Repository, log *zap.Logger, config ut
 	}
 }
 
+// trustedProxies splits the comma separated TRUSTED_PROXIES setting.
+func trustedProxies(list string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(list, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 func ApiV1(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
 	AuthRoute(r.Group("/auth"), handler, mw)
 	UserRoute(r.Group("/users"), handler, mw)
@@ -58,32 +85,88 @@ func ApiV1(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCu
 	InventoryRoute(r.Group("/inventories"), handler, mw)
 	CategoryRoute(r.Group("/categories"), handler, mw)
 	ProductRoute(r.Group("/products"), handler, mw)
+	ModifierRoute(r, handler, mw)
+	OrderRoute(r.Group("/orders"), handler, mw)
+	IngredientRoute(r.Group("/ingredients"), handler, mw)
+	SupplierRoute(r.Group("/suppliers"), handler, mw)
+	PurchaseOrderRoute(r.Group("/purchase-orders"), handler, mw)
+	StockTakeRoute(r.Group("/stock-takes"), handler, mw)
+	ShiftRoute(r.Group("/shifts"), handler, mw)
+	AttendanceRoute(r.Group("/attendance"), handler, mw)
+	CashDrawerRoute(r.Group("/cash-drawers"), handler, mw)
+	ReportRoute(r.Group("/reports"), handler, mw)
+	TransactionRoute(r.Group("/transactions"), handler, mw)
+	KitchenRoute(r.Group("/kds"), handler, mw)
+	RoleRoute(r, handler, mw)
+	AuditRoute(r.Group("/audit-logs"), handler, mw)
 }
 
 func AuthRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
 	r.POST("/login", handler.AuthHandler.Login)
+	r.POST("/login/verify", handler.AuthHandler.VerifyLogin)
+	r.POST("/login/email-code", handler.AuthHandler.SendLoginCode)
+	r.POST("/refresh", handler.AuthHandler.Refresh)
+	r.POST("/request-reset-password", handler.AuthHandler.RequestResetPassword)
+	r.POST("/validate-otp", handler.AuthHandler.ValidateOTP)
+	r.POST("/reset-password", handler.AuthHandler.ResetPassword)
+	r.POST("/invitation", handler.UserHandler.GetInvitation)
+	r.POST("/invitation/accept", handler.UserHandler.AcceptInvitation)
 
 	r.Use(mw.AuthMiddleware())
 	{
 		r.POST("/logout", handler.AuthHandler.Logout)
-		r.POST("/request-reset-password", handler.AuthHandler.RequestResetPassword)
-		r.POST("/validate-otp", handler.AuthHandler.ValidateOTP)
-		r.POST("/reset-password", handler.AuthHandler.ResetPassword)
+		r.GET("/sessions", handler.AuthHandler.ListSessions)
+		r.DELETE("/sessions", handler.AuthHandler.RevokeOtherSessions)
+		r.DELETE("/sessions/:id", handler.AuthHandler.RevokeSession)
+		r.PUT("/manager-pin", handler.AuthHandler.SetManagerPIN)
+		r.GET("/2fa", handler.AuthHandler.GetTwoFactorStatus)
+		r.POST("/2fa/setup", handler.AuthHandler.SetupTwoFactor)
+		r.POST("/2fa/enable", handler.AuthHandler.EnableTwoFactor)
+		r.POST("/2fa/disable", handler.AuthHandler.DisableTwoFactor)
+		r.POST("/2fa/recovery-codes", handler.AuthHandler.RegenerateRecoveryCodes)
 	}
 }
 
 func UserRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
-	r.Use(mw.AuthMiddleware(), mw.RequirePermission("superadmin", "admin"))
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermUsersManage))
 	r.GET("/", handler.UserHandler.GetUserList)
 	r.POST("/", handler.UserHandler.CreateUser)
 	r.PUT("/:id", handler.UserHandler.UpdateRole)
 	r.DELETE("/:id", handler.UserHandler.DeleteUser)
+	r.POST("/:id/restore", handler.UserHandler.RestoreUser)
+	r.POST("/:id/invitation", handler.UserHandler.ResendInvitation)
+	r.DELETE("/:id/invitation", handler.UserHandler.RevokeInvitation)
+	r.GET("/:id/profile", handler.ProfileHandler.GetStaffProfile)
+	r.PUT("/:id/profile", handler.ProfileHandler.UpdateStaffProfile)
+	r.GET("/:id/salary", mw.RequirePermission(entity.PermSalaryManage), handler.ProfileHandler.GetSalaryHistory)
+	r.POST("/:id/salary", mw.RequirePermission(entity.PermSalaryManage), handler.ProfileHandler.ChangeSalary)
+	r.POST("/:id/logout", handler.AuthHandler.ForceLogout)
+	r.POST("/:id/unlock", handler.AuthHandler.UnlockAccount)
+	r.GET("/:id/permissions", mw.RequirePermission(entity.PermRolesManage), handler.RoleHandler.GetUserPermissions)
+	r.PUT("/:id/permissions", mw.RequirePermission(entity.PermRolesManage), handler.RoleHandler.SetUserPermissions)
+}
+
+func RoleRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	protected := r.Group("")
+	protected.Use(
+		mw.AuthMiddleware(),
+		mw.RequirePermission(entity.PermRolesManage),
+	)
+
+	protected.GET("/permissions", handler.RoleHandler.GetPermissions)
+	protected.GET("/roles", handler.RoleHandler.GetRoles)
+	protected.POST("/roles", handler.RoleHandler.CreateRole)
+	protected.GET("/roles/:id", handler.RoleHandler.GetRoleByID)
+	protected.PUT("/roles/:id", handler.RoleHandler.UpdateRole)
+	protected.DELETE("/roles/:id", handler.RoleHandler.DeleteRole)
 }
 
 func ProfileRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
 	r.Use(mw.AuthMiddleware())
 	r.GET("/", handler.ProfileHandler.GetProfile)
 	r.PUT("/", handler.ProfileHandler.UpdateProfile)
+	r.POST("/image", handler.UploadHandler.UploadProfileImage)
+	r.GET("/shifts", handler.ShiftHandler.GetMyShifts)
 }
 
 func ReservationRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
@@ -102,9 +185,10 @@ func ReservationRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.M
 }
 
 func InventoryRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
-	r.Use(mw.AuthMiddleware(), mw.RequirePermission("superadmin", "admin", "staff"))
-	r.GET("/", mw.AuthMiddleware(), handler.InventoryLogHandler.GetInventoryLogs)
-	r.POST("/", mw.AuthMiddleware(), handler.InventoryLogHandler.CreateInventoryLog)
+	r.Use(mw.AuthMiddleware())
+	r.GET("/", mw.RequirePermission(entity.PermInventoryView), handler.InventoryLogHandler.GetInventoryLogs)
+	r.POST("/", mw.RequirePermission(entity.PermInventoryAdjust), handler.InventoryLogHandler.CreateInventoryLog)
+	r.GET("/products/:id/history", mw.RequirePermission(entity.PermInventoryView), handler.InventoryLogHandler.GetProductStockHistory)
 }
 
 func CategoryRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
@@ -115,26 +199,202 @@ func CategoryRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.Midd
 	protected := r.Group("")
 	protected.Use(
 		mw.AuthMiddleware(),
-		mw.RequirePermission("admin", "superadmin"), // 🔥 GUNAKAN middleware yang ADA
+		mw.RequirePermission(entity.PermCatalogManage), // 🔥 GUNAKAN middleware yang ADA
 	)
 	protected.POST("", handler.CategoryHandler.CreateCategory)       // POST /api/v1/categories
 	protected.PUT("/:id", handler.CategoryHandler.UpdateCategory)    // PUT /api/v1/categories/:id
 	protected.DELETE("/:id", handler.CategoryHandler.DeleteCategory) // DELETE /api/v1/categories/:id
+	protected.POST("/:id/icon", handler.UploadHandler.UploadCategoryIcon)
 }
 
 func ProductRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
 	// 🔥 PUBLIC ROUTES - tidak perlu auth
 	r.GET("", handler.ProductHandler.GetAllProducts) // TODO: akan dibuat nanti
 	r.GET("/:id", handler.ProductHandler.GetProductByID)
+	r.GET("/:id/modifier-groups", handler.ModifierHandler.GetGroupsByProduct)
 
 	// 🔥 PROTECTED ROUTES - perlu auth DAN admin permission
 	protected := r.Group("")
 	protected.Use(
 		mw.AuthMiddleware(),
-		mw.RequirePermission("admin", "superadmin"),
+		mw.RequirePermission(entity.PermCatalogManage),
 	)
 
 	protected.POST("", handler.ProductHandler.CreateProduct)
 	protected.PUT("/:id", handler.ProductHandler.UpdateProduct)
 	protected.DELETE("/:id", handler.ProductHandler.DeleteProduct)
+	protected.POST("/:id/image", handler.UploadHandler.UploadProductImage)
+	protected.POST("/:id/modifier-groups", handler.ModifierHandler.CreateGroup)
+	protected.GET("/:id/recipe", handler.IngredientHandler.GetProductRecipe)
+	protected.PUT("/:id/recipe", handler.IngredientHandler.SetProductRecipe)
+}
+
+func ModifierRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	protected := r.Group("")
+	protected.Use(
+		mw.AuthMiddleware(),
+		mw.RequirePermission(entity.PermCatalogManage),
+	)
+
+	protected.PUT("/modifier-groups/:id", handler.ModifierHandler.UpdateGroup)
+	protected.DELETE("/modifier-groups/:id", handler.ModifierHandler.DeleteGroup)
+	protected.POST("/modifier-groups/:id/options", handler.ModifierHandler.CreateOption)
+	protected.PUT("/modifier-options/:id", handler.ModifierHandler.UpdateOption)
+	protected.DELETE("/modifier-options/:id", handler.ModifierHandler.DeleteOption)
+	protected.GET("/modifier-options/:id/recipe", handler.IngredientHandler.GetModifierRecipe)
+	protected.PUT("/modifier-options/:id/recipe", handler.IngredientHandler.SetModifierRecipe)
+}
+
+func OrderRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermOrdersManage))
+	r.POST("", handler.OrderHandler.CreateOrder)
+	r.GET("", handler.OrderHandler.GetOrders)
+	r.GET("/:id", handler.OrderHandler.GetOrderByID)
+	r.POST("/:id/items", handler.OrderHandler.AddOrderItem)
+	r.PUT("/:id/status", handler.OrderHandler.UpdateOrderStatus)
+	r.POST("/:id/discount", handler.OrderHandler.ApplyDiscount)
+	r.POST("/:id/refunds", handler.OrderHandler.RefundOrder)
+	r.GET("/:id/receipt", handler.ReceiptHandler.GetReceipt)
+	r.POST("/:id/receipt/email", handler.ReceiptHandler.EmailReceipt)
+}
+
+func IngredientRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware())
+
+	// Kitchen staff need to see what is running low
+	staff := r.Group("")
+	staff.Use(mw.RequirePermission(entity.PermIngredientsView))
+	staff.GET("", handler.IngredientHandler.GetIngredients)
+	staff.GET("/low-stock", handler.IngredientHandler.GetLowStockIngredients)
+	staff.GET("/:id", handler.IngredientHandler.GetIngredientByID)
+	staff.GET("/:id/logs", handler.IngredientHandler.GetIngredientLogs)
+
+	protected := r.Group("")
+	protected.Use(mw.RequirePermission(entity.PermIngredientsManage))
+	protected.POST("", handler.IngredientHandler.CreateIngredient)
+	protected.PUT("/:id", handler.IngredientHandler.UpdateIngredient)
+	protected.DELETE("/:id", handler.IngredientHandler.DeleteIngredient)
+	protected.POST("/:id/stock", handler.IngredientHandler.AdjustStock)
+}
+
+func SupplierRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermSuppliersManage))
+	r.GET("", handler.SupplierHandler.GetSuppliers)
+	r.POST("", handler.SupplierHandler.CreateSupplier)
+	r.GET("/:id", handler.SupplierHandler.GetSupplierByID)
+	r.PUT("/:id", handler.SupplierHandler.UpdateSupplier)
+	r.DELETE("/:id", handler.SupplierHandler.DeleteSupplier)
+}
+
+func PurchaseOrderRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware())
+
+	// Staff receive deliveries at the back door
+	staff := r.Group("")
+	staff.Use(mw.RequirePermission(entity.PermPurchasingReceive))
+	staff.GET("", handler.PurchaseOrderHandler.GetPurchaseOrders)
+	staff.GET("/:id", handler.PurchaseOrderHandler.GetPurchaseOrderByID)
+	staff.POST("/:id/receive", handler.PurchaseOrderHandler.ReceivePurchaseOrder)
+
+	protected := r.Group("")
+	protected.Use(mw.RequirePermission(entity.PermPurchasingManage))
+	protected.POST("", handler.PurchaseOrderHandler.CreatePurchaseOrder)
+	protected.PUT("/:id", handler.PurchaseOrderHandler.UpdatePurchaseOrder)
+	protected.POST("/:id/submit", handler.PurchaseOrderHandler.SubmitPurchaseOrder)
+	protected.POST("/:id/cancel", handler.PurchaseOrderHandler.CancelPurchaseOrder)
+}
+
+func StockTakeRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware())
+
+	// Staff do the counting, managers review and commit
+	staff := r.Group("")
+	staff.Use(mw.RequirePermission(entity.PermStockTakesCount))
+	staff.GET("", handler.StockTakeHandler.GetStockTakes)
+	staff.POST("", handler.StockTakeHandler.OpenStockTake)
+	staff.GET("/:id", handler.StockTakeHandler.GetStockTakeByID)
+	staff.PUT("/:id/counts", handler.StockTakeHandler.RecordCounts)
+	staff.DELETE("/:id/counts/:product_id", handler.StockTakeHandler.RemoveCount)
+
+	protected := r.Group("")
+	protected.Use(mw.RequirePermission(entity.PermStockTakesManage))
+	protected.GET("/:id/report", handler.StockTakeHandler.DownloadVarianceReport)
+	protected.POST("/:id/commit", handler.StockTakeHandler.CommitStockTake)
+	protected.POST("/:id/cancel", handler.StockTakeHandler.CancelStockTake)
+}
+
+func ShiftRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermShiftsManage))
+	r.GET("", handler.ShiftHandler.GetShifts)
+	r.POST("", handler.ShiftHandler.CreateShift)
+	r.POST("/bulk", handler.ShiftHandler.BulkAssignShifts)
+	r.POST("/copy-week", handler.ShiftHandler.CopyWeek)
+	r.GET("/:id", handler.ShiftHandler.GetShiftByID)
+	r.PUT("/:id", handler.ShiftHandler.UpdateShift)
+	r.DELETE("/:id", handler.ShiftHandler.DeleteShift)
+}
+
+func AttendanceRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware())
+
+	// Everyone punches their own time
+	staff := r.Group("")
+	staff.Use(mw.RequirePermission(entity.PermAttendanceClock))
+	staff.POST("/clock-in", handler.AttendanceHandler.ClockIn)
+	staff.POST("/clock-out", handler.AttendanceHandler.ClockOut)
+	staff.GET("/me", handler.AttendanceHandler.GetMyAttendance)
+
+	protected := r.Group("")
+	protected.Use(mw.RequirePermission(entity.PermAttendanceManage))
+	protected.GET("", handler.AttendanceHandler.GetAttendance)
+	protected.GET("/timesheet", handler.AttendanceHandler.GetTimesheet)
+	protected.GET("/:id", handler.AttendanceHandler.GetAttendanceByID)
+	protected.PUT("/:id", handler.AttendanceHandler.CorrectAttendance)
+}
+
+func CashDrawerRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware())
+
+	// Cashiers run their own till, managers can see and close any of them
+	staff := r.Group("")
+	staff.Use(mw.RequirePermission(entity.PermCashDrawerOperate))
+	staff.POST("", handler.CashDrawerHandler.OpenDrawer)
+	staff.GET("/current", handler.CashDrawerHandler.GetCurrentDrawer)
+	staff.GET("/:id", handler.CashDrawerHandler.GetDrawerByID)
+	staff.POST("/:id/pay-ins", handler.CashDrawerHandler.PayIn)
+	staff.POST("/:id/pay-outs", handler.CashDrawerHandler.PayOut)
+	staff.POST("/:id/close", handler.CashDrawerHandler.CloseDrawer)
+	staff.GET("/:id/z-report", handler.CashDrawerHandler.GetZReport)
+
+	protected := r.Group("")
+	protected.Use(mw.RequirePermission(entity.PermCashDrawerManage))
+	protected.GET("", handler.CashDrawerHandler.GetDrawers)
+}
+
+func ReportRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermReportsView))
+	r.GET("/dashboard", handler.ReportHandler.GetDashboard)
+	r.GET("/summary", handler.ReportHandler.GetSummary)
+	r.GET("/revenue", handler.ReportHandler.GetRevenue)
+	r.GET("/sales-by-category", handler.ReportHandler.GetSalesByCategory)
+	r.GET("/sales-by-product", handler.ReportHandler.GetSalesByProduct)
+	r.GET("/sales-by-payment-method", handler.ReportHandler.GetSalesByPaymentMethod)
+	r.GET("/peak-hours", handler.ReportHandler.GetPeakHours)
+}
+
+func TransactionRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermTransactionsView))
+	r.GET("/", handler.TransactionHandler.GetTransactions)
+}
+
+func AuditRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermAuditView))
+	r.GET("", handler.AuditHandler.GetAuditLogs)
+}
+
+func KitchenRoute(r *gin.RouterGroup, handler *adaptor.Handler, mw mCustom.MiddlewareCustom) {
+	r.Use(mw.AuthMiddleware(), mw.RequirePermission(entity.PermKitchenOperate))
+	r.GET("", handler.KitchenHandler.GetQueue)
+	r.POST("/items/:id/bump", handler.KitchenHandler.BumpItem)
+	r.POST("/items/:id/recall", handler.KitchenHandler.RecallItem)
 }
@@ -32,6 +32,7 @@ func InitDB(config utils.DatabaseConfig) (*gorm.DB, error) {
 	conn, err := gorm.Open(postgres.Open(connStr), &gorm.Config{
 		Logger: newLogger,
 		PrepareStmt: true,
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
@@ -1,9 +1,9 @@
 package middleware
 
 import (
-	"errors"
-	"net/http"
+	"fmt"
 	"project-POS-APP-golang-integer/internal/data/entity"
+	"project-POS-APP-golang-integer/internal/usecase"
 	"project-POS-APP-golang-integer/pkg/utils"
 	"strings"
 
@@ -14,63 +14,47 @@ func (mw *MiddlewareCustom) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if !strings.HasPrefix(auth, "Bearer ") {
-			utils.ResponseFailed(c, http.StatusUnauthorized, "missing token", nil)
+			utils.ResponseError(c, utils.ErrMissingToken)
 			c.Abort()
 			return
 		}
 
 		token := strings.TrimPrefix(auth, "Bearer ")
 
-		userID, err := mw.Usecase.AuthService.ValidateToken(c, token)
+		principal, err := mw.Usecase.AuthService.Authenticate(c, token)
 		if err != nil {
-			utils.ResponseFailed(c, http.StatusUnauthorized, "invalid token", err)
-			c.Abort()
-			return
-		}
-
-		user, err := mw.Usecase.UserService.GetUserByID(c, *userID)
-		if err != nil {
-			utils.ResponseFailed(c, http.StatusUnauthorized, "user not found", err)
+			if utils.AsError(err) == utils.ErrInternal {
+				err = utils.ErrInvalidToken
+			}
+			utils.ResponseError(c, err)
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", user.ID)
-		c.Set("user_role", entity.UserRole(user.Role))
+		c.Set("user_id", principal.UserID)
+		c.Set("session_id", principal.SessionID)
+		c.Set("user_role", entity.UserRole(principal.Role))
+		c.Set("permissions", mw.Usecase.PermissionService.EffectivePermissions(c, principal.Role, principal.UserID))
 		c.Next()
 	}
 }
 
-func (mw *MiddlewareCustom) RequirePermission(roles ...entity.UserRole) gin.HandlerFunc {
+// RequirePermission lets the request through only when the authenticated
+// user holds the named permission, through their role or an override.
+func (mw *MiddlewareCustom) RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roleVal, exists := c.Get("user_role")
-		if !exists {
-			utils.ResponseFailed(c, http.StatusUnauthorized, "unauthorized", nil)
+		if _, exists := c.Get("permissions"); !exists {
+			utils.ResponseError(c, utils.ErrUnauthorized)
 			c.Abort()
 			return
 		}
 
-		userRole, ok := roleVal.(entity.UserRole)
-		if !ok {
-			utils.ResponseFailed(c, http.StatusInternalServerError, "invalid role type", nil)
+		if !usecase.HasPermission(c, permission) {
+			utils.ResponseError(c, fmt.Errorf("%w: missing %s", utils.ErrPermissionDenied, permission))
 			c.Abort()
 			return
 		}
 
-		for _, role := range roles {
-			if userRole == role {
-				c.Next()
-				return
-			}
-		}
-
-		utils.ResponseFailed(
-			c,
-			http.StatusForbidden,
-			"forbidden",
-			errors.New("insufficient permission"),
-		)
-		c.Abort()
 		c.Next()
 	}
 }
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDPattern limits client supplied request IDs to something safe to
+// log and store.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestContext tags every request with an ID, taken from X-Request-ID when
+// the client sends a usable one, and stores it with the client address for
+// the logs and the audit trail.
+func (mw *MiddlewareCustom) RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("request_id", requestID)
+		c.Set("client_ip", c.ClientIP())
+		c.Header("X-Request-ID", requestID)
+		c.Next()
+	}
+}
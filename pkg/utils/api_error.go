@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error is an error the API reports to clients. Code is stable and machine
+// readable so clients can localize the message, Status is the HTTP status
+// it is answered with and Fields point at the input at fault.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Fields  []FieldError
+}
+
+func NewError(code string, status int, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so a copy carrying field details still matches
+// the error it was made from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithFields returns a copy of e pointing at the given fields.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	copied := *e
+	copied.Fields = append([]FieldError(nil), fields...)
+	return &copied
+}
+
+// ErrorBody is what Reponse.Errors holds when a request fails.
+type ErrorBody struct {
+	Code   string       `json:"code"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// AsError finds the API error in err's chain. Any other error is reported
+// as ErrInternal so its details do not leak to clients.
+func AsError(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return ErrInternal
+}
+
+// InvalidParam reports a path or query parameter that could not be parsed.
+func InvalidParam(name string) error {
+	return ParamError(FieldError{Field: name, Code: "invalid", Message: name + " is invalid"})
+}
+
+// ParamError reports a path or query parameter that breaks a rule, with
+// the field's message as the error message.
+func ParamError(field FieldError) error {
+	return &Error{
+		Code:    ErrInvalidParameter.Code,
+		Status:  ErrInvalidParameter.Status,
+		Message: field.Message,
+		Fields:  []FieldError{field},
+	}
+}
+
+var (
+	// =============== ERROR REQUEST ===============
+	ErrInternal         = NewError("internal_error", http.StatusInternalServerError, "internal server error")
+	ErrInvalidRequest   = NewError("invalid_request", http.StatusBadRequest, "invalid request")
+	ErrInvalidParameter = NewError("invalid_parameter", http.StatusBadRequest, "invalid parameter")
+	ErrMissingToken     = NewError("missing_token", http.StatusUnauthorized, "missing token")
+	ErrUnauthorized     = NewError("unauthorized", http.StatusUnauthorized, "unauthorized")
+	ErrPermissionDenied = NewError("permission_denied", http.StatusForbidden, "permission denied")
+)
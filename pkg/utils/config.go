@@ -14,7 +14,18 @@ type Configuration struct {
 	DB          DatabaseConfig
 	SMTP SMTPConfig
 	BaseURL string
+	PasswordResetURL string
+	Invitation InvitationConfig
 	BusinessRules BusinessRules
+	Receipt ReceiptConfig
+	Session SessionConfig
+	LoginProtection LoginProtectionConfig
+	TwoFactor TwoFactorConfig
+	Storage StorageConfig
+	// TrustedProxies lists, comma separated, the proxies whose
+	// X-Forwarded-For header names the client. Empty trusts none, so the
+	// client is whoever connected.
+	TrustedProxies string
 }
 
 type DatabaseConfig struct {
@@ -38,6 +49,91 @@ type BusinessRules struct {
 	ProfitMargin int
 	DefaultShiftStart string
 	DefaultShiftEnd string
+	Timezone string
+	LateGraceMinutes int
+	MonthlyWorkHours int
+	OvertimeMultiplier float64
+}
+
+// SessionConfig controls login sessions. The access token expires after
+// IdleMinutes without activity; the refresh token lasts RefreshDays and is
+// renewed on every refresh.
+//
+// Mode "jwt" issues signed access tokens instead of opaque session tokens so
+// requests are authorized without a database lookup. They live
+// JWTAccessMinutes and are signed with JWTActiveKey from JWTKeys, written as
+// "kid:secret,kid:secret".
+type SessionConfig struct {
+	IdleMinutes int
+	RefreshDays int
+	Mode string
+	JWTKeys string
+	JWTActiveKey string
+	JWTAccessMinutes int
+}
+
+// LoginProtectionConfig throttles password guessing. After FreeAttempts
+// failures each further attempt must wait BaseDelaySeconds, doubling per
+// failure up to MaxDelaySeconds. An account is locked for LockoutMinutes
+// after MaxAccountFailures failures, a client address after MaxIPFailures.
+// Failures older than WindowMinutes are forgotten. A reset OTP is
+// invalidated after OTPMaxAttempts wrong codes.
+type LoginProtectionConfig struct {
+	FreeAttempts int
+	BaseDelaySeconds int
+	MaxDelaySeconds int
+	MaxAccountFailures int
+	MaxIPFailures int
+	LockoutMinutes int
+	WindowMinutes int
+	OTPMaxAttempts int
+}
+
+// InvitationConfig controls how new staff are invited. The emailed link
+// points to URL, by default the accept-invitation page of the app, and works
+// for ExpiryHours.
+type InvitationConfig struct {
+	URL string
+	ExpiryHours int
+}
+
+// TwoFactorConfig controls the second login step. Users whose role is in
+// RequiredRoles, comma separated, always need one and fall back to an emailed
+// code until they enrol an authenticator app; "none" requires it of no role.
+// Other users need it once they enrol. Issuer names the account in
+// authenticator apps and a login challenge lasts ChallengeMinutes.
+type TwoFactorConfig struct {
+	RequiredRoles string
+	Issuer string
+	ChallengeMinutes int
+}
+
+// StorageConfig controls where uploaded files are kept. Driver "local"
+// writes them under LocalDir and serves them from the /uploads route; "s3"
+// puts them in Bucket on any S3-compatible Endpoint, addressed path-style so
+// stand-ins such as MinIO work. PublicURL is the address files are linked
+// from and uploads are limited to MaxUploadMB.
+type StorageConfig struct {
+	Driver string
+	LocalDir string
+	PublicURL string
+	MaxUploadMB int
+	S3Endpoint string
+	S3Region string
+	S3Bucket string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// ReceiptConfig is the outlet information printed on every receipt. Header
+// and Footer may hold several lines separated by "|".
+type ReceiptConfig struct {
+	OutletName string
+	Address string
+	Phone string
+	Header string
+	Footer string
+	PaperWidth int
 }
 
 func ReadConfiguration() (Configuration, error) {
@@ -79,11 +175,63 @@ func ReadConfiguration() (Configuration, error) {
 			Password: viper.GetString("SMTP_PASSWORD"),
 		},
 		BaseURL: viper.GetString("APP_URL"),
+		TrustedProxies: viper.GetString("TRUSTED_PROXIES"),
+		PasswordResetURL: viper.GetString("PASSWORD_RESET_URL"),
+		Invitation: InvitationConfig{
+			URL: viper.GetString("INVITATION_URL"),
+			ExpiryHours: viper.GetInt("INVITATION_EXPIRY_HOURS"),
+		},
 		BusinessRules: BusinessRules{
 			TaxRate: viper.GetInt("TAX_RATE"),
 			ProfitMargin: viper.GetInt("PROFIT_MARGIN"),
 			DefaultShiftStart: viper.GetString("DEFAULT_SHIFT_START"),
 			DefaultShiftEnd: viper.GetString("DEFAULT_SHIFT_END"),
+			Timezone: viper.GetString("TIMEZONE"),
+			LateGraceMinutes: viper.GetInt("LATE_GRACE_MINUTES"),
+			MonthlyWorkHours: viper.GetInt("MONTHLY_WORK_HOURS"),
+			OvertimeMultiplier: viper.GetFloat64("OVERTIME_MULTIPLIER"),
+		},
+		Session: SessionConfig{
+			IdleMinutes: viper.GetInt("SESSION_IDLE_MINUTES"),
+			RefreshDays: viper.GetInt("SESSION_REFRESH_DAYS"),
+			Mode: viper.GetString("AUTH_MODE"),
+			JWTKeys: viper.GetString("JWT_KEYS"),
+			JWTActiveKey: viper.GetString("JWT_ACTIVE_KEY"),
+			JWTAccessMinutes: viper.GetInt("JWT_ACCESS_MINUTES"),
+		},
+		LoginProtection: LoginProtectionConfig{
+			FreeAttempts: viper.GetInt("LOGIN_FREE_ATTEMPTS"),
+			BaseDelaySeconds: viper.GetInt("LOGIN_BASE_DELAY_SECONDS"),
+			MaxDelaySeconds: viper.GetInt("LOGIN_MAX_DELAY_SECONDS"),
+			MaxAccountFailures: viper.GetInt("LOGIN_MAX_ACCOUNT_FAILURES"),
+			MaxIPFailures: viper.GetInt("LOGIN_MAX_IP_FAILURES"),
+			LockoutMinutes: viper.GetInt("LOGIN_LOCKOUT_MINUTES"),
+			WindowMinutes: viper.GetInt("LOGIN_WINDOW_MINUTES"),
+			OTPMaxAttempts: viper.GetInt("OTP_MAX_ATTEMPTS"),
+		},
+		TwoFactor: TwoFactorConfig{
+			RequiredRoles: viper.GetString("TWO_FACTOR_REQUIRED_ROLES"),
+			Issuer: viper.GetString("TWO_FACTOR_ISSUER"),
+			ChallengeMinutes: viper.GetInt("TWO_FACTOR_CHALLENGE_MINUTES"),
+		},
+		Storage: StorageConfig{
+			Driver: viper.GetString("STORAGE_DRIVER"),
+			LocalDir: viper.GetString("STORAGE_LOCAL_DIR"),
+			PublicURL: viper.GetString("STORAGE_PUBLIC_URL"),
+			MaxUploadMB: viper.GetInt("UPLOAD_MAX_MB"),
+			S3Endpoint: viper.GetString("S3_ENDPOINT"),
+			S3Region: viper.GetString("S3_REGION"),
+			S3Bucket: viper.GetString("S3_BUCKET"),
+			S3AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			S3SecretKey: viper.GetString("S3_SECRET_KEY"),
+		},
+		Receipt: ReceiptConfig{
+			OutletName: viper.GetString("RECEIPT_OUTLET_NAME"),
+			Address: viper.GetString("RECEIPT_ADDRESS"),
+			Phone: viper.GetString("RECEIPT_PHONE"),
+			Header: viper.GetString("RECEIPT_HEADER"),
+			Footer: viper.GetString("RECEIPT_FOOTER"),
+			PaperWidth: viper.GetInt("RECEIPT_PAPER_WIDTH"),
 		},
 	}, nil
 
@@ -0,0 +1,32 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"time"
+)
+
+func AccountLocked(lockedUntil time.Time, ipAddress string) string {
+	return fmt.Sprintf(`
+	<h2>Your Account Has Been Locked</h2>
+
+	<p>
+	We locked your account after several failed login attempts.
+	The last attempt came from <strong>%v</strong>.
+	</p>
+
+	<p>
+	You can try again after <strong>%v</strong>,
+	or ask an administrator to unlock your account sooner.
+	</p>
+
+	<p>
+	If these attempts were not made by you, please reset your password
+	and contact support.
+	</p>
+
+	<p style="color: #888; font-size: 12px;">
+	⚠️ Do not share your login credentials with anyone.
+	</p>
+	`, html.EscapeString(ipAddress), lockedUntil.Format("02 Jan 2006 15:04 MST"))
+}
@@ -0,0 +1,37 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"project-POS-APP-golang-integer/internal/dto/response"
+)
+
+func Invitation(data response.InvitationEmail) string {
+	return fmt.Sprintf(`
+	<h2>You Have Been Invited</h2>
+
+	<p>
+	An administrator has created a <strong>%v</strong> account for you
+	with the email <strong>%v</strong>.
+	Open the link below to choose your password and complete your profile:
+	</p>
+
+	<p style="margin: 16px 0; text-align: center;">
+		<a href="%s">Accept invitation</a>
+	</p>
+
+	<p>
+	The link works once and expires at <strong>%v</strong>.
+	If it has expired, ask your administrator to send a new one.
+	</p>
+
+	<p>
+	If you did not expect this invitation, please ignore this email.
+	</p>
+
+	<p style="color: #888; font-size: 12px;">
+	⚠️ Do not share this link with anyone.
+	</p>
+	`, html.EscapeString(string(data.Role)), html.EscapeString(data.Email),
+		html.EscapeString(data.Link), data.ExpiresAt.Format("02 Jan 2006 15:04 MST"))
+}
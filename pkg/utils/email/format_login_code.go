@@ -0,0 +1,41 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"time"
+)
+
+func LoginCode(code string, expiresAt time.Time, ipAddress string) string {
+	return fmt.Sprintf(`
+	<h2>Your Login Code</h2>
+
+	<p>
+	Someone signed in to your account with your password from
+	<strong>%v</strong>. Enter the code below to finish logging in:
+	</p>
+
+	<div style="
+		font-size: 26px;
+		font-weight: bold;
+		letter-spacing: 6px;
+		margin: 20px 0;
+		text-align: center;
+	">
+		%v
+	</div>
+
+	<p>
+	This code expires at <strong>%v</strong>.
+	</p>
+
+	<p>
+	If this was not you, your password is known to someone else.
+	Please reset it and contact support.
+	</p>
+
+	<p style="color: #888; font-size: 12px;">
+	⚠️ Do not share this code with anyone.
+	</p>
+	`, html.EscapeString(ipAddress), code, expiresAt.Format("02 Jan 2006 15:04 MST"))
+}
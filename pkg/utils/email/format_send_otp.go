@@ -2,10 +2,11 @@ package email
 
 import (
 	"fmt"
+	"html"
 	"project-POS-APP-golang-integer/internal/dto/response"
 )
 
-func SendOTP(data response.OTPResponse) string {
+func SendOTP(data response.PasswordResetEmail) string {
 	return fmt.Sprintf(`
 	<h2>Password Reset Request</h2>
 
@@ -28,12 +29,21 @@ func SendOTP(data response.OTPResponse) string {
 	This OTP will expire in <strong>10 minutes</strong>.
 	</p>
 
+	<p>
+	Or open the link below to choose a new password directly.
+	It works once and expires in <strong>30 minutes</strong>:
+	</p>
+
+	<p style="margin: 16px 0; text-align: center;">
+		<a href="%s">Reset my password</a>
+	</p>
+
 	<p>
 	If you did not request a password reset, please ignore this email.
 	Your account remains secure.
 	</p>
 
 	<p style="color: #888; font-size: 12px;">
-	⚠️ Do not share this OTP with anyone.
-	</p>`, data.OTPCode)
-}
\ No newline at end of file
+	⚠️ Do not share this OTP or link with anyone.
+	</p>`, data.OTPCode, html.EscapeString(data.ResetLink))
+}
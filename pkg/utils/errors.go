@@ -1,85 +1,161 @@
 package utils
 
-import "errors"
+import "net/http"
 
+// Every error below is reported to clients under its code, which stays the
+// same across releases; the message is only a default for display.
 var (
 	// =============== ERROR AUTH ===============
-	ErrUserNotFound = errors.New("user not found")
-	ErrInvalidOTP = errors.New("invalid OTP")
-	ErrInvalidToken = errors.New("invalid token")
+	ErrUserNotFound = NewError("user_not_found", http.StatusNotFound, "user not found")
+	ErrInvalidOTP   = NewError("invalid_otp", http.StatusBadRequest, "invalid OTP")
+	ErrInvalidToken = NewError("invalid_token", http.StatusUnauthorized, "invalid token")
+
+	// =============== ERROR USER MANAGEMENT ===============
+	ErrCannotManageUser = NewError("cannot_manage_user", http.StatusForbidden, "you cannot manage users with this role")
+	ErrCannotModifySelf = NewError("cannot_modify_self", http.StatusForbidden, "you cannot manage your own account")
+	ErrLastSuperAdmin   = NewError("last_superadmin", http.StatusConflict, "cannot remove the last superadmin")
+	ErrSalaryInFuture   = NewError("salary_in_future", http.StatusBadRequest, "salary effective date cannot be in the future")
+
+	// =============== ERROR INVITATION ===============
+	ErrInvalidInvitation   = NewError("invalid_invitation", http.StatusBadRequest, "invalid or expired invitation")
+	ErrNoPendingInvitation = NewError("no_pending_invitation", http.StatusConflict, "user has already joined or was never invited")
+
+	// =============== ERROR LOGIN PROTECTION ===============
+	ErrInvalidCredentials   = NewError("invalid_credentials", http.StatusUnauthorized, "invalid email or password")
+	ErrTooManyLoginAttempts = NewError("too_many_login_attempts", http.StatusTooManyRequests, "too many failed login attempts, try again later")
+	ErrTooManyEmailRequests = NewError("too_many_email_requests", http.StatusTooManyRequests, "too many emails requested, try again later")
+	ErrAccountLocked        = NewError("account_locked", http.StatusLocked, "account is temporarily locked after too many failed logins")
+	ErrOTPAttemptsExceeded  = NewError("otp_attempts_exceeded", http.StatusTooManyRequests, "too many wrong codes, request a new OTP")
+
+	// =============== ERROR TWO FACTOR ===============
+	ErrInvalidLoginChallenge   = NewError("invalid_login_challenge", http.StatusUnauthorized, "invalid or expired login challenge")
+	ErrInvalidTwoFactorCode    = NewError("invalid_two_factor_code", http.StatusUnauthorized, "invalid two-factor code")
+	ErrLoginChallengeExceeded  = NewError("login_challenge_exceeded", http.StatusTooManyRequests, "too many wrong codes, log in again")
+	ErrLoginCodeTooSoon        = NewError("login_code_too_soon", http.StatusTooManyRequests, "a code was sent recently, try again in a minute")
+	ErrTwoFactorAlreadyEnabled = NewError("two_factor_already_enabled", http.StatusConflict, "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = NewError("two_factor_not_enabled", http.StatusConflict, "two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp       = NewError("two_factor_not_set_up", http.StatusConflict, "start two-factor setup first")
+
+	// =============== ERROR UPLOAD ===============
+	ErrFileTooLarge        = NewError("file_too_large", http.StatusRequestEntityTooLarge, "file is too large")
+	ErrUnsupportedFileType = NewError("unsupported_file_type", http.StatusUnsupportedMediaType, "only JPEG, PNG and GIF images are allowed")
+	ErrInvalidImage        = NewError("invalid_image", http.StatusBadRequest, "file is not a valid image")
+	ErrImageTooLarge       = NewError("image_too_large", http.StatusRequestEntityTooLarge, "image dimensions are too large")
+	ErrStoredImageURL      = NewError("stored_image_url", http.StatusBadRequest, "images in file storage can only be set by uploading")
+
+	// =============== ERROR PERMISSION ===============
+	ErrRoleNotFound        = NewError("role_not_found", http.StatusNotFound, "role not found")
+	ErrRoleExists          = NewError("role_exists", http.StatusConflict, "role name already exists")
+	ErrRoleNotEditable     = NewError("role_not_editable", http.StatusBadRequest, "superadmin permissions cannot be changed")
+	ErrSystemRole          = NewError("system_role", http.StatusBadRequest, "built-in roles cannot be deleted")
+	ErrRoleInUse           = NewError("role_in_use", http.StatusConflict, "cannot delete role assigned to users")
+	ErrUnknownPermission   = NewError("unknown_permission", http.StatusBadRequest, "unknown permission")
+	ErrDuplicatePermission = NewError("duplicate_permission", http.StatusBadRequest, "permission listed more than once")
+	ErrSuperAdminOnly      = NewError("superadmin_only", http.StatusForbidden, "only a superadmin can change permission mappings")
+
+	// =============== ERROR MANAGER OVERRIDE ===============
+	ErrManagerApprovalRequired = NewError("manager_approval_required", http.StatusForbidden, "manager approval required")
+	ErrInvalidManagerPIN       = NewError("invalid_manager_pin", http.StatusForbidden, "invalid manager or PIN")
+	ErrManagerPINLocked        = NewError("manager_pin_locked", http.StatusLocked, "manager PIN is locked after repeated failures")
+	ErrManagerPINNotAllowed    = NewError("manager_pin_not_allowed", http.StatusForbidden, "only users who can approve overrides may set a PIN")
+
+	// =============== ERROR SESSION ===============
+	ErrSessionNotFound     = NewError("session_not_found", http.StatusNotFound, "session not found")
+	ErrInvalidRefreshToken = NewError("invalid_refresh_token", http.StatusUnauthorized, "invalid or expired refresh token")
+	ErrCannotRevokeCurrent = NewError("cannot_revoke_current", http.StatusBadRequest, "use logout to end the current session")
 
 	// =============== ERROR RESERVATION ===============
-	ErrReservationNotFound     = errors.New("reservation not found")
-	ErrTableNotFound           = errors.New("table not found")
-	ErrTableUnavailable        = errors.New("table is not available at the requested time")
-	ErrInsufficientCapacity    = errors.New("table capacity is insufficient")
-	ErrInvalidReservationTime  = errors.New("reservation time must be at least 1 hour from now")
-	ErrInvalidStatusTransition = errors.New("invalid status transition")
-	ErrInvalidStatus           = errors.New("invalid reservation status")
-	ErrValidationFailed        = errors.New("validation failed")
-	ErrInvalidDateFormat       = errors.New("invalid date format")
-	ErrInvalidTimeFormat       = errors.New("invalid time format")
+	ErrReservationNotFound     = NewError("reservation_not_found", http.StatusNotFound, "reservation not found")
+	ErrTableNotFound           = NewError("table_not_found", http.StatusNotFound, "table not found")
+	ErrTableUnavailable        = NewError("table_unavailable", http.StatusConflict, "table is not available at the requested time")
+	ErrInsufficientCapacity    = NewError("insufficient_capacity", http.StatusBadRequest, "table capacity is insufficient")
+	ErrInvalidReservationTime  = NewError("invalid_reservation_time", http.StatusBadRequest, "reservation time must be at least 1 hour from now")
+	ErrInvalidStatusTransition = NewError("invalid_status_transition", http.StatusBadRequest, "invalid status transition")
+	ErrInvalidStatus           = NewError("invalid_status", http.StatusBadRequest, "invalid reservation status")
+	ErrValidationFailed        = NewError("validation_failed", http.StatusBadRequest, "validation failed")
+	ErrInvalidDateFormat       = NewError("invalid_date_format", http.StatusBadRequest, "invalid date format")
+	ErrInvalidTimeFormat       = NewError("invalid_time_format", http.StatusBadRequest, "invalid time format")
+	ErrInvalidDateRange        = NewError("invalid_date_range", http.StatusBadRequest, "start date must not be after end date")
 
 	// Customer errors
-	ErrCustomerNotFound      = errors.New("customer not found")
-	ErrCustomerAlreadyExists = errors.New("customer already exists")
+	ErrCustomerNotFound      = NewError("customer_not_found", http.StatusNotFound, "customer not found")
+	ErrCustomerAlreadyExists = NewError("customer_already_exists", http.StatusConflict, "customer already exists")
 
 	// =============== ERROR CATEGORY ===============
-	ErrCategoryNotFound    = errors.New("category not found")
-	ErrCategoryExists      = errors.New("category name already exists")
-	ErrCategoryHasProducts = errors.New("cannot delete category with associated products")
-	ErrInvalidCategoryName = errors.New("category name is invalid")
-	ErrCategoryInactive    = errors.New("category is inactive")
-	ErrNoChangesProvided   = errors.New("no changes provided") // 🔥 Optional
+	ErrCategoryNotFound    = NewError("category_not_found", http.StatusNotFound, "category not found")
+	ErrCategoryExists      = NewError("category_exists", http.StatusConflict, "category name already exists")
+	ErrCategoryHasProducts = NewError("category_has_products", http.StatusConflict, "cannot delete category with associated products")
+	ErrInvalidCategoryName = NewError("invalid_category_name", http.StatusBadRequest, "category name is invalid")
+	ErrCategoryInactive    = NewError("category_inactive", http.StatusBadRequest, "category is inactive")
+	ErrNoChangesProvided   = NewError("no_changes_provided", http.StatusBadRequest, "no changes provided") // 🔥 Optional
 
 	// =============== ERROR PRODUCT ===============
-	ErrProductNotFound   = errors.New("product not found")
-	ErrProductExists     = errors.New("product name already exists in category")
-	ErrProductHasOrders  = errors.New("cannot delete product with associated orders")
-	ErrProductInactive   = errors.New("product is inactive")
-	ErrProductOutOfStock = errors.New("product is out of stock")
-	ErrInsufficientStock = errors.New("insufficient stock")
-)
+	ErrProductNotFound   = NewError("product_not_found", http.StatusNotFound, "product not found")
+	ErrProductExists     = NewError("product_exists", http.StatusConflict, "product name already exists in category")
+	ErrProductHasOrders  = NewError("product_has_orders", http.StatusConflict, "cannot delete product with associated orders")
+	ErrProductInactive   = NewError("product_inactive", http.StatusBadRequest, "product is inactive")
+	ErrProductOutOfStock = NewError("product_out_of_stock", http.StatusBadRequest, "product is out of stock")
+	ErrInsufficientStock = NewError("insufficient_stock", http.StatusBadRequest, "insufficient stock")
+
+	// =============== ERROR MODIFIER ===============
+	ErrModifierGroupNotFound    = NewError("modifier_group_not_found", http.StatusNotFound, "modifier group not found")
+	ErrModifierOptionNotFound   = NewError("modifier_option_not_found", http.StatusNotFound, "modifier option not found")
+	ErrInvalidModifierRange     = NewError("invalid_modifier_range", http.StatusBadRequest, "min_select cannot be greater than max_select")
+	ErrInvalidModifierSelection = NewError("invalid_modifier_selection", http.StatusBadRequest, "invalid modifier selection")
 
-// Helper untuk check business error
-func IsBusinessError(err error) bool {
-	businessErrors := []error{
-		// Reservation errors
-		ErrReservationNotFound,
-		ErrTableNotFound,
-		ErrTableUnavailable,
-		ErrInsufficientCapacity,
-		ErrInvalidReservationTime,
-		ErrInvalidStatus,
-		ErrInvalidStatusTransition,
-		ErrValidationFailed,
-		ErrInvalidDateFormat,
-		ErrInvalidTimeFormat,
-
-		// Customer errors
-		ErrCustomerNotFound,
-		ErrCustomerAlreadyExists,
-
-		// Category errors
-		ErrCategoryNotFound,
-		ErrCategoryExists,
-		ErrCategoryHasProducts,
-		ErrInvalidCategoryName,
-		ErrCategoryInactive,
-		ErrNoChangesProvided, // 🔥
-		// Product errors
-		ErrProductNotFound,
-		ErrProductExists,
-		ErrProductHasOrders,
-		ErrProductInactive,
-		ErrProductOutOfStock,
-		ErrInsufficientStock,
-	}
-
-	for _, businessErr := range businessErrors {
-		if errors.Is(err, businessErr) {
-			return true
-		}
-	}
-	return false
-}
+	// =============== ERROR ORDER ===============
+	ErrOrderNotFound       = NewError("order_not_found", http.StatusNotFound, "order not found")
+	ErrOrderNotEditable    = NewError("order_not_editable", http.StatusConflict, "order can no longer be modified")
+	ErrOrderNotCompleted   = NewError("order_not_completed", http.StatusConflict, "receipt is only available for completed orders")
+	ErrCustomerNoEmail     = NewError("customer_no_email", http.StatusBadRequest, "customer has no email address")
+	ErrInvalidDiscount     = NewError("invalid_discount", http.StatusBadRequest, "discount exceeds order subtotal")
+	ErrOrderNotRefundable  = NewError("order_not_refundable", http.StatusConflict, "only completed orders can be refunded")
+	ErrRefundExceedsPaid   = NewError("refund_exceeds_paid", http.StatusBadRequest, "refund exceeds amount paid")
+	ErrRefundMethodNotUsed = NewError("refund_method_not_used", http.StatusBadRequest, "refund must go to a payment method used on the order")
+
+	// =============== ERROR KITCHEN ===============
+	ErrOrderItemNotFound      = NewError("order_item_not_found", http.StatusNotFound, "order item not found")
+	ErrOrderItemAlreadyBumped = NewError("order_item_already_bumped", http.StatusConflict, "order item is already bumped")
+	ErrOrderItemNotBumped     = NewError("order_item_not_bumped", http.StatusConflict, "order item has not been bumped")
+
+	// =============== ERROR INGREDIENT ===============
+	ErrIngredientNotFound  = NewError("ingredient_not_found", http.StatusNotFound, "ingredient not found")
+	ErrIngredientExists    = NewError("ingredient_exists", http.StatusConflict, "ingredient name already exists")
+	ErrDuplicateRecipeItem = NewError("duplicate_recipe_item", http.StatusBadRequest, "ingredient listed more than once in recipe")
+	ErrInvalidRestock      = NewError("invalid_restock", http.StatusBadRequest, "restock quantity must be greater than zero")
+
+	// =============== ERROR PURCHASING ===============
+	ErrSupplierNotFound          = NewError("supplier_not_found", http.StatusNotFound, "supplier not found")
+	ErrSupplierInactive          = NewError("supplier_inactive", http.StatusBadRequest, "supplier is inactive")
+	ErrSupplierHasPurchaseOrders = NewError("supplier_has_purchase_orders", http.StatusConflict, "cannot delete supplier with purchase orders")
+	ErrPurchaseOrderNotFound     = NewError("purchase_order_not_found", http.StatusNotFound, "purchase order not found")
+	ErrPurchaseOrderNotEditable  = NewError("purchase_order_not_editable", http.StatusConflict, "only draft purchase orders can be edited")
+	ErrPurchaseOrderItemNotFound = NewError("purchase_order_item_not_found", http.StatusNotFound, "purchase order item not found")
+	ErrReceiveQuantityExceeded   = NewError("receive_quantity_exceeded", http.StatusBadRequest, "received quantity exceeds remaining quantity")
+
+	// =============== ERROR STOCK TAKE ===============
+	ErrStockTakeNotFound = NewError("stock_take_not_found", http.StatusNotFound, "stock take not found")
+	ErrStockTakeNotOpen  = NewError("stock_take_not_open", http.StatusConflict, "stock take is no longer open")
+	ErrStockTakeEmpty    = NewError("stock_take_empty", http.StatusBadRequest, "stock take has no counted products")
+
+	// =============== ERROR SHIFT ===============
+	ErrProfileNotFound = NewError("profile_not_found", http.StatusNotFound, "profile not found")
+	ErrShiftNotFound   = NewError("shift_not_found", http.StatusNotFound, "shift not found")
+	ErrShiftOverlap    = NewError("shift_overlap", http.StatusConflict, "shift overlaps an existing shift")
+
+	// =============== ERROR ATTENDANCE ===============
+	ErrAttendanceNotFound = NewError("attendance_not_found", http.StatusNotFound, "attendance not found")
+	ErrAlreadyClockedIn   = NewError("already_clocked_in", http.StatusConflict, "already clocked in")
+	ErrNotClockedIn       = NewError("not_clocked_in", http.StatusConflict, "not clocked in")
+	ErrInvalidClockOut    = NewError("invalid_clock_out", http.StatusBadRequest, "clock out must be after clock in")
+
+	// =============== ERROR CASH DRAWER ===============
+	ErrCashDrawerNotFound     = NewError("cash_drawer_not_found", http.StatusNotFound, "cash drawer session not found")
+	ErrCashDrawerAlreadyOpen  = NewError("cash_drawer_already_open", http.StatusConflict, "cashier already has an open cash drawer")
+	ErrCashDrawerNotOpen      = NewError("cash_drawer_not_open", http.StatusConflict, "cash drawer session is not open")
+	ErrCashDrawerNotOwner     = NewError("cash_drawer_not_owner", http.StatusForbidden, "cash drawer belongs to another cashier")
+	ErrInsufficientDrawerCash = NewError("insufficient_drawer_cash", http.StatusBadRequest, "pay out exceeds cash in drawer")
+
+	// =============== ERROR REPORT ===============
+	ErrInvalidTimezone = NewError("invalid_timezone", http.StatusBadRequest, "invalid timezone")
+)
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+
+	// exportFlushEvery is how many rows are buffered before flushing to the client.
+	exportFlushEvery = 200
+)
+
+// ExportWriter writes a spreadsheet one row at a time. Numbers are written as
+// numeric cells, times as RFC3339 text and everything else as text.
+type ExportWriter interface {
+	WriteRow(values ...any) error
+	Close() error
+}
+
+// IsExportFormat reports whether format asks for a file instead of JSON.
+func IsExportFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatXLSX
+}
+
+func ExportContentType(format string) string {
+	if format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// NewExportWriter returns a writer for format that streams to w. When w is an
+// http.Flusher rows are pushed to the client as they are written.
+func NewExportWriter(w io.Writer, format string) (ExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return &csvExportWriter{w: csv.NewWriter(w), flusher: asFlusher(w)}, nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func asFlusher(w io.Writer) http.Flusher {
+	f, _ := w.(http.Flusher)
+	return f
+}
+
+func exportText(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Format(time.RFC3339)
+	case *time.Time:
+		if x == nil {
+			return ""
+		}
+		return exportText(*x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func isNumeric(v any) bool {
+	switch v.(type) {
+	case int, int32, int64, uint, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// =============== CSV ===============
+
+type csvExportWriter struct {
+	w       *csv.Writer
+	flusher http.Flusher
+	rows    int
+}
+
+func (e *csvExportWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = exportText(v)
+		if !isNumeric(v) {
+			record[i] = csvSafe(record[i])
+		}
+	}
+	if err := e.w.Write(record); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushEvery == 0 {
+		e.w.Flush()
+		if e.flusher != nil {
+			e.flusher.Flush()
+		}
+	}
+	return e.w.Error()
+}
+
+// csvSafe prefixes text that a spreadsheet would read as a formula with a
+// quote, so a product or customer name cannot run code when the file is opened.
+func csvSafe(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// =============== XLSX ===============
+
+// xlsxExportWriter writes a single-sheet workbook. The sheet is streamed into
+// the zip archive with inline strings, so no row is kept in memory.
+type xlsxExportWriter struct {
+	zw      *zip.Writer
+	sheet   *bufio.Writer
+	flusher http.Flusher
+	rows    int
+}
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	zw := zip.NewWriter(w)
+
+	for _, part := range xlsxStaticParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return &xlsxExportWriter{zw: zw, sheet: sheet, flusher: asFlusher(w)}, nil
+}
+
+func (e *xlsxExportWriter) WriteRow(values ...any) error {
+	e.rows++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.rows)
+	for i, v := range values {
+		ref := xlsxColumn(i) + strconv.Itoa(e.rows)
+		if isNumeric(v) {
+			fmt.Fprintf(e.sheet, `<c r="%s"><v>%s</v></c>`, ref, exportText(v))
+			continue
+		}
+
+		text := exportText(v)
+		if text == "" {
+			continue
+		}
+		fmt.Fprintf(e.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		xml.EscapeText(e.sheet, []byte(text))
+		e.sheet.WriteString(`</t></is></c>`)
+	}
+	if _, err := e.sheet.WriteString(`</row>`); err != nil {
+		return err
+	}
+
+	if e.rows%exportFlushEvery == 0 {
+		if err := e.sheet.Flush(); err != nil {
+			return err
+		}
+		if err := e.zw.Flush(); err != nil {
+			return err
+		}
+		if e.flusher != nil {
+			e.flusher.Flush()
+		}
+	}
+	return nil
+}
+
+func (e *xlsxExportWriter) Close() error {
+	e.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// xlsxColumn converts a zero-based column index to its letter name (0 -> A, 26 -> AA).
+func xlsxColumn(i int) string {
+	var b strings.Builder
+	for i >= 0 {
+		b.WriteByte(byte('A' + i%26))
+		i = i/26 - 1
+	}
+	s := []byte(b.String())
+	for l, r := 0, len(s)-1; l < r; l, r = l+1, r-1 {
+		s[l], s[r] = s[r], s[l]
+	}
+	return string(s)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVExportWriter_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewExportWriter(&buf, ExportFormatCSV)
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow("=SUM(A1:A9)", "+1", "-cmd", "@here", "plain", -5, 2.5))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"'=SUM(A1:A9)", "'+1", "'-cmd", "'@here", "plain", "-5", "2.5"}}, records)
+}
+
+func TestCSVSafe(t *testing.T) {
+	require.Equal(t, "", csvSafe(""))
+	require.Equal(t, "'\tx", csvSafe("\tx"))
+	require.Equal(t, "a=b", csvSafe("a=b"))
+}
+
+func TestExportText(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	require.Equal(t, "", exportText(nil))
+	require.Equal(t, "", exportText(time.Time{}))
+	require.Equal(t, "", exportText((*time.Time)(nil)))
+	require.Equal(t, "2026-03-01T09:30:00Z", exportText(&at))
+	require.Equal(t, "1.25", exportText(1.25))
+	require.Equal(t, "7", exportText(uint(7)))
+}
+
+func TestIsNumeric(t *testing.T) {
+	require.True(t, isNumeric(-5))
+	require.True(t, isNumeric(uint(3)))
+	require.True(t, isNumeric(1.5))
+	require.False(t, isNumeric("5"))
+	require.False(t, isNumeric(nil))
+}
+
+func TestXLSXColumn(t *testing.T) {
+	require.Equal(t, "A", xlsxColumn(0))
+	require.Equal(t, "Z", xlsxColumn(25))
+	require.Equal(t, "AA", xlsxColumn(26))
+	require.Equal(t, "ZZ", xlsxColumn(701))
+}
+
+func TestXLSXExportWriter_WritesWellFormedSheet(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewExportWriter(&buf, ExportFormatXLSX)
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow("name", "qty"))
+	require.NoError(t, w.WriteRow("Tea & <Cake>", 3))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	var sheet []byte
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		require.NoError(t, err)
+		sheet, err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+	require.NotEmpty(t, sheet)
+
+	var doc struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				Ref   string `xml:"r,attr"`
+				Type  string `xml:"t,attr"`
+				Value string `xml:"v"`
+				Text  string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &doc))
+	require.Len(t, doc.Rows, 2)
+	require.Equal(t, "A2", doc.Rows[1].Cells[0].Ref)
+	require.Equal(t, "Tea & <Cake>", doc.Rows[1].Cells[0].Text)
+	require.Equal(t, "", doc.Rows[1].Cells[1].Type)
+	require.Equal(t, "3", doc.Rows[1].Cells[1].Value)
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const jpegQuality = 85
+
+// ResizeToFit scales img down so neither side exceeds max, keeping its
+// aspect ratio. Each output pixel averages the source pixels it covers, so
+// thumbnails stay smooth. Images that already fit are returned as they are.
+func ResizeToFit(img image.Image, max int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= max && h <= max {
+		return img
+	}
+
+	dw, dh := max, h*max/w
+	if h > w {
+		dw, dh = w*max/h, max
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	// Work on RGBA so pixels can be read without per-pixel conversions
+	src := image.NewNRGBA(b)
+	draw.Draw(src, b, img, b.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*h/dh, (y+1)*h/dh
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*w/dw, (x+1)*w/dw
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(b.Min.X+x0, b.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					pa := uint64(src.Pix[i+3])
+					// Weight by alpha so transparent pixels do not darken edges
+					r += uint64(src.Pix[i]) * pa
+					g += uint64(src.Pix[i+1]) * pa
+					bl += uint64(src.Pix[i+2]) * pa
+					a += pa
+					n++
+					i += 4
+				}
+			}
+
+			c := color.NRGBA{}
+			if a > 0 {
+				c = color.NRGBA{R: uint8(r / a), G: uint8(g / a), B: uint8(bl / a), A: uint8(a / n)}
+			}
+			dst.SetNRGBA(x, y, c)
+		}
+	}
+	return dst
+}
+
+// EncodeImage writes img as PNG when it has to keep transparency and as
+// JPEG otherwise. It returns the bytes, their content type and the file
+// extension to store them under.
+func EncodeImage(img image.Image, keepAlpha bool) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+	if keepAlpha {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/png", ".png", nil
+	}
+
+	// JPEG has no alpha; flatten onto white rather than black
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), "image/jpeg", ".jpg", nil
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrJWTMalformed  = errors.New("malformed token")
+	ErrJWTSignature  = errors.New("invalid token signature")
+	ErrJWTExpired    = errors.New("token expired")
+	ErrJWTUnknownKey = errors.New("unknown signing key")
+	ErrJWTNoKey      = errors.New("jwt signing key is not configured")
+	ErrJWTWeakKey    = errors.New("jwt signing key must be at least 32 bytes and not the example value")
+)
+
+// MinJWTKeyBytes is the shortest HS256 key accepted.
+const MinJWTKeyBytes = 32
+
+// jwtPlaceholderKey is the example key shipped in .env.
+const jwtPlaceholderKey = "change-me-to-a-long-random-secret"
+
+// JWTClaims are the claims carried by an access token.
+type JWTClaims struct {
+	Subject   uint   `json:"sub"`
+	Role      string `json:"role"`
+	SessionID uint   `json:"sid"`
+	ID        string `json:"jti"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// JWTKeySet holds the HMAC keys tokens may be signed with. New tokens are
+// signed with ActiveKID; any key in the set still verifies, so a key can be
+// rotated out once the tokens it signed have expired.
+type JWTKeySet struct {
+	ActiveKID string
+	Keys      map[string][]byte
+}
+
+// ParseJWTKeySet reads keys written as "kid:secret,kid:secret".
+func ParseJWTKeySet(keys, activeKID string) JWTKeySet {
+	set := JWTKeySet{ActiveKID: activeKID, Keys: map[string][]byte{}}
+	for _, pair := range strings.Split(keys, ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			continue
+		}
+		set.Keys[kid] = []byte(secret)
+	}
+	return set
+}
+
+// CheckActiveKey makes sure the active key exists and is fit to sign with.
+func (k JWTKeySet) CheckActiveKey() error {
+	secret, ok := k.Keys[k.ActiveKID]
+	if !ok {
+		return ErrJWTNoKey
+	}
+	if len(secret) < MinJWTKeyBytes || string(secret) == jwtPlaceholderKey {
+		return ErrJWTWeakKey
+	}
+	return nil
+}
+
+// SignJWT returns claims as an HS256 token signed with the active key.
+func SignJWT(claims JWTClaims, keys JWTKeySet) (string, error) {
+	secret, ok := keys.Keys[keys.ActiveKID]
+	if !ok {
+		return "", ErrJWTNoKey
+	}
+
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT", Kid: keys.ActiveKID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + jwtSignature(unsigned, secret), nil
+}
+
+// ParseJWT verifies a token's signature and expiry and returns its claims.
+func ParseJWT(token string, keys JWTKeySet, now time.Time) (*JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrJWTMalformed
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, ErrJWTMalformed
+	}
+	if header.Alg != "HS256" {
+		return nil, ErrJWTMalformed
+	}
+
+	secret, ok := keys.Keys[header.Kid]
+	if !ok {
+		return nil, ErrJWTUnknownKey
+	}
+	expected := jwtSignature(parts[0]+"."+parts[1], secret)
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrJWTSignature
+	}
+
+	var claims JWTClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, ErrJWTMalformed
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrJWTExpired
+	}
+
+	return &claims, nil
+}
+
+func jwtSignature(unsigned string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func decodeJWTPart(part string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
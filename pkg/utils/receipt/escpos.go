@@ -0,0 +1,62 @@
+package receipt
+
+import (
+	"bytes"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"strings"
+)
+
+// ESC/POS command bytes understood by common 58mm and 80mm thermal printers.
+var (
+	escInit         = []byte{0x1B, 0x40}       // ESC @
+	escCodePage437  = []byte{0x1B, 0x74, 0x00} // ESC t 0
+	escBoldOn       = []byte{0x1B, 0x45, 0x01} // ESC E 1
+	escBoldOff      = []byte{0x1B, 0x45, 0x00} // ESC E 0
+	escDoubleHeight = []byte{0x1D, 0x21, 0x01} // GS ! 1
+	escNormalSize   = []byte{0x1D, 0x21, 0x00} // GS ! 0
+	escFeedLines    = []byte{0x1B, 0x64, 0x04} // ESC d 4
+	escPartialCut   = []byte{0x1D, 0x56, 0x01} // GS V 1
+)
+
+// ESCPOS renders the receipt as raw bytes for a thermal printer of the given
+// paper width. The outlet name is printed tall and the total in bold.
+func ESCPOS(r response.ReceiptResponse, paperWidth int) []byte {
+	var buf bytes.Buffer
+	buf.Write(escInit)
+	buf.Write(escCodePage437)
+
+	for i, line := range Lines(r, Columns(paperWidth)) {
+		title := i == 0
+		bold := title || strings.HasPrefix(line, "TOTAL")
+
+		if bold {
+			buf.Write(escBoldOn)
+		}
+		if title {
+			buf.Write(escDoubleHeight)
+		}
+		buf.WriteString(printable(line))
+		buf.WriteByte('\n')
+		if title {
+			buf.Write(escNormalSize)
+		}
+		if bold {
+			buf.Write(escBoldOff)
+		}
+	}
+
+	buf.Write(escFeedLines)
+	buf.Write(escPartialCut)
+	return buf.Bytes()
+}
+
+// printable replaces anything outside printable ASCII, which the printer
+// would otherwise misread as a command or print from the wrong code page.
+func printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return '?'
+		}
+		return r
+	}, s)
+}
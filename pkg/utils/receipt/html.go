@@ -0,0 +1,65 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+	"project-POS-APP-golang-integer/internal/dto/response"
+)
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount": Amount,
+	"date":   func(r response.ReceiptResponse) string { return r.IssuedAt.Format("02/01/2006 15:04") },
+	"tax":    trimZeros,
+}).Parse(`<div style="max-width: 420px; margin: 0 auto; font-family: Arial, sans-serif; font-size: 14px; color: #222;">
+	<div style="text-align: center;">
+		<h2 style="margin: 0;">{{.OutletName}}</h2>
+		{{if .Address}}<div>{{.Address}}</div>{{end}}
+		{{if .Phone}}<div>{{.Phone}}</div>{{end}}
+		{{range .Header}}<div>{{.}}</div>{{end}}
+	</div>
+	<hr>
+	<table style="width: 100%;">
+		<tr><td>Order</td><td style="text-align: right;">{{.OrderNumber}}</td></tr>
+		<tr><td>Date</td><td style="text-align: right;">{{date .}}</td></tr>
+		{{if .TableNumber}}<tr><td>Table</td><td style="text-align: right;">{{.TableNumber}}</td></tr>{{end}}
+		{{if .CustomerName}}<tr><td>Customer</td><td style="text-align: right;">{{.CustomerName}}</td></tr>{{end}}
+	</table>
+	<hr>
+	<table style="width: 100%;">
+		{{range .Items}}
+		<tr>
+			<td>
+				{{.Name}}<br>
+				<span style="color: #666;">{{.Quantity}} x {{amount .UnitPrice}}</span>
+				{{range .Modifiers}}<br><span style="color: #666;">+ {{.}}</span>{{end}}
+				{{if .Notes}}<br><span style="color: #666;">* {{.Notes}}</span>{{end}}
+			</td>
+			<td style="text-align: right; vertical-align: top;">{{amount .Total}}</td>
+		</tr>
+		{{end}}
+	</table>
+	<hr>
+	<table style="width: 100%;">
+		<tr><td>Subtotal</td><td style="text-align: right;">{{amount .Subtotal}}</td></tr>
+		{{if gt .Discount 0.0}}<tr><td>Discount</td><td style="text-align: right;">-{{amount .Discount}}</td></tr>{{end}}
+		<tr><td>Tax ({{tax .TaxPercentage}}%)</td><td style="text-align: right;">{{amount .TaxAmount}}</td></tr>
+		<tr><td><strong>TOTAL</strong></td><td style="text-align: right;"><strong>{{amount .Total}}</strong></td></tr>
+	</table>
+	<hr>
+	<table style="width: 100%;">
+		{{range .Payments}}<tr><td>{{.Method}}{{if ne .Type "payment"}} ({{.Type}}){{end}}</td><td style="text-align: right;">{{amount .Amount}}</td></tr>{{end}}
+		<tr><td>Paid</td><td style="text-align: right;">{{amount .Paid}}</td></tr>
+		{{if gt .Refunded 0.0}}<tr><td>Refunded</td><td style="text-align: right;">{{amount .Refunded}}</td></tr>{{end}}
+		<tr><td>Change</td><td style="text-align: right;">{{amount .Change}}</td></tr>
+	</table>
+	{{if .Footer}}<hr><div style="text-align: center;">{{range .Footer}}<div>{{.}}</div>{{end}}</div>{{end}}
+</div>`))
+
+// HTML renders the receipt as an HTML fragment suitable for an email body.
+func HTML(r response.ReceiptResponse) (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
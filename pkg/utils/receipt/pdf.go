@@ -0,0 +1,67 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"strings"
+)
+
+// The PDF is a single page as wide as an 80mm roll, printed in the built-in
+// Courier font so it shows exactly the same lines as the thermal receipt.
+const (
+	pdfPageWidth = 226.77 // 80mm in points
+	pdfFontSize  = 9.0
+	pdfLeading   = 11.0
+	pdfMargin    = 12.0
+	pdfColumns   = 37 // Courier is 0.6em wide: (226.77 - 2*12) / 5.4
+	pdfMinHeight = 200.0
+)
+
+// PDF renders the receipt as a one page PDF document.
+func PDF(r response.ReceiptResponse) []byte {
+	lines := Lines(r, pdfColumns)
+	height := 2*pdfMargin + float64(len(lines))*pdfLeading
+	if height < pdfMinHeight {
+		height = pdfMinHeight
+	}
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %.0f Tf\n%.0f TL\n%.2f %.2f Td\n", pdfFontSize, pdfLeading, pdfMargin, height-pdfMargin-pdfFontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", pdfPageWidth, height),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+// pdfEscape escapes a line for a PDF string literal. Characters the
+// standard font cannot show are replaced.
+func pdfEscape(s string) string {
+	s = printable(s)
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
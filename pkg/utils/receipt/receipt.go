@@ -0,0 +1,197 @@
+package receipt
+
+import (
+	"fmt"
+	"math"
+	"project-POS-APP-golang-integer/internal/dto/response"
+	"strings"
+	"unicode/utf8"
+)
+
+// Paper widths supported by the thermal printers and the number of
+// characters that fit on one line in the printer's default font.
+const (
+	Paper58mm = 58
+	Paper80mm = 80
+)
+
+// Columns returns the characters per line for a paper width, defaulting to 80mm.
+func Columns(paperWidth int) int {
+	if paperWidth == Paper58mm {
+		return 32
+	}
+	return 48
+}
+
+// Lines lays the receipt out as plain text, cols characters wide. The thermal
+// and PDF renderers both print these lines so the two always match.
+func Lines(r response.ReceiptResponse, cols int) []string {
+	var lines []string
+	add := func(l ...string) { lines = append(lines, l...) }
+	rule := strings.Repeat("-", cols)
+
+	add(center(r.OutletName, cols))
+	for _, l := range []string{r.Address, r.Phone} {
+		if l != "" {
+			add(wrapCenter(l, cols)...)
+		}
+	}
+	for _, l := range r.Header {
+		add(wrapCenter(l, cols)...)
+	}
+	add(rule)
+
+	add(columns("Order", r.OrderNumber, cols))
+	add(columns("Date", r.IssuedAt.Format("02/01/2006 15:04"), cols))
+	if r.TableNumber != "" {
+		add(columns("Table", r.TableNumber, cols))
+	}
+	if r.CustomerName != "" {
+		add(columns("Customer", r.CustomerName, cols))
+	}
+	add(rule)
+
+	for _, item := range r.Items {
+		add(wrap(item.Name, cols)...)
+		add(columns(fmt.Sprintf("  %d x %s", item.Quantity, Amount(item.UnitPrice)), Amount(item.Total), cols))
+		for _, m := range item.Modifiers {
+			add(hanging("  + ", m, cols)...)
+		}
+		if item.Notes != "" {
+			add(hanging("  * ", item.Notes, cols)...)
+		}
+	}
+	add(rule)
+
+	add(columns("Subtotal", Amount(r.Subtotal), cols))
+	if r.Discount > 0 {
+		add(columns("Discount", "-"+Amount(r.Discount), cols))
+	}
+	add(columns(fmt.Sprintf("Tax (%s%%)", trimZeros(r.TaxPercentage)), Amount(r.TaxAmount), cols))
+	add(columns("TOTAL", Amount(r.Total), cols))
+	add(rule)
+
+	for _, p := range r.Payments {
+		label := p.Method
+		if p.Type != "payment" {
+			label = fmt.Sprintf("%s (%s)", p.Method, p.Type)
+		}
+		add(columns(label, Amount(p.Amount), cols))
+	}
+	add(columns("Paid", Amount(r.Paid), cols))
+	if r.Refunded > 0 {
+		add(columns("Refunded", Amount(r.Refunded), cols))
+	}
+	add(columns("Change", Amount(r.Change), cols))
+
+	if len(r.Footer) > 0 {
+		add(rule)
+		for _, l := range r.Footer {
+			add(wrapCenter(l, cols)...)
+		}
+	}
+
+	return lines
+}
+
+// Amount formats money with thousands separators and two decimals.
+func Amount(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	cents := int64(math.Round(v * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return fmt.Sprintf("%s%s.%02d", sign, b.String(), cents%100)
+}
+
+func trimZeros(v float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
+
+// columns puts left and right on one line, shortening left when both do not fit.
+func columns(left, right string, cols int) string {
+	space := cols - utf8.RuneCountInString(right) - 1
+	if space < 1 {
+		return truncate(right, cols)
+	}
+	left = truncate(left, space)
+	return left + strings.Repeat(" ", cols-utf8.RuneCountInString(left)-utf8.RuneCountInString(right)) + right
+}
+
+func center(s string, cols int) string {
+	s = truncate(s, cols)
+	pad := (cols - utf8.RuneCountInString(s)) / 2
+	return strings.Repeat(" ", pad) + s
+}
+
+func wrapCenter(s string, cols int) []string {
+	lines := wrap(s, cols)
+	for i, l := range lines {
+		lines[i] = center(l, cols)
+	}
+	return lines
+}
+
+// wrap breaks s on spaces so that no line is longer than cols; words longer
+// than a line are split.
+func wrap(s string, cols int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for utf8.RuneCountInString(word) > cols {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:cols]))
+			word = string(r[cols:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= cols:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// hanging wraps text after prefix, indenting continuation lines to match.
+func hanging(prefix, text string, cols int) []string {
+	width := utf8.RuneCountInString(prefix)
+	lines := wrap(text, cols-width)
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", width) + lines[i]
+		}
+	}
+	return lines
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
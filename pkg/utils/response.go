@@ -20,13 +20,25 @@ func ResponseSuccess(c *gin.Context, code int, message string, data any) {
 	c.JSON(code, response)
 }
 
-func ResponseFailed(c *gin.Context, code int, message string, errors any) {
+// ResponseError answers with the status and code of err. The message is
+// err's own text, including any detail wrapped around it; errors the API
+// does not know about are answered as ErrInternal.
+func ResponseError(c *gin.Context, err error) {
+	apiErr := AsError(err)
+	message := apiErr.Message
+	if apiErr != ErrInternal {
+		message = err.Error()
+	}
+
 	response := Reponse{
 		Status:  false,
 		Message: message,
-		Errors:  errors,
+		Errors: ErrorBody{
+			Code:   apiErr.Code,
+			Fields: apiErr.Fields,
+		},
 	}
-	c.JSON(code, response)
+	c.JSON(apiErr.Status, response)
 }
 
 func ResponsePagination(c *gin.Context, code int, message string, data any, pagination interface{}) {
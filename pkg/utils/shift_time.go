@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -12,9 +13,7 @@ func BuildShiftTime(
 	timeStr string,
 	loc *time.Location,
 ) time.Time {
-	parts := strings.Split(timeStr, ".")
-	hour, _ := strconv.Atoi(parts[0])
-	min, _ := strconv.Atoi(parts[1])
+	hour, min, _ := ParseClock(timeStr)
 
 	// ISO week starts on Monday
 	firstDay := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
@@ -34,3 +33,57 @@ func BuildShiftTime(
 		loc,
 	).UTC()
 }
+
+// ParseClock parses "HH:MM" (or the older "HH.MM") into hour and minute.
+func ParseClock(timeStr string) (int, int, error) {
+	parts := strings.FieldsFunc(timeStr, func(r rune) bool { return r == ':' || r == '.' })
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidTimeFormat, timeStr)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidTimeFormat, timeStr)
+	}
+	min, err := strconv.Atoi(parts[1])
+	if err != nil || min < 0 || min > 59 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidTimeFormat, timeStr)
+	}
+
+	return hour, min, nil
+}
+
+// ShiftWindow places start and end clock times on the given day. An end time
+// at or before the start time is treated as finishing the next day.
+func ShiftWindow(date time.Time, startStr, endStr string, loc *time.Location) (time.Time, time.Time, error) {
+	sh, sm, err := ParseClock(startStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	eh, em, err := ParseClock(endStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, sh, sm, 0, 0, loc)
+	end := time.Date(y, m, d, eh, em, 0, 0, loc)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start.UTC(), end.UTC(), nil
+}
+
+// LoadLocation returns the named time zone, falling back to the server's
+// local zone when the name is empty or unknown.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 
 	"github.com/google/uuid"
@@ -17,3 +18,19 @@ func GenerateRandomToken(length int) (uuid.UUID, error) {
 	token := uuid.MustParse(hex.EncodeToString(bytes))
 	return token, nil
 }
+
+// GenerateSecureToken returns length random bytes as a hex string.
+func GenerateSecureToken(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// HashToken returns the SHA-256 of a token so it can be stored and still be
+// looked up, unlike a salted password hash.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,22 +2,10 @@ package utils
 
 import (
 	"crypto/rand"
-	"encoding/base64"
 	"math/big"
 	"time"
 )
 
-func GenerateRandomString(length int) (string, error) {
-	bytes := make([]byte, length)
-	_, err := rand.Read(bytes)
-	if err != nil {
-		return "", err
-	}
-
-	encoded := base64.URLEncoding.EncodeToString(bytes)
-	return encoded[:length], nil
-}
-
 // GenerateOTP generates a cryptographically secure numeric OTP of a given length.
 func GenerateOTP(length int) (string, error) {
 	const digits = "0123456789"
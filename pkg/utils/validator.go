@@ -1,57 +1,130 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// FieldError points at one input field at fault. Code is the rule it broke,
+// such as "required" or "min", and Param the rule's argument.
 type FieldError struct {
 	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Param   string `json:"param,omitempty"`
 	Message string `json:"message"`
 }
 
-// validator object struct message
-func ValidateErrors(data any) ([]FieldError, error) {
-	validate := validator.New()
+var validate = newValidator()
 
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// Name fields the way clients send them
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(f.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return f.Name
+	})
+	return v
+}
+
+// Validate checks data against its validate tags. A failure is
+// ErrValidationFailed with a detail for each field at fault.
+func Validate(data any) error {
 	err := validate.Struct(data)
 	if err == nil {
-		return nil, nil
+		return nil
 	}
 
-	var errors []FieldError
-
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, err := range validationErrors {
-			var message string
-			switch err.Tag() {
-			case "required":
-				message = fmt.Sprintf("%s is required", err.Field())
-			case "email":
-				message = "Please enter a valid email format"
-			case "gte":
-				message = fmt.Sprintf("%s must be a non-negative number", err.Field())
-			case "min":
-				message = fmt.Sprintf("%s must be at least %s characters long", err.Field(), err.Param())
-			case "eqfield":
-				message = fmt.Sprintf("%s must match %s", err.Field(), err.Param())
-			case "oneof":
-				message = fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param())
-			case "len":
-				message = fmt.Sprintf("%s must be exactly %s characters", err.Field(), err.Param())
-			default:
-				message = fmt.Sprintf("%s is invalid", err.Field())
-			}
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return ErrValidationFailed.WithFields(fieldErrors(validationErrors)...)
+	}
+
+	// Fallback: return original error if not a validation error
+	return err
+}
+
+// InvalidRequest describes a body or query that could not be bound. When
+// a value has the wrong type or breaks a binding rule, the field is named.
+func InvalidRequest(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return ErrInvalidRequest.WithFields(fieldErrors(validationErrors)...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return ErrInvalidRequest.WithFields(FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Param:   typeErr.Type.String(),
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		})
+	}
 
-			errors = append(errors, FieldError{
-				Field:   err.Field(),
-				Message: message,
-			})
+	return ErrInvalidRequest
+}
+
+func fieldErrors(validationErrors validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, err := range validationErrors {
+		// Drop the struct name, keep the path inside it
+		field := err.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
 		}
-		return errors, err
+
+		var message string
+		switch err.Tag() {
+		case "required":
+			message = fmt.Sprintf("%s is required", field)
+		case "email":
+			message = "Please enter a valid email format"
+		case "gte":
+			message = fmt.Sprintf("%s must be a non-negative number", field)
+		case "min":
+			message = fmt.Sprintf("%s must be at least %s%s", field, err.Param(), lengthUnit(err.Kind()))
+		case "max":
+			message = fmt.Sprintf("%s must be at most %s%s", field, err.Param(), lengthUnit(err.Kind()))
+		case "eqfield":
+			message = fmt.Sprintf("%s must match %s", field, err.Param())
+		case "oneof":
+			message = fmt.Sprintf("%s must be one of: %s", field, err.Param())
+		case "len":
+			message = fmt.Sprintf("%s must be exactly %s characters", field, err.Param())
+		default:
+			message = fmt.Sprintf("%s is invalid", field)
+		}
+
+		fields = append(fields, FieldError{
+			Field:   field,
+			Code:    err.Tag(),
+			Param:   err.Param(),
+			Message: message,
+		})
 	}
+	return fields
+}
 
-	// Fallback: return original error if not a validation error
-	return nil, err
+// lengthUnit is what min and max count for a value of kind k.
+func lengthUnit(k reflect.Kind) string {
+	switch k {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
 }